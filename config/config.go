@@ -2,6 +2,7 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	coreGrpcClient "github.com/cnc-csku/task-nexus-go-lib/grpcclient"
@@ -9,19 +10,28 @@ import (
 )
 
 type Config struct {
-	ServiceName  string                          `env:"SERVICE_NAME"`
-	AllowOrigins []string                        `env:"ALLOW_ORIGINS" envSeparator:","`
-	RestServer   RestServerConfig                `envPrefix:"REST_SERVER_"`
-	MongoDB      MongoDBConfig                   `envPrefix:"MONGO_"`
-	GrpcServer   GrpcServerConfig                `envPrefix:"GRPC_SERVER_"`
-	GrpcClient   coreGrpcClient.GrpcClientConfig `envPrefix:"GRPC_CLIENT_"`
-	OllamaClient OllamaClientConfig              `envPrefix:"OLLAMA_CLIENT_"`
-	GeminiClient GeminiClientConfig              `envPrefix:"GEMINI_CLIENT_"`
-	MinioClient  MinioClientConfig               `envPrefix:"MINIO_CLIENT_"`
-	JWT          JWT                             `envPrefix:"JWT_"`
-	Redis        RedisConfig                     `envPrefix:"REDIS_"`
-	Cache        CacheConfig                     `envPrefix:"CACHE_"`
-	LogFormat    string                          `env:"LOG_FORMAT"`
+	ServiceName   string                          `env:"SERVICE_NAME"`
+	AllowOrigins  []string                        `env:"ALLOW_ORIGINS" envSeparator:","`
+	RestServer    RestServerConfig                `envPrefix:"REST_SERVER_"`
+	MongoDB       MongoDBConfig                   `envPrefix:"MONGO_"`
+	GrpcServer    GrpcServerConfig                `envPrefix:"GRPC_SERVER_"`
+	GrpcClient    coreGrpcClient.GrpcClientConfig `envPrefix:"GRPC_CLIENT_"`
+	OllamaClient  OllamaClientConfig              `envPrefix:"OLLAMA_CLIENT_"`
+	GeminiClient  GeminiClientConfig              `envPrefix:"GEMINI_CLIENT_"`
+	LLM           LLMConfig                       `envPrefix:"LLM_"`
+	MinioClient   MinioClientConfig               `envPrefix:"MINIO_CLIENT_"`
+	JWT           JWT                             `envPrefix:"JWT_"`
+	Redis         RedisConfig                     `envPrefix:"REDIS_"`
+	Cache         CacheConfig                     `envPrefix:"CACHE_"`
+	Mail          MailConfig                      `envPrefix:"MAIL_"`
+	Job           JobConfig                       `envPrefix:"JOB_"`
+	OIDC          OIDCConfig                      `envPrefix:"OIDC_"`
+	AuthRateLimit AuthRateLimitConfig             `envPrefix:"AUTH_RATE_LIMIT_"`
+	APIRateLimit  APIRateLimitConfig              `envPrefix:"API_RATE_LIMIT_"`
+	FrontendURL   string                          `env:"FRONTEND_URL"`
+	// AdminEmails lists the users allowed to deactivate, anonymise and export other users, and to edit prompt templates
+	AdminEmails []string `env:"ADMIN_EMAILS" envSeparator:","`
+	LogFormat   string   `env:"LOG_FORMAT"`
 }
 
 type RestServerConfig struct {
@@ -41,15 +51,37 @@ type GrpcServerConfig struct {
 }
 
 type GeminiClientConfig struct {
-	ApiKey string `env:"API_KEY"`
-	Model  string `env:"MODEL"`
+	ApiKey         string `env:"API_KEY"`
+	Model          string `env:"MODEL"`
+	EmbeddingModel string `env:"EMBEDDING_MODEL" envDefault:"text-embedding-004"`
 }
 
 type OllamaClientConfig struct {
-	Endpoint      string `env:"ENDPOINT"`
-	UseProxy      bool   `env:"USE_PROXY"`
-	HttpProxyHost string `env:"HTTP_PROXY_HOST"`
-	HttpProxyPort string `env:"HTTP_PROXY_PORT"`
+	Endpoint string `env:"ENDPOINT"`
+	Model    string `env:"MODEL" envDefault:"llama3.2"`
+	// EmbeddingModel must be pulled on the Ollama server, like the completion model
+	EmbeddingModel string `env:"EMBEDDING_MODEL" envDefault:"nomic-embed-text"`
+	UseProxy       bool   `env:"USE_PROXY"`
+	HttpProxyHost  string `env:"HTTP_PROXY_HOST"`
+	HttpProxyPort  string `env:"HTTP_PROXY_PORT"`
+	// Timeout bounds every request to the Ollama server, it must exceed LLM_STREAM_TIMEOUT as streams use the same client
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"3m"`
+}
+
+type LLMConfig struct {
+	// Provider selects the model used by the AI features, either "gemini", "ollama" or "fake".
+	// The model is configured on the provider's client config.
+	Provider string `env:"PROVIDER" envDefault:"gemini"`
+	// DuplicateThreshold is the cosine similarity of task embeddings above which tasks are possible duplicates
+	DuplicateThreshold float64 `env:"DUPLICATE_THRESHOLD" envDefault:"0.85"`
+	// DuplicateLimit is the number of possible duplicates returned for a task
+	DuplicateLimit int `env:"DUPLICATE_LIMIT" envDefault:"5"`
+	// StreamConcurrencyPerUser is the number of description generations, streamed or not, a user may run at the same time
+	StreamConcurrencyPerUser int `env:"STREAM_CONCURRENCY_PER_USER" envDefault:"2"`
+	// StreamTimeout stops a description generation, streamed or not, that takes longer
+	StreamTimeout time.Duration `env:"STREAM_TIMEOUT" envDefault:"2m"`
+	// EmbeddingTimeout bounds embedding a task, and the duplicate lookup that follows when a task is created
+	EmbeddingTimeout time.Duration `env:"EMBEDDING_TIMEOUT" envDefault:"10s"`
 }
 
 type MinioClientConfig struct {
@@ -63,12 +95,89 @@ type MinioClientConfig struct {
 }
 
 type JWT struct {
-	AccessTokenSecret  string `env:"ACCESS_TOKEN_SECRET"`
-	RefreshTokenSecret string `env:"REFRESH_TOKEN_SECRET"`
+	AccessTokenSecret            string `env:"ACCESS_TOKEN_SECRET"`
+	RefreshTokenSecret           string `env:"REFRESH_TOKEN_SECRET"`
+	InvitationTokenSecret        string `env:"INVITATION_TOKEN_SECRET"`
+	PasswordResetTokenSecret     string `env:"PASSWORD_RESET_TOKEN_SECRET"`
+	EmailVerificationTokenSecret string `env:"EMAIL_VERIFICATION_TOKEN_SECRET"`
+	TwoFactorChallengeSecret     string `env:"TWO_FACTOR_CHALLENGE_SECRET"`
 }
 
 type CacheConfig struct {
 	GlobalConfigTTL string `env:"GLOBAL_CONFIG_TTL"`
+	// TaskSummaryTTL is how long a generated task summary is kept, a new comment replaces it earlier
+	TaskSummaryTTL time.Duration `env:"TASK_SUMMARY_TTL" envDefault:"168h"`
+}
+
+type MailConfig struct {
+	// Driver selects the mail sender, either "smtp" or "log"
+	Driver string     `env:"DRIVER" envDefault:"log"`
+	From   string     `env:"FROM"`
+	SMTP   SMTPConfig `envPrefix:"SMTP_"`
+	// OutboxDir makes the log driver also write each mail to a file in this directory
+	OutboxDir string `env:"OUTBOX_DIR"`
+}
+
+type SMTPConfig struct {
+	Host     string `env:"HOST"`
+	Port     string `env:"PORT"`
+	Username string `env:"USERNAME"`
+	Password string `env:"PASSWORD"`
+}
+
+type JobConfig struct {
+	InvitationExpiryInterval time.Duration `env:"INVITATION_EXPIRY_INTERVAL" envDefault:"1h"`
+}
+
+type OIDCConfig struct {
+	// IssuerURL is used to discover the provider endpoints, OIDC login is disabled when it is empty
+	IssuerURL string `env:"ISSUER_URL"`
+	// AllowedIssuers lists the accepted "iss" claims of ID tokens, it defaults to IssuerURL
+	AllowedIssuers []string      `env:"ALLOWED_ISSUERS" envSeparator:","`
+	ClientID       string        `env:"CLIENT_ID"`
+	ClientSecret   string        `env:"CLIENT_SECRET"`
+	RedirectURL    string        `env:"REDIRECT_URL"`
+	Scopes         []string      `env:"SCOPES" envSeparator:"," envDefault:"openid,email,profile"`
+	StateTTL       time.Duration `env:"STATE_TTL" envDefault:"10m"`
+}
+
+// AuthRateLimitConfig holds the brute-force protection of each unauthenticated auth route.
+type AuthRateLimitConfig struct {
+	Login    AuthRateLimitRouteConfig `envPrefix:"LOGIN_"`
+	Register AuthRateLimitRouteConfig `envPrefix:"REGISTER_"`
+	// TwoFactor counts failures per user, taken from the challenge token, its AccountField is ignored
+	TwoFactor AuthRateLimitRouteConfig `envPrefix:"TWO_FACTOR_"`
+}
+
+type AuthRateLimitRouteConfig struct {
+	Enabled bool `env:"ENABLED" envDefault:"true"`
+	// IPLimit is the number of attempts allowed from one IP address within Window
+	IPLimit int `env:"IP_LIMIT" envDefault:"20"`
+	// AccountField is the JSON body field that identifies the account, e.g. "email"
+	AccountField string `env:"ACCOUNT_FIELD" envDefault:"email"`
+	// MaxAccountFailures is the number of failed attempts on one account within Window before it is locked
+	MaxAccountFailures int           `env:"MAX_ACCOUNT_FAILURES" envDefault:"5"`
+	Window             time.Duration `env:"WINDOW" envDefault:"15m"`
+	// LockoutDuration is how long a locked account is rejected, it unlocks by itself afterwards
+	LockoutDuration time.Duration `env:"LOCKOUT_DURATION" envDefault:"15m"`
+}
+
+// APIRateLimitConfig holds the token bucket of each rate limited route group,
+// every user or access token has its own bucket per group.
+type APIRateLimitConfig struct {
+	Enabled    bool                    `env:"ENABLED" envDefault:"true"`
+	Tasks      APIRateLimitGroupConfig `envPrefix:"TASKS_"`
+	TaskSearch APIRateLimitGroupConfig `envPrefix:"TASK_SEARCH_"`
+	Reports    APIRateLimitGroupConfig `envPrefix:"REPORTS_"`
+	LLM        APIRateLimitGroupConfig `envPrefix:"LLM_"`
+}
+
+// APIRateLimitGroupConfig disables the limit of the group when either value is not positive.
+type APIRateLimitGroupConfig struct {
+	// Capacity is the number of requests that can be made in a burst
+	Capacity int `env:"CAPACITY" envDefault:"60"`
+	// RefillPerSecond is the number of requests regained every second
+	RefillPerSecond float64 `env:"REFILL_PER_SECOND" envDefault:"1"`
 }
 
 type RedisConfig struct {
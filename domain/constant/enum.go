@@ -26,6 +26,13 @@ const (
 	InvitationActionDecline = "DECLINE"
 )
 
+const (
+	AttributeMigrationActionRename        = "RENAME"
+	AttributeMigrationActionConvert       = "CONVERT"
+	AttributeMigrationActionRenameConvert = "RENAME_CONVERT"
+	AttributeMigrationActionRemove        = "REMOVE"
+)
+
 const (
 	SearchTaskParamsTaskBacklog          = "BACKLOG" // WITH_NO_SPRINT
 	SearchTaskParamsTaskWithNoEpicFilter = "WITH_NO_EPIC"
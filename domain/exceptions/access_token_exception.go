@@ -0,0 +1,11 @@
+package exceptions
+
+import "github.com/pkg/errors"
+
+var (
+	ErrAccessTokenNotFound              = errors.New("access token not found")
+	ErrInvalidAccessTokenScope          = errors.New("invalid access token scope")
+	ErrAccessTokenScopeDenied           = errors.New("access token scopes do not allow this request")
+	ErrServiceAccountNotFound           = errors.New("service account not found")
+	ErrServiceAccountCannotManageTokens = errors.New("service accounts cannot manage personal access tokens")
+)
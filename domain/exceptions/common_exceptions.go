@@ -7,4 +7,5 @@ var (
 	ErrInvalidReqPayload   = errors.New("invalid request payload")
 	ErrPermissionDenied    = errors.New("permission denied")
 	ErrInvalidFileCategory = errors.New("invalid file category")
+	ErrRateLimitExceeded   = errors.New("rate limit exceeded, please slow down")
 )
@@ -7,4 +7,10 @@ var (
 	ErrInvitationNotFound         = errors.New("invitation not found")
 	ErrInvitationAlreadyResponded = errors.New("invitation already responded")
 	ErrInvalidInvitationAction    = errors.New("invalid invitation action")
+	ErrInvalidInvitationToken     = errors.New("invalid invitation token")
+	ErrInvitationEmailMismatch    = errors.New("email does not match the invitation")
+	ErrSendInvitationMailFailed   = errors.New("failed to send invitation email")
+	ErrInvitationExpired          = errors.New("invitation has expired")
+	ErrInvitationNotPending       = errors.New("invitation is not pending")
+	ErrInvitationCannotBeResent   = errors.New("invitation cannot be resent")
 )
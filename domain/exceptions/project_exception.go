@@ -17,4 +17,16 @@ var (
 	ErrWorkflowUsedByTask         = errors.New("workflow is used by task")
 	ErrMemberNotFoundInProject    = errors.New("member not found in project")
 	ErrInvalidProjectSetupStatus  = errors.New("invalid project setup status")
+	ErrInvalidStatusMapping       = errors.New("invalid status mapping")
+	ErrInvalidPreviousAttribute   = errors.New("invalid previous attribute name")
+	ErrDuplicateAttributeName     = errors.New("duplicate attribute name")
+	ErrProjectArchived            = errors.New("project is archived")
+	ErrProjectAlreadyArchived     = errors.New("project is already archived")
+	ErrProjectNotArchived         = errors.New("project is not archived")
+	ErrInvalidConfirmationToken   = errors.New("invalid or expired confirmation token")
+	ErrCannotTransferToSelf       = errors.New("cannot transfer ownership to current owner")
+	ErrInvalidProjectMemberRole   = errors.New("invalid project member role")
+	ErrCannotModifyProjectOwner   = errors.New("cannot remove or change the role of the project owner")
+	ErrInvalidReassignee          = errors.New("invalid reassignee")
+	ErrInvalidProjectPermission   = errors.New("invalid project permission")
 )
@@ -0,0 +1,10 @@
+package exceptions
+
+import "github.com/pkg/errors"
+
+var (
+	ErrProjectTemplateNotFound          = errors.New("project template not found")
+	ErrProjectTemplateNameAlreadyExists = errors.New("project template name already exists")
+	ErrProjectNotInWorkspace            = errors.New("project not in workspace")
+	ErrMultipleProjectSourcesProvided   = errors.New("only one of template or source project can be provided")
+)
@@ -0,0 +1,11 @@
+package exceptions
+
+import "github.com/pkg/errors"
+
+var (
+	ErrInvalidPromptTemplateKey      = errors.New("invalid prompt template key")
+	ErrInvalidPromptTemplate         = errors.New("invalid prompt template")
+	ErrPromptTemplateVersionNotFound = errors.New("prompt template version not found")
+	ErrPromptTemplateVersionInUse    = errors.New("prompt template version is already in use")
+	ErrPromptTemplateVersionConflict = errors.New("prompt template was changed at the same time, please try again")
+)
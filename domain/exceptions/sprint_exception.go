@@ -6,4 +6,7 @@ var (
 	ErrSprintNotFound        = errors.New("sprint not found")
 	ErrInvalidSprintStatus   = errors.New("invalid sprint status")
 	ErrDeletedSprintHasTasks = errors.New("deleted sprint has tasks")
+	ErrSprintNotStarted      = errors.New("sprint has not started")
+
+	ErrInvalidGeneratedSprintSummary = errors.New("failed to generate a valid sprint summary, please try again")
 )
@@ -14,4 +14,14 @@ var (
 	ErrNotAllTasksIsDone                   = errors.New("not all tasks is done")
 	ErrDueDateBeforeStartDate              = errors.New("due date before start date")
 	ErrOnlyTaskInTheSameLevelCanChangeType = errors.New("only task in the same level can change type")
+	ErrInvalidGeneratedDescription         = errors.New("failed to generate a valid description, please try again")
+	ErrInvalidGeneratedSubTasks            = errors.New("failed to generate valid sub-tasks, please try again")
+	ErrInvalidGeneratedSearchFilter        = errors.New("failed to understand the search, please rephrase it")
+	ErrInvalidGeneratedPointEstimate       = errors.New("failed to generate a valid point estimate, please try again")
+	ErrNoPointEstimationHistory            = errors.New("no done tasks with points to estimate from")
+	ErrInvalidGeneratedTaskSummary         = errors.New("failed to generate a valid task summary, please try again")
+	ErrNothingToSummarize                  = errors.New("the task has no description or comments to summarize")
+	ErrTooManyConcurrentGenerations        = errors.New("too many generations in progress, please wait for one to finish")
+	ErrGenerationTimedOut                  = errors.New("the generation took too long, please try again")
+	ErrPositionNotInProject                = errors.New("position is not defined in the project")
 )
@@ -7,4 +7,37 @@ var (
 	ErrInvalidCredentials = errors.New("invalid username or password")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrUserNotFound       = errors.New("user not found")
+
+	ErrInvalidCurrentPassword          = errors.New("current password is incorrect")
+	ErrInvalidPasswordResetToken       = errors.New("invalid or expired password reset token")
+	ErrInvalidEmailVerificationToken   = errors.New("invalid or expired email verification token")
+	ErrEmailAlreadyVerified            = errors.New("email already verified")
+	ErrSessionRevoked                  = errors.New("session has been revoked")
+	ErrSendPasswordResetMailFailed     = errors.New("failed to send password reset email")
+	ErrSendEmailVerificationMailFailed = errors.New("failed to send email verification email")
+
+	ErrOIDCNotConfigured    = errors.New("single sign-on is not configured")
+	ErrInvalidOIDCState     = errors.New("invalid or expired single sign-on state")
+	ErrOIDCLoginFailed      = errors.New("single sign-on login failed")
+	ErrOIDCEmailMissing     = errors.New("identity provider did not return an email")
+	ErrOIDCEmailNotVerified = errors.New("email is not verified by the identity provider")
+
+	ErrTwoFactorAlreadyEnabled         = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled            = errors.New("two-factor authentication enrolment has not been started")
+	ErrTwoFactorNotEnabled             = errors.New("two-factor authentication is not enabled")
+	ErrInvalidTwoFactorCode            = errors.New("invalid two-factor authentication code")
+	ErrInvalidTwoFactorChallengeToken  = errors.New("invalid or expired two-factor challenge token")
+	ErrTwoFactorRequiredByWorkspace    = errors.New("two-factor authentication is required by a workspace you are a member of")
+	ErrTwoFactorRequiredForWorkspace   = errors.New("this workspace requires two-factor authentication, enable it to continue")
+	ErrTwoFactorRequiredToEnforceOwner = errors.New("enable two-factor authentication on your own account before requiring it for the workspace")
+
+	ErrTooManyAuthAttempts      = errors.New("too many attempts, please try again later")
+	ErrAccountTemporarilyLocked = errors.New("account is temporarily locked after too many failed attempts, please try again later")
+
+	ErrUserDeactivated          = errors.New("user account has been deactivated")
+	ErrUserAlreadyDeactivated   = errors.New("user account is already deactivated")
+	ErrUserNotDeactivated       = errors.New("user account is not deactivated")
+	ErrUserAlreadyAnonymized    = errors.New("user account has already been anonymized")
+	ErrCannotDeactivateYourself = errors.New("you cannot deactivate or anonymize your own account")
+	ErrInvalidExportFormat      = errors.New("invalid export format")
 )
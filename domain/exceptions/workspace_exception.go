@@ -7,4 +7,9 @@ var (
 	ErrMemberNotFoundInWorkspace    = errors.New("member not found in workspace")
 	ErrRequesterNotFoundInWorkspace = errors.New("requester not found in workspace")
 	ErrMemberAlreadyInWorkspace     = errors.New("member already in workspace")
+	ErrWorkspaceNotFound            = errors.New("workspace not found")
+	ErrWorkspaceNotSetup            = errors.New("workspace not setup")
+	ErrInvalidWorkspaceMemberRole   = errors.New("invalid workspace member role")
+	ErrCannotModifyWorkspaceOwner   = errors.New("cannot modify workspace owner")
+	ErrMemberOwnsProjects           = errors.New("member still owns projects in workspace")
 )
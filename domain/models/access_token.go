@@ -0,0 +1,80 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AccessTokenPrefix marks personal access tokens and service account tokens, so the auth middleware
+// can tell them apart from JWTs.
+const AccessTokenPrefix = "tnx_"
+
+// AccessToken is a long-lived token for scripts, owned by a user or a service account.
+// Only the hash of the token is stored.
+type AccessToken struct {
+	ID          bson.ObjectID      `bson:"_id" json:"id"`
+	UserID      bson.ObjectID      `bson:"user_id" json:"userId"`
+	Name        string             `bson:"name" json:"name"`
+	TokenHash   string             `bson:"token_hash" json:"-"`
+	TokenPrefix string             `bson:"token_prefix" json:"tokenPrefix"`
+	Scopes      []AccessTokenScope `bson:"scopes" json:"scopes"`
+	ExpiresAt   time.Time          `bson:"expires_at" json:"expiresAt"`
+	LastUsedAt  *time.Time         `bson:"last_used_at" json:"lastUsedAt"`
+	RevokedAt   *time.Time         `bson:"revoked_at" json:"revokedAt"`
+	CreatedAt   time.Time          `bson:"created_at" json:"createdAt"`
+	CreatedBy   bson.ObjectID      `bson:"created_by" json:"createdBy"`
+}
+
+func (a *AccessToken) IsActive() bool {
+	return a.RevokedAt == nil && time.Now().Before(a.ExpiresAt)
+}
+
+// Allows reports whether any scope of the token grants the request, routePath is the matched Echo route.
+func (a *AccessToken) Allows(method string, routePath string) bool {
+	for _, scope := range a.Scopes {
+		if scope.Allows(method, routePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type AccessTokenScope string
+
+const (
+	// AccessTokenScopeReadOnly grants every read request
+	AccessTokenScopeReadOnly AccessTokenScope = "read-only"
+	// AccessTokenScopeTasksWrite grants reading and changing tasks and their comments
+	AccessTokenScopeTasksWrite AccessTokenScope = "tasks:write"
+	// AccessTokenScopeReportsRead grants reading project reports
+	AccessTokenScopeReportsRead AccessTokenScope = "reports:read"
+)
+
+func (a AccessTokenScope) String() string {
+	return string(a)
+}
+
+func (a AccessTokenScope) IsValid() bool {
+	switch a {
+	case AccessTokenScopeReadOnly, AccessTokenScopeTasksWrite, AccessTokenScopeReportsRead:
+		return true
+	}
+	return false
+}
+
+func (a AccessTokenScope) Allows(method string, routePath string) bool {
+	isRead := method == "GET" || method == "HEAD"
+
+	switch a {
+	case AccessTokenScopeReadOnly:
+		return isRead
+	case AccessTokenScopeTasksWrite:
+		return strings.Contains(routePath, "/tasks/v1")
+	case AccessTokenScopeReportsRead:
+		return isRead && strings.Contains(routePath, "/reports/v1")
+	}
+	return false
+}
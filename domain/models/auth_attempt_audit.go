@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AuthAttemptAudit records a failed or rejected attempt on a rate limited auth route.
+type AuthAttemptAudit struct {
+	ID         bson.ObjectID           `bson:"_id" json:"id"`
+	Route      string                  `bson:"route" json:"route"`
+	Account    string                  `bson:"account" json:"account"`
+	IPAddress  string                  `bson:"ip_address" json:"ipAddress"`
+	UserAgent  string                  `bson:"user_agent" json:"userAgent"`
+	Outcome    AuthAttemptAuditOutcome `bson:"outcome" json:"outcome"`
+	StatusCode int                     `bson:"status_code" json:"statusCode"`
+	CreatedAt  time.Time               `bson:"created_at" json:"createdAt"`
+}
+
+type AuthAttemptAuditOutcome string
+
+const (
+	// AuthAttemptAuditOutcomeFailed is an attempt the route rejected, e.g. a wrong password
+	AuthAttemptAuditOutcomeFailed AuthAttemptAuditOutcome = "FAILED"
+	// AuthAttemptAuditOutcomeAccountLocked is the failed attempt that locked the account
+	AuthAttemptAuditOutcomeAccountLocked AuthAttemptAuditOutcome = "ACCOUNT_LOCKED"
+	// AuthAttemptAuditOutcomeRateLimited is an attempt rejected because the IP address exceeded its limit
+	AuthAttemptAuditOutcomeRateLimited AuthAttemptAuditOutcome = "RATE_LIMITED"
+	// AuthAttemptAuditOutcomeRejectedLocked is an attempt rejected because the account is locked
+	AuthAttemptAuditOutcomeRejectedLocked AuthAttemptAuditOutcome = "REJECTED_LOCKED"
+)
+
+func (a AuthAttemptAuditOutcome) String() string {
+	return string(a)
+}
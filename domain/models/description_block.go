@@ -0,0 +1,55 @@
+package models
+
+// DescriptionBlock is a block of the BlockNote editor used for task descriptions.
+// Only the block types the description editor supports are listed.
+type DescriptionBlock struct {
+	ID       string                     `json:"id"`
+	Type     DescriptionBlockType       `json:"type"`
+	Props    DescriptionBlockProps      `json:"props"`
+	Content  []DescriptionInlineContent `json:"content"`
+	Children []DescriptionBlock         `json:"children"`
+}
+
+type DescriptionBlockType string
+
+const (
+	DescriptionBlockTypeParagraph        DescriptionBlockType = "paragraph"
+	DescriptionBlockTypeHeading          DescriptionBlockType = "heading"
+	DescriptionBlockTypeBulletListItem   DescriptionBlockType = "bulletListItem"
+	DescriptionBlockTypeNumberedListItem DescriptionBlockType = "numberedListItem"
+)
+
+func (t DescriptionBlockType) String() string {
+	return string(t)
+}
+
+func (t DescriptionBlockType) IsValid() bool {
+	switch t {
+	case DescriptionBlockTypeParagraph, DescriptionBlockTypeHeading, DescriptionBlockTypeBulletListItem, DescriptionBlockTypeNumberedListItem:
+		return true
+	}
+	return false
+}
+
+type DescriptionBlockProps struct {
+	TextColor       string `json:"textColor"`
+	BackgroundColor string `json:"backgroundColor"`
+	TextAlignment   string `json:"textAlignment"`
+	// Level is only set on headings, from 1 to 3
+	Level int `json:"level,omitempty"`
+}
+
+type DescriptionInlineContent struct {
+	// Type is always "text", links and mentions are not generated
+	Type   string                `json:"type"`
+	Text   string                `json:"text"`
+	Styles DescriptionTextStyles `json:"styles"`
+}
+
+type DescriptionTextStyles struct {
+	Bold      bool `json:"bold,omitempty"`
+	Italic    bool `json:"italic,omitempty"`
+	Underline bool `json:"underline,omitempty"`
+	Strike    bool `json:"strike,omitempty"`
+	Code      bool `json:"code,omitempty"`
+}
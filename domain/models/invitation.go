@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -10,6 +11,7 @@ type Invitation struct {
 	ID            bson.ObjectID    `bson:"_id" json:"id"`
 	WorkspaceID   bson.ObjectID    `bson:"workspace_id" json:"workspaceId"`
 	InviteeUserID bson.ObjectID    `bson:"invitee_user_id" json:"inviteeUserId"`
+	InviteeEmail  *string          `bson:"invitee_email,omitempty" json:"inviteeEmail,omitempty"`
 	Role          InvitationRole   `bson:"role" json:"role"`
 	Status        InvitationStatus `bson:"status" json:"status"`
 	ExpiredAt     time.Time        `bson:"expired_at" json:"expiredAt"`
@@ -45,6 +47,7 @@ const (
 	InvitationStatusAccepted InvitationStatus = "ACCEPTED"
 	InvitationStatusDeclined InvitationStatus = "DECLINED"
 	InvitationStatusExpired  InvitationStatus = "EXPIRED"
+	InvitationStatusRevoked  InvitationStatus = "REVOKED"
 )
 
 func (i InvitationStatus) String() string {
@@ -53,8 +56,15 @@ func (i InvitationStatus) String() string {
 
 func (i InvitationStatus) IsValid() bool {
 	switch i {
-	case InvitationStatusPending, InvitationStatusAccepted, InvitationStatusDeclined, InvitationStatusExpired:
+	case InvitationStatusPending, InvitationStatusAccepted, InvitationStatusDeclined, InvitationStatusExpired, InvitationStatusRevoked:
 		return true
 	}
 	return false
 }
+
+// InvitationTokenClaims is carried by the registration link sent to invitees who do not have an account yet.
+type InvitationTokenClaims struct {
+	InvitationID string `json:"invitationId"`
+	Email        string `json:"email"`
+	jwt.RegisteredClaims
+}
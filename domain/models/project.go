@@ -19,12 +19,44 @@ type Project struct {
 	AttributeTemplates  []ProjectAttributeTemplate `bson:"attributes_templates" json:"attributesTemplates"`
 	Positions           []string                   `bson:"positions" json:"positions"`
 	SetupStatus         ProjectSetupStatus         `bson:"setup_status" json:"setupStatus"`
+	Permissions         ProjectPermissionMatrix    `bson:"permissions" json:"permissions"`
 	CreatedAt           time.Time                  `bson:"created_at" json:"createdAt"`
 	CreatedBy           bson.ObjectID              `bson:"created_by" json:"createdBy"`
 	UpdatedAt           time.Time                  `bson:"updated_at" json:"updatedAt"`
 	UpdatedBy           bson.ObjectID              `bson:"updated_by" json:"updatedBy"`
 }
 
+// ProjectPermissionMatrix maps each permission to the roles that are granted it.
+// Permissions missing from the matrix fall back to GetDefaultProjectPermissions.
+type ProjectPermissionMatrix map[ProjectPermission][]ProjectMemberRole
+
+// HasPermission reports whether the role is granted the permission. The owner is always granted every permission.
+func (p *Project) HasPermission(role ProjectMemberRole, permission ProjectPermission) bool {
+	if role == ProjectMemberRoleOwner {
+		return true
+	}
+
+	for _, grantedRole := range p.GetPermissionMatrix()[permission] {
+		if grantedRole == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetPermissionMatrix returns the effective permission matrix, with defaults filled in.
+func (p *Project) GetPermissionMatrix() ProjectPermissionMatrix {
+	matrix := ProjectPermissionMatrix(GetDefaultProjectPermissions())
+	for permission, roles := range p.Permissions {
+		if permission.IsValid() {
+			matrix[permission] = roles
+		}
+	}
+
+	return matrix
+}
+
 type ProjectStatus string
 
 const (
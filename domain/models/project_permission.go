@@ -0,0 +1,57 @@
+package models
+
+type ProjectPermission string
+
+const (
+	ProjectPermissionUpdateProject          ProjectPermission = "UPDATE_PROJECT"
+	ProjectPermissionArchiveProject         ProjectPermission = "ARCHIVE_PROJECT"
+	ProjectPermissionDeleteProject          ProjectPermission = "DELETE_PROJECT"
+	ProjectPermissionTransferOwnership      ProjectPermission = "TRANSFER_OWNERSHIP"
+	ProjectPermissionManagePositions        ProjectPermission = "MANAGE_POSITIONS"
+	ProjectPermissionManageMembers          ProjectPermission = "MANAGE_MEMBERS"
+	ProjectPermissionManageMemberRoles      ProjectPermission = "MANAGE_MEMBER_ROLES"
+	ProjectPermissionEditWorkflows          ProjectPermission = "EDIT_WORKFLOWS"
+	ProjectPermissionEditAttributeTemplates ProjectPermission = "EDIT_ATTRIBUTE_TEMPLATES"
+	ProjectPermissionCreateSprint           ProjectPermission = "CREATE_SPRINT"
+	ProjectPermissionManageSprints          ProjectPermission = "MANAGE_SPRINTS"
+	ProjectPermissionCreateTask             ProjectPermission = "CREATE_TASK"
+	ProjectPermissionEditTask               ProjectPermission = "EDIT_TASK"
+	ProjectPermissionManageApprovers        ProjectPermission = "MANAGE_APPROVERS"
+	ProjectPermissionApproveTask            ProjectPermission = "APPROVE_TASK"
+	ProjectPermissionComment                ProjectPermission = "COMMENT"
+)
+
+func (p ProjectPermission) String() string {
+	return string(p)
+}
+
+func (p ProjectPermission) IsValid() bool {
+	_, ok := GetDefaultProjectPermissions()[p]
+	return ok
+}
+
+// GetDefaultProjectPermissions returns the roles granted each permission when a project has not configured it.
+func GetDefaultProjectPermissions() map[ProjectPermission][]ProjectMemberRole {
+	owner := []ProjectMemberRole{ProjectMemberRoleOwner}
+	managers := []ProjectMemberRole{ProjectMemberRoleOwner, ProjectMemberRoleModerator}
+	everyone := []ProjectMemberRole{ProjectMemberRoleOwner, ProjectMemberRoleModerator, ProjectMemberRoleMember}
+
+	return map[ProjectPermission][]ProjectMemberRole{
+		ProjectPermissionUpdateProject:          managers,
+		ProjectPermissionArchiveProject:         owner,
+		ProjectPermissionDeleteProject:          owner,
+		ProjectPermissionTransferOwnership:      owner,
+		ProjectPermissionManagePositions:        managers,
+		ProjectPermissionManageMembers:          managers,
+		ProjectPermissionManageMemberRoles:      owner,
+		ProjectPermissionEditWorkflows:          managers,
+		ProjectPermissionEditAttributeTemplates: managers,
+		ProjectPermissionCreateSprint:           managers,
+		ProjectPermissionManageSprints:          managers,
+		ProjectPermissionCreateTask:             everyone,
+		ProjectPermissionEditTask:               everyone,
+		ProjectPermissionManageApprovers:        everyone,
+		ProjectPermissionApproveTask:            everyone,
+		ProjectPermissionComment:                everyone,
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ProjectTemplate struct {
+	ID                 bson.ObjectID              `bson:"_id" json:"id"`
+	WorkspaceID        bson.ObjectID              `bson:"workspace_id" json:"workspaceId"`
+	Name               string                     `bson:"name" json:"name"`
+	Description        string                     `bson:"description" json:"description"`
+	Positions          []string                   `bson:"positions" json:"positions"`
+	Workflows          []ProjectWorkflow          `bson:"workflows" json:"workflows"`
+	AttributeTemplates []ProjectAttributeTemplate `bson:"attributes_templates" json:"attributesTemplates"`
+	Epics              []ProjectTemplateEpic      `bson:"epics" json:"epics"`
+	CreatedAt          time.Time                  `bson:"created_at" json:"createdAt"`
+	CreatedBy          bson.ObjectID              `bson:"created_by" json:"createdBy"`
+	UpdatedAt          time.Time                  `bson:"updated_at" json:"updatedAt"`
+	UpdatedBy          bson.ObjectID              `bson:"updated_by" json:"updatedBy"`
+}
+
+type ProjectTemplateEpic struct {
+	Title       string       `bson:"title" json:"title"`
+	Description string       `bson:"description" json:"description"`
+	Priority    TaskPriority `bson:"priority" json:"priority"`
+}
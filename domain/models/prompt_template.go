@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// PromptTemplate is a version of a prompt sent to the LLM, written with text/template.
+// Versions are never changed, the latest version of a key is the one in use.
+type PromptTemplate struct {
+	ID       bson.ObjectID     `bson:"_id" json:"id"`
+	Key      PromptTemplateKey `bson:"key" json:"key"`
+	Version  int               `bson:"version" json:"version"`
+	Template string            `bson:"template" json:"template"`
+	// RestoredFromVersion is set on versions created by a rollback, version 0 is the built-in template
+	RestoredFromVersion *int          `bson:"restored_from_version,omitempty" json:"restoredFromVersion,omitempty"`
+	CreatedAt           time.Time     `bson:"created_at" json:"createdAt"`
+	CreatedBy           bson.ObjectID `bson:"created_by" json:"createdBy"`
+}
+
+type PromptTemplateKey string
+
+const (
+	PromptTemplateKeyDescription PromptTemplateKey = "DESCRIPTION"
+	PromptTemplateKeySubTasks    PromptTemplateKey = "SUB_TASKS"
+	PromptTemplateKeyTaskSummary PromptTemplateKey = "TASK_SUMMARY"
+)
+
+func (k PromptTemplateKey) String() string {
+	return string(k)
+}
+
+func (k PromptTemplateKey) IsValid() bool {
+	switch k {
+	case PromptTemplateKeyDescription, PromptTemplateKeySubTasks, PromptTemplateKeyTaskSummary:
+		return true
+	}
+	return false
+}
+
+func GetPromptTemplateKeys() []PromptTemplateKey {
+	return []PromptTemplateKey{PromptTemplateKeyDescription, PromptTemplateKeySubTasks, PromptTemplateKeyTaskSummary}
+}
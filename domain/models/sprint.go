@@ -7,17 +7,31 @@ import (
 )
 
 type Sprint struct {
-	ID         bson.ObjectID `bson:"_id" json:"id"`
-	ProjectID  bson.ObjectID `bson:"project_id" json:"projectId"`
-	Title      string        `bson:"title" json:"title"`
-	SprintGoal string        `bson:"sprint_goal" json:"sprintGoal"`
-	Status     SprintStatus  `bson:"status" json:"status"`
-	StartDate  *time.Time    `bson:"start_date" json:"startDate"`
-	EndDate    *time.Time    `bson:"end_date" json:"endDate"`
-	CreatedAt  time.Time     `bson:"created_at" json:"createdAt"`
-	CreatedBy  bson.ObjectID `bson:"created_by" json:"createdBy"`
-	UpdatedAt  time.Time     `bson:"updated_at" json:"updatedAt"`
-	UpdatedBy  bson.ObjectID `bson:"updated_by" json:"updatedBy"`
+	ID         bson.ObjectID  `bson:"_id" json:"id"`
+	ProjectID  bson.ObjectID  `bson:"project_id" json:"projectId"`
+	Title      string         `bson:"title" json:"title"`
+	SprintGoal string         `bson:"sprint_goal" json:"sprintGoal"`
+	Status     SprintStatus   `bson:"status" json:"status"`
+	StartDate  *time.Time     `bson:"start_date" json:"startDate"`
+	EndDate    *time.Time     `bson:"end_date" json:"endDate"`
+	CreatedAt  time.Time      `bson:"created_at" json:"createdAt"`
+	CreatedBy  bson.ObjectID  `bson:"created_by" json:"createdBy"`
+	UpdatedAt  time.Time      `bson:"updated_at" json:"updatedAt"`
+	UpdatedBy  bson.ObjectID  `bson:"updated_by" json:"updatedBy"`
+	Summary    *SprintSummary `bson:"summary,omitempty" json:"summary"`
+}
+
+// SprintSummary is the review of a sprint, drafted by the LLM and edited by the team afterwards.
+type SprintSummary struct {
+	Overview           string         `bson:"overview" json:"overview"`
+	Shipped            []string       `bson:"shipped" json:"shipped"`
+	Slipped            []string       `bson:"slipped" json:"slipped"`
+	Risks              []string       `bson:"risks" json:"risks"`
+	RetroTalkingPoints []string       `bson:"retro_talking_points" json:"retroTalkingPoints"`
+	GeneratedAt        *time.Time     `bson:"generated_at" json:"generatedAt"`
+	GeneratedBy        *bson.ObjectID `bson:"generated_by" json:"generatedBy"`
+	UpdatedAt          time.Time      `bson:"updated_at" json:"updatedAt"`
+	UpdatedBy          bson.ObjectID  `bson:"updated_by" json:"updatedBy"`
 }
 
 type SprintStatus string
@@ -24,10 +24,19 @@ type Task struct {
 	Attributes    []TaskAttribute `bson:"attributes" json:"attributes"`
 	StartDate     *time.Time      `bson:"start_date" json:"startDate"`
 	DueDate       *time.Time      `bson:"due_date" json:"dueDate"`
-	CreatedAt     time.Time       `bson:"created_at" json:"createdAt"`
-	CreatedBy     bson.ObjectID   `bson:"created_by" json:"createdBy"`
-	UpdatedAt     time.Time       `bson:"updated_at" json:"updatedAt"`
-	UpdatedBy     bson.ObjectID   `bson:"updated_by" json:"updatedBy"`
+	// StatusChanges lists every status the task has been moved to, oldest first. Tasks created before
+	// status changes were recorded only have the changes made since.
+	StatusChanges []TaskStatusChange `bson:"status_changes,omitempty" json:"statusChanges,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at" json:"createdAt"`
+	CreatedBy     bson.ObjectID      `bson:"created_by" json:"createdBy"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updatedAt"`
+	UpdatedBy     bson.ObjectID      `bson:"updated_by" json:"updatedBy"`
+}
+
+type TaskStatusChange struct {
+	Status    string        `bson:"status" json:"status"`
+	ChangedAt time.Time     `bson:"changed_at" json:"changedAt"`
+	ChangedBy bson.ObjectID `bson:"changed_by" json:"changedBy"`
 }
 
 type TaskType string
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TaskEmbedding is the embedding of the title and description of a task, a task has at most one.
+type TaskEmbedding struct {
+	TaskID    bson.ObjectID `bson:"_id" json:"taskId"`
+	ProjectID bson.ObjectID `bson:"project_id" json:"projectId"`
+	Provider  string        `bson:"provider" json:"provider"`
+	Model     string        `bson:"model" json:"model"`
+	Vector    []float32     `bson:"vector" json:"vector"`
+	UpdatedAt time.Time     `bson:"updated_at" json:"updatedAt"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// TaskSummary is a generated summary of the description and comments of a task, it is only cached.
+type TaskSummary struct {
+	CurrentState  string   `json:"currentState"`
+	Decisions     []string `json:"decisions"`
+	OpenQuestions []string `json:"openQuestions"`
+	CommentCount  int      `json:"commentCount"`
+	// LatestCommentAt is the time of the latest comment summarised, nil when the task has no comments
+	LatestCommentAt *time.Time `json:"latestCommentAt"`
+	GeneratedAt     time.Time  `json:"generatedAt"`
+}
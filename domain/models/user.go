@@ -8,15 +8,53 @@ import (
 )
 
 type User struct {
-	ID                 bson.ObjectID `bson:"_id" json:"id"`
-	Email              string        `bson:"email" json:"email"`
-	PasswordHash       string        `bson:"password_hash" json:"passwordHash"`
-	FullName           string        `bson:"full_name" json:"fullName"`
-	DisplayName        string        `bson:"display_name" json:"displayName"`
-	DefaultProfileUrl  string        `bson:"default_profile_url" json:"defaultProfileUrl"`
-	UploadedProfileUrl *string       `bson:"uploaded_profile_url" json:"uploadedProfileUrl"`
-	CreatedAt          time.Time     `bson:"created_at" json:"createdAt"`
-	UpdatedAt          time.Time     `bson:"updated_at" json:"updatedAt"`
+	ID                 bson.ObjectID   `bson:"_id" json:"id"`
+	Email              string          `bson:"email" json:"email"`
+	PasswordHash       string          `bson:"password_hash" json:"passwordHash"`
+	FullName           string          `bson:"full_name" json:"fullName"`
+	DisplayName        string          `bson:"display_name" json:"displayName"`
+	DefaultProfileUrl  string          `bson:"default_profile_url" json:"defaultProfileUrl"`
+	UploadedProfileUrl *string         `bson:"uploaded_profile_url" json:"uploadedProfileUrl"`
+	EmailVerifiedAt    *time.Time      `bson:"email_verified_at,omitempty" json:"emailVerifiedAt,omitempty"`
+	OIDCIdentities     []OIDCLink      `bson:"oidc_identities,omitempty" json:"oidcIdentities,omitempty"`
+	TwoFactor          *TwoFactor      `bson:"two_factor,omitempty" json:"-"`
+	ServiceAccount     *ServiceAccount `bson:"service_account,omitempty" json:"serviceAccount,omitempty"`
+	// DeactivatedAt blocks the user from signing in, their memberships and history are kept
+	DeactivatedAt *time.Time     `bson:"deactivated_at,omitempty" json:"deactivatedAt,omitempty"`
+	DeactivatedBy *bson.ObjectID `bson:"deactivated_by,omitempty" json:"deactivatedBy,omitempty"`
+	// AnonymizedAt is set once the personal fields have been scrubbed, the user stays deactivated
+	AnonymizedAt *time.Time `bson:"anonymized_at,omitempty" json:"anonymizedAt,omitempty"`
+	CreatedAt    time.Time  `bson:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time  `bson:"updated_at" json:"updatedAt"`
+}
+
+func (u *User) IsTwoFactorEnabled() bool {
+	return u.TwoFactor != nil && u.TwoFactor.EnabledAt != nil
+}
+
+func (u *User) IsDeactivated() bool {
+	return u.DeactivatedAt != nil
+}
+
+func (u *User) IsServiceAccount() bool {
+	return u.ServiceAccount != nil
+}
+
+// ServiceAccount marks a user that belongs to a workspace instead of a person. It has no password
+// and can only authenticate with access tokens.
+type ServiceAccount struct {
+	WorkspaceID bson.ObjectID `bson:"workspace_id" json:"workspaceId"`
+	CreatedBy   bson.ObjectID `bson:"created_by" json:"createdBy"`
+}
+
+// TwoFactor holds the TOTP settings of a user. The secret is stored when enrolment starts,
+// but two-factor authentication is only enforced once EnabledAt is set.
+type TwoFactor struct {
+	Secret             string     `bson:"secret"`
+	EnabledAt          *time.Time `bson:"enabled_at,omitempty"`
+	RecoveryCodeHashes []string   `bson:"recovery_code_hashes"`
+	// LastUsedStep is the TOTP time step of the last accepted code, codes can not be replayed
+	LastUsedStep int64 `bson:"last_used_step"`
 }
 
 type UserCustomClaims struct {
@@ -25,5 +63,58 @@ type UserCustomClaims struct {
 	DisplayName string `json:"displayName"`
 	Email       string `json:"email"`
 	ProfileUrl  string `json:"profileUrl"`
+	// AccessTokenID is only set when the request is authenticated with a personal access token or service account token
+	AccessTokenID string `json:"accessTokenId,omitempty"`
+	// IssuedAtMilli is the issue time in milliseconds, the registered iat claim only has second precision
+	IssuedAtMilli int64 `json:"iatMs,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// GetIssuedAtMilli returns the issue time in milliseconds, falling back to the iat claim for older tokens.
+func (c *UserCustomClaims) GetIssuedAtMilli() int64 {
+	if c.IssuedAtMilli != 0 {
+		return c.IssuedAtMilli
+	} else if c.IssuedAt != nil {
+		return c.IssuedAt.UnixMilli()
+	}
+
+	return 0
+}
+
+// PasswordResetTokenClaims is carried by the password reset link. PasswordFingerprint ties the token
+// to the current password hash, so the token stops working once the password has been changed.
+type PasswordResetTokenClaims struct {
+	UserID              string `json:"userId"`
+	PasswordFingerprint string `json:"passwordFingerprint"`
+	jwt.RegisteredClaims
+}
+
+type EmailVerificationTokenClaims struct {
+	UserID string `json:"userId"`
+	Email  string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// OIDCLink links a user to an account at an OpenID Connect provider.
+type OIDCLink struct {
+	Issuer   string    `bson:"issuer" json:"issuer"`
+	Subject  string    `bson:"subject" json:"subject"`
+	LinkedAt time.Time `bson:"linked_at" json:"linkedAt"`
+}
+
+// OIDCIdentity is the verified content of an ID token returned by the OpenID Connect provider.
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Nonce         string
+}
+
+// TwoFactorChallengeTokenClaims is returned by Login instead of the access token when the user has
+// two-factor authentication enabled, it is exchanged for the access token together with a code.
+type TwoFactorChallengeTokenClaims struct {
+	UserID string `json:"userId"`
 	jwt.RegisteredClaims
 }
@@ -7,9 +7,10 @@ import (
 )
 
 type Workspace struct {
-	ID        bson.ObjectID `bson:"_id,omitempty" json:"id"`
-	Name      string        `bson:"name" json:"name"`
-	CreatedBy bson.ObjectID `bson:"created_by" json:"createdBy"`
-	CreatedAt time.Time     `bson:"created_at" json:"createdAt"`
-	UpdatedAt time.Time     `bson:"updated_at" json:"updatedAt"`
+	ID               bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name             string        `bson:"name" json:"name"`
+	RequireTwoFactor bool          `bson:"require_two_factor" json:"requireTwoFactor"`
+	CreatedBy        bson.ObjectID `bson:"created_by" json:"createdBy"`
+	CreatedAt        time.Time     `bson:"created_at" json:"createdAt"`
+	UpdatedAt        time.Time     `bson:"updated_at" json:"updatedAt"`
 }
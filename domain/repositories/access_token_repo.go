@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type AccessTokenRepository interface {
+	Create(ctx context.Context, in *CreateAccessTokenRequest) (*models.AccessToken, error)
+	FindByID(ctx context.Context, id bson.ObjectID) (*models.AccessToken, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*models.AccessToken, error)
+	// FindByUserID returns the tokens of the user that have not been revoked
+	FindByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.AccessToken, error)
+	Revoke(ctx context.Context, id bson.ObjectID) error
+	UpdateLastUsedAt(ctx context.Context, id bson.ObjectID, lastUsedAt time.Time) error
+}
+
+type CreateAccessTokenRequest struct {
+	UserID      bson.ObjectID
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scopes      []models.AccessTokenScope
+	ExpiresAt   time.Time
+	CreatedBy   bson.ObjectID
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+type APIRateLimitCacheRepository interface {
+	// Take removes one token from the bucket, the bucket starts full and refills continuously up to its capacity
+	Take(ctx context.Context, in *TakeAPIRateLimitTokenRequest) (*TakeAPIRateLimitTokenResult, error)
+}
+
+type TakeAPIRateLimitTokenRequest struct {
+	Group           string
+	Subject         string
+	Capacity        int
+	RefillPerSecond float64
+}
+
+type TakeAPIRateLimitTokenResult struct {
+	Allowed bool
+	// Remaining is the number of whole tokens left after this request
+	Remaining int
+	// RetryAfter is the wait until the next token, zero when a token is left
+	RetryAfter time.Duration
+	// ResetAfter is the wait until the bucket is full again
+	ResetAfter time.Duration
+}
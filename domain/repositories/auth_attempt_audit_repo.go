@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+)
+
+type AuthAttemptAuditRepository interface {
+	Create(ctx context.Context, in *CreateAuthAttemptAuditRequest) (*models.AuthAttemptAudit, error)
+	// ReplaceAccount rewrites the account of past attempts, e.g. when the user is anonymised
+	ReplaceAccount(ctx context.Context, account string, replacement string) error
+}
+
+type CreateAuthAttemptAuditRequest struct {
+	Route      string
+	Account    string
+	IPAddress  string
+	UserAgent  string
+	Outcome    models.AuthAttemptAuditOutcome
+	StatusCode int
+}
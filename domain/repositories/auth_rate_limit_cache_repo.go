@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+type AuthRateLimitCacheRepository interface {
+	// IncrementIPAttempts counts an attempt of the IP address on the route and returns the count of the current window
+	IncrementIPAttempts(ctx context.Context, route string, ipAddress string, window time.Duration) (int64, error)
+	// IncrementAccountFailures counts a failed attempt on the account and returns the count of the current window
+	IncrementAccountFailures(ctx context.Context, route string, account string, window time.Duration) (int64, error)
+	ResetAccountFailures(ctx context.Context, route string, account string) error
+	LockAccount(ctx context.Context, route string, account string, duration time.Duration) error
+	// GetAccountLockTTL returns the remaining lockout of the account, zero when it is not locked
+	GetAccountLockTTL(ctx context.Context, route string, account string) (time.Duration, error)
+}
@@ -11,15 +11,20 @@ import (
 type InvitationRepository interface {
 	FindByID(ctx context.Context, id bson.ObjectID) (*models.Invitation, error)
 	FindByWorkspaceIDAndInviteeUserID(ctx context.Context, workspaceID bson.ObjectID, inviteeUserID bson.ObjectID) (*models.Invitation, error)
-	Create(ctx context.Context, invitation *CreateInvitationRequest) error
+	FindByWorkspaceIDAndInviteeEmail(ctx context.Context, workspaceID bson.ObjectID, inviteeEmail string) (*models.Invitation, error)
+	Create(ctx context.Context, invitation *CreateInvitationRequest) (*models.Invitation, error)
 	FindByInviteeUserID(ctx context.Context, inviteeUserID bson.ObjectID, sortBy string, order string) ([]models.Invitation, error)
 	UpdateStatus(ctx context.Context, id bson.ObjectID, status models.InvitationStatus) error
 	SearchInvitationForEachWorkspace(ctx context.Context, in *SearchInvitationForEachWorkspaceRequest) ([]models.Invitation, int64, error)
+	AcceptPendingByID(ctx context.Context, id bson.ObjectID, inviteeUserID bson.ObjectID) (bool, error)
+	Renew(ctx context.Context, id bson.ObjectID, expiredAt time.Time) (*models.Invitation, error)
+	ExpireOverdue(ctx context.Context) (int64, error)
 }
 
 type CreateInvitationRequest struct {
 	WorkspaceID   bson.ObjectID
 	InviteeUserID bson.ObjectID
+	InviteeEmail  *string
 	Role          models.InvitationRole
 	Status        models.InvitationStatus
 	ExpiredAt     time.Time
@@ -0,0 +1,63 @@
+package repositories
+
+import "context"
+
+// LLMRepository is implemented by every large language model provider, the provider in use is selected
+// with config.LLMConfig so services never depend on a specific vendor.
+type LLMRepository interface {
+	Complete(ctx context.Context, in *LLMCompletionRequest) (*LLMCompletion, error)
+	// Stream calls onChunk with each piece of text as soon as it is generated. It stops when ctx is done
+	// or onChunk returns an error, the returned completion holds the whole text.
+	Stream(ctx context.Context, in *LLMCompletionRequest, onChunk func(chunk string) error) (*LLMCompletion, error)
+	// CompleteJSON asks the model for a JSON document matching in.Schema and decodes it into out.
+	// When the document can not be decoded, the completion is returned with the error so it can be repaired.
+	CompleteJSON(ctx context.Context, in *LLMCompletionRequest, out any) (*LLMCompletion, error)
+	// Embed returns the embedding vector of text. Only vectors of the same provider and model can be compared.
+	Embed(ctx context.Context, text string) (*LLMEmbedding, error)
+}
+
+type LLMCompletionRequest struct {
+	SystemPrompt string
+	Prompt       string
+	// Temperature uses the provider default when nil
+	Temperature *float32
+	// MaxTokens uses the provider default when zero
+	MaxTokens int
+	// Schema describes the expected output of CompleteJSON, any JSON document is accepted when nil
+	Schema *LLMSchema
+}
+
+type LLMCompletion struct {
+	Provider         string
+	Model            string
+	Text             string
+	PromptTokens     int
+	CompletionTokens int
+}
+
+type LLMEmbedding struct {
+	Provider string
+	Model    string
+	Vector   []float32
+}
+
+// LLMSchema is the subset of JSON Schema understood by every provider.
+type LLMSchema struct {
+	Type        LLMSchemaType         `json:"type"`
+	Description string                `json:"description,omitempty"`
+	Enum        []string              `json:"enum,omitempty"`
+	Items       *LLMSchema            `json:"items,omitempty"`
+	Properties  map[string]*LLMSchema `json:"properties,omitempty"`
+	Required    []string              `json:"required,omitempty"`
+}
+
+type LLMSchemaType string
+
+const (
+	LLMSchemaTypeString  LLMSchemaType = "string"
+	LLMSchemaTypeNumber  LLMSchemaType = "number"
+	LLMSchemaTypeInteger LLMSchemaType = "integer"
+	LLMSchemaTypeBoolean LLMSchemaType = "boolean"
+	LLMSchemaTypeArray   LLMSchemaType = "array"
+	LLMSchemaTypeObject  LLMSchemaType = "object"
+)
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// LLMStreamCacheRepository tracks the LLM streams each user has running, across every instance of the service.
+type LLMStreamCacheRepository interface {
+	// Acquire registers the stream unless the user already runs limit streams. A stream that is never released,
+	// because its instance stopped, is forgotten after ttl.
+	Acquire(ctx context.Context, userID string, streamID string, limit int, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, userID string, streamID string) error
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import "context"
+
+type MailRepository interface {
+	Send(ctx context.Context, in *SendMailRequest) error
+}
+
+type SendMailRequest struct {
+	To      []string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+)
+
+// OIDCRepository talks to the configured OpenID Connect provider using the authorization code flow.
+type OIDCRepository interface {
+	IsEnabled() bool
+	AuthCodeURL(ctx context.Context, state string, nonce string) (string, error)
+	// ExchangeCode redeems the authorization code and returns the identity from the verified ID token
+	ExchangeCode(ctx context.Context, code string) (*models.OIDCIdentity, error)
+}
+
+// OIDCStateCacheRepository stores the state of pending authorization requests,
+// each state can only be consumed once.
+type OIDCStateCacheRepository interface {
+	Set(ctx context.Context, in *SetOIDCStateRequest) error
+	Consume(ctx context.Context, state string) (*string, error)
+}
+
+type SetOIDCStateRequest struct {
+	State string
+	Nonce string
+	TTL   time.Duration
+}
@@ -1,5 +0,0 @@
-package repositories
-
-type OllamaRepository interface {
-	Generate(model string, prompt string, stream bool) (string, error)
-}
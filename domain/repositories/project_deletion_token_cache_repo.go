@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ProjectDeletionTokenCacheRepository interface {
+	Set(ctx context.Context, in *SetProjectDeletionTokenRequest) error
+	GetAndDelete(ctx context.Context, projectID bson.ObjectID, userID bson.ObjectID) (string, error)
+}
+
+type SetProjectDeletionTokenRequest struct {
+	ProjectID bson.ObjectID
+	UserID    bson.ObjectID
+	Token     string
+	TTL       time.Duration
+}
@@ -18,6 +18,10 @@ type ProjectMemberRepository interface {
 	FindProjectOwnersByProjectIDs(ctx context.Context, projectIDs []bson.ObjectID) (map[bson.ObjectID]models.ProjectMember, error)
 	FindByProjectIDAndPositions(ctx context.Context, projectID bson.ObjectID, positions []string) ([]*models.ProjectMember, error)
 	UpdatePositionByID(ctx context.Context, in *UpdatePositionRequest) (*models.ProjectMember, error)
+	UpdateRoleByID(ctx context.Context, in *UpdateRoleRequest) (*models.ProjectMember, error)
+	DeleteByProjectID(ctx context.Context, projectID bson.ObjectID) error
+	RemoveByID(ctx context.Context, id bson.ObjectID) (*models.ProjectMember, error)
+	RemoveByProjectIDsAndUserID(ctx context.Context, projectIDs []bson.ObjectID, userID bson.ObjectID) error
 }
 
 type CreateProjectMemberRequest struct {
@@ -37,3 +41,8 @@ type UpdatePositionRequest struct {
 	ID       bson.ObjectID
 	Position string
 }
+
+type UpdateRoleRequest struct {
+	ID   bson.ObjectID
+	Role models.ProjectMemberRole
+}
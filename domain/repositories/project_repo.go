@@ -23,6 +23,9 @@ type ProjectRepository interface {
 	FindAttributeTemplatesByProjectID(ctx context.Context, projectID bson.ObjectID) ([]models.ProjectAttributeTemplate, error)
 	UpdateSetupStatus(ctx context.Context, in *UpdateProjectSetupStatus) (*models.Project, error)
 	UpdateDetail(ctx context.Context, in *UpdateProjectDetailRequest) (*models.Project, error)
+	UpdateStatus(ctx context.Context, in *UpdateProjectStatusRequest) (*models.Project, error)
+	Delete(ctx context.Context, projectID bson.ObjectID) error
+	UpdatePermissions(ctx context.Context, in *UpdateProjectPermissionsRequest) (*models.Project, error)
 }
 
 type CreateProjectRequest struct {
@@ -35,6 +38,7 @@ type CreateProjectRequest struct {
 	Workflows          []models.ProjectWorkflow
 	AttributeTemplates []models.ProjectAttributeTemplate
 	Positions          []string
+	SetupStatus        models.ProjectSetupStatus
 	CreatedBy          bson.ObjectID
 }
 
@@ -48,3 +52,15 @@ type UpdateProjectDetailRequest struct {
 	Name        string
 	Description string
 }
+
+type UpdateProjectStatusRequest struct {
+	ProjectID bson.ObjectID
+	Status    models.ProjectStatus
+	UpdatedBy bson.ObjectID
+}
+
+type UpdateProjectPermissionsRequest struct {
+	ProjectID   bson.ObjectID
+	Permissions models.ProjectPermissionMatrix
+	UpdatedBy   bson.ObjectID
+}
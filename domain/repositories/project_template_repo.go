@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ProjectTemplateRepository interface {
+	Create(ctx context.Context, in *CreateProjectTemplateRequest) (*models.ProjectTemplate, error)
+	FindByID(ctx context.Context, id bson.ObjectID) (*models.ProjectTemplate, error)
+	FindByWorkspaceID(ctx context.Context, workspaceID bson.ObjectID) ([]*models.ProjectTemplate, error)
+	FindByWorkspaceIDAndName(ctx context.Context, workspaceID bson.ObjectID, name string) (*models.ProjectTemplate, error)
+	Delete(ctx context.Context, id bson.ObjectID) error
+}
+
+type CreateProjectTemplateRequest struct {
+	WorkspaceID        bson.ObjectID
+	Name               string
+	Description        string
+	Positions          []string
+	Workflows          []models.ProjectWorkflow
+	AttributeTemplates []models.ProjectAttributeTemplate
+	Epics              []models.ProjectTemplateEpic
+	CreatedBy          bson.ObjectID
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ErrPromptTemplateVersionExists is returned by Create when another version with the same number was saved first
+var ErrPromptTemplateVersionExists = errors.New("prompt template version already exists")
+
+type PromptTemplateRepository interface {
+	Create(ctx context.Context, in *CreatePromptTemplateRequest) (*models.PromptTemplate, error)
+	// FindLatestByKey returns nil when the key still uses its built-in template
+	FindLatestByKey(ctx context.Context, key models.PromptTemplateKey) (*models.PromptTemplate, error)
+	FindByKeyAndVersion(ctx context.Context, key models.PromptTemplateKey, version int) (*models.PromptTemplate, error)
+	// FindByKey returns the versions of the key, the latest first
+	FindByKey(ctx context.Context, key models.PromptTemplateKey) ([]*models.PromptTemplate, error)
+}
+
+type CreatePromptTemplateRequest struct {
+	Key                 models.PromptTemplateKey
+	Version             int
+	Template            string
+	RestoredFromVersion *int
+	CreatedBy           bson.ObjectID
+}
@@ -17,6 +17,8 @@ type SprintRepository interface {
 	UpdateStatus(ctx context.Context, req *UpdateSprintStatusRequest) (*models.Sprint, error)
 	Delete(ctx context.Context, sprintID bson.ObjectID) error
 	FindByProjectIDAndStatus(ctx context.Context, projectID bson.ObjectID, status models.SprintStatus) ([]models.Sprint, error)
+	DeleteByProjectID(ctx context.Context, projectID bson.ObjectID) error
+	UpdateSummary(ctx context.Context, req *UpdateSprintSummaryRequest) (*models.Sprint, error)
 }
 
 type CreateSprintRequest struct {
@@ -46,3 +48,8 @@ type UpdateSprintStatusRequest struct {
 	Status    models.SprintStatus
 	UpdatedBy bson.ObjectID
 }
+
+type UpdateSprintSummaryRequest struct {
+	ID      bson.ObjectID
+	Summary *models.SprintSummary
+}
@@ -10,6 +10,9 @@ import (
 type TaskCommentRepository interface {
 	Create(ctx context.Context, taskComment *CreateTaskCommentRequest) (*models.TaskComment, error)
 	FindByTaskID(ctx context.Context, taskID bson.ObjectID) ([]*models.TaskComment, error)
+	FindByTaskIDs(ctx context.Context, taskIDs []bson.ObjectID) ([]*models.TaskComment, error)
+	FindByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.TaskComment, error)
+	DeleteByTaskIDs(ctx context.Context, taskIDs []bson.ObjectID) error
 }
 
 type CreateTaskCommentRequest struct {
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type TaskEmbeddingRepository interface {
+	Upsert(ctx context.Context, in *UpsertTaskEmbeddingRequest) (*models.TaskEmbedding, error)
+	FindByTaskID(ctx context.Context, taskID bson.ObjectID) (*models.TaskEmbedding, error)
+	// FindByProjectIDAndModel returns the embeddings of a project that can be compared with each other
+	FindByProjectIDAndModel(ctx context.Context, projectID bson.ObjectID, provider string, model string) ([]*models.TaskEmbedding, error)
+	DeleteByProjectID(ctx context.Context, projectID bson.ObjectID) error
+}
+
+type UpsertTaskEmbeddingRequest struct {
+	TaskID    bson.ObjectID
+	ProjectID bson.ObjectID
+	Provider  string
+	Model     string
+	Vector    []float32
+}
@@ -41,6 +41,13 @@ type TaskRepository interface {
 	UpdateManyTasksStatus(ctx context.Context, in *UpdateManyTasksStatusRequest) error
 	UpdateStartDateAndDueDate(ctx context.Context, in *UpdateTaskStartDateAndDueDateRequest) (*models.Task, error)
 	BulkUpdateStartDateAndDueDate(ctx context.Context, in *BulkUpdateStartDateAndDueDateRequest) error
+	FindByProjectIDAndAttributeKeys(ctx context.Context, projectID bson.ObjectID, keys []string) ([]*models.Task, error)
+	BulkUpdateAttributes(ctx context.Context, in []UpdateTaskAttributesRequest) error
+	DeleteByProjectID(ctx context.Context, projectID bson.ObjectID) error
+	FindByProjectIDAndAssigneeOrApprover(ctx context.Context, projectID bson.ObjectID, userID bson.ObjectID) ([]*models.Task, error)
+	// FindByCreatorOrAssigneeOrApprover returns the tasks of every project the user created, is assigned to or approves
+	FindByCreatorOrAssigneeOrApprover(ctx context.Context, userID bson.ObjectID) ([]*models.Task, error)
+	BulkUpdateAssigneesAndApprovals(ctx context.Context, in []UpdateTaskAssigneesAndApprovalsRequest) error
 }
 
 type CreateTaskRequest struct {
@@ -157,6 +164,8 @@ type SearchTaskRequest struct {
 	Statuses           []string
 	IsDoneStatuses     []string
 	SearchKeyword      *string
+	Priorities         []models.TaskPriority
+	IsOverdue          bool
 }
 
 type UpdateTaskAttributesRequest struct {
@@ -190,3 +199,10 @@ type BulkUpdateStartDateAndDueDateRequest struct {
 	DueDate   *time.Time
 	UpdatedBy bson.ObjectID
 }
+
+type UpdateTaskAssigneesAndApprovalsRequest struct {
+	ID        bson.ObjectID
+	Assignees []models.TaskAssignee
+	Approvals []models.TaskApproval
+	UpdatedBy bson.ObjectID
+}
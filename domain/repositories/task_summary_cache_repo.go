@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TaskSummaryCacheRepository caches task summaries by the version of the task they summarise,
+// a new comment or description changes the version so the stale summary is never read again.
+type TaskSummaryCacheRepository interface {
+	// Get returns nil when no summary is cached for the version
+	Get(ctx context.Context, taskID bson.ObjectID, version string) (*models.TaskSummary, error)
+	Set(ctx context.Context, in *SetTaskSummaryRequest) error
+}
+
+type SetTaskSummaryRequest struct {
+	TaskID  bson.ObjectID
+	Version string
+	Summary *models.TaskSummary
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// TwoFactorChallengeCacheRepository stores the pending two-factor login challenges. A challenge is
+// consumed once it has been completed, and burned after too many wrong codes.
+type TwoFactorChallengeCacheRepository interface {
+	Create(ctx context.Context, in *CreateTwoFactorChallengeRequest) error
+	Exists(ctx context.Context, challengeID string) (bool, error)
+	// RecordFailure counts a wrong code and deletes the challenge once maxFailures is reached,
+	// it returns false when the challenge is unknown, expired or has just been burned
+	RecordFailure(ctx context.Context, challengeID string, maxFailures int) (bool, error)
+	// Consume deletes the challenge, it returns false when the challenge is unknown or expired
+	Consume(ctx context.Context, challengeID string) (bool, error)
+}
+
+type CreateTwoFactorChallengeRequest struct {
+	ChallengeID string
+	TTL         time.Duration
+}
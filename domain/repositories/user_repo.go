@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/cnc-csku/task-nexus/task-management/domain/models"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -15,6 +16,21 @@ type UserRepository interface {
 	SearchWithUserIDs(ctx context.Context, in *SearchUserWithUserIDsRequest) ([]*models.User, int64, error)
 	FindByID(ctx context.Context, userID bson.ObjectID) (*models.User, error)
 	UpdateProfile(ctx context.Context, in *UpdateUserProfileRequest) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID bson.ObjectID, passwordHash string) error
+	UpdateEmailVerifiedAt(ctx context.Context, userID bson.ObjectID, verifiedAt time.Time) error
+	FindByOIDCIdentity(ctx context.Context, issuer string, subject string) (*models.User, error)
+	AddOIDCIdentity(ctx context.Context, userID bson.ObjectID, link *models.OIDCLink) error
+	SetTwoFactor(ctx context.Context, userID bson.ObjectID, twoFactor *models.TwoFactor) error
+	RemoveTwoFactor(ctx context.Context, userID bson.ObjectID) error
+	// UseTwoFactorStep records the step of an accepted TOTP code, it returns false when the step was already used
+	UseTwoFactorStep(ctx context.Context, userID bson.ObjectID, step int64) (bool, error)
+	// UseRecoveryCode removes the recovery code, it returns false when the code does not exist
+	UseRecoveryCode(ctx context.Context, userID bson.ObjectID, codeHash string) (bool, error)
+	FindServiceAccountsByWorkspaceID(ctx context.Context, workspaceID bson.ObjectID) ([]*models.User, error)
+	Deactivate(ctx context.Context, userID bson.ObjectID, deactivatedBy bson.ObjectID) error
+	Reactivate(ctx context.Context, userID bson.ObjectID) error
+	// Anonymize replaces the personal fields and removes the credentials, the user keeps its ID
+	Anonymize(ctx context.Context, in *AnonymizeUserRequest) error
 }
 
 type CreateUserRequest struct {
@@ -23,17 +39,22 @@ type CreateUserRequest struct {
 	FullName          string
 	DisplayName       string
 	DefaultProfileUrl string
+	EmailVerifiedAt   *time.Time
+	OIDCIdentities    []models.OIDCLink
+	ServiceAccount    *models.ServiceAccount
 }
 
 type SearchUserRequest struct {
-	Keyword           string
-	PaginationRequest PaginationRequest
+	Keyword            string
+	IncludeDeactivated bool
+	PaginationRequest  PaginationRequest
 }
 
 type SearchUserWithUserIDsRequest struct {
-	UserIDs           []bson.ObjectID
-	Keyword           string
-	PaginationRequest PaginationRequest
+	UserIDs            []bson.ObjectID
+	Keyword            string
+	IncludeDeactivated bool
+	PaginationRequest  PaginationRequest
 }
 
 type UpdateUserProfileRequest struct {
@@ -44,3 +65,11 @@ type UpdateUserProfileRequest struct {
 	UploadedProfileUrl *string
 	UpdatedBy          bson.ObjectID
 }
+
+type AnonymizeUserRequest struct {
+	UserID            bson.ObjectID
+	Email             string
+	FullName          string
+	DisplayName       string
+	DefaultProfileUrl string
+}
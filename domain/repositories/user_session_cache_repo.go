@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// UserSessionCacheRepository keeps track of when a user's sessions were revoked,
+// access tokens issued before that moment are no longer accepted.
+type UserSessionCacheRepository interface {
+	SetRevokedAt(ctx context.Context, in *SetUserSessionRevokedAtRequest) error
+	GetRevokedAt(ctx context.Context, userID bson.ObjectID) (*time.Time, error)
+}
+
+type SetUserSessionRevokedAtRequest struct {
+	UserID    bson.ObjectID
+	RevokedAt time.Time
+	TTL       time.Duration
+}
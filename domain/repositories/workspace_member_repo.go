@@ -12,6 +12,9 @@ type WorkspaceMemberRepository interface {
 	Create(ctx context.Context, req *CreateWorkspaceMemberRequest) (*models.WorkspaceMember, error)
 	FindByUserID(ctx context.Context, userID bson.ObjectID) ([]models.WorkspaceMember, error)
 	FindByWorkspaceIDAndUserID(ctx context.Context, workspaceID bson.ObjectID, userID bson.ObjectID) (*models.WorkspaceMember, error)
+	FindByID(ctx context.Context, id bson.ObjectID) (*models.WorkspaceMember, error)
+	UpdateRoleByID(ctx context.Context, in *UpdateWorkspaceMemberRoleRequest) (*models.WorkspaceMember, error)
+	RemoveByID(ctx context.Context, id bson.ObjectID) error
 }
 
 type CreateWorkspaceMemberRequest struct {
@@ -19,3 +22,8 @@ type CreateWorkspaceMemberRequest struct {
 	UserID      bson.ObjectID
 	Role        models.WorkspaceMemberRole
 }
+
+type UpdateWorkspaceMemberRoleRequest struct {
+	ID   bson.ObjectID
+	Role models.WorkspaceMemberRole
+}
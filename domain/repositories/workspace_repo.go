@@ -11,6 +11,8 @@ type WorkspaceRepository interface {
 	FindByID(ctx context.Context, workspaceID bson.ObjectID) (*models.Workspace, error)
 	Create(ctx context.Context, workspace *CreateWorkspaceRequest) (*models.Workspace, error)
 	FindByWorkspaceIDs(ctx context.Context, workspaceIDs []bson.ObjectID) ([]models.Workspace, error)
+	UpdateName(ctx context.Context, in *UpdateWorkspaceNameRequest) (*models.Workspace, error)
+	UpdateRequireTwoFactor(ctx context.Context, in *UpdateWorkspaceRequireTwoFactorRequest) (*models.Workspace, error)
 }
 
 type CreateWorkspaceRequest struct {
@@ -19,3 +21,13 @@ type CreateWorkspaceRequest struct {
 	UserDisplayName string
 	ProfileUrl      string
 }
+
+type UpdateWorkspaceNameRequest struct {
+	WorkspaceID bson.ObjectID
+	Name        string
+}
+
+type UpdateWorkspaceRequireTwoFactorRequest struct {
+	WorkspaceID      bson.ObjectID
+	RequireTwoFactor bool
+}
@@ -0,0 +1,39 @@
+package requests
+
+type CreateAccessTokenRequest struct {
+	Name          string   `json:"name" validate:"required"`
+	Scopes        []string `json:"scopes" validate:"required,min=1"`
+	ExpiresInDays int      `json:"expiresInDays" validate:"required,min=1,max=365"`
+}
+
+type RevokeAccessTokenRequest struct {
+	TokenID string `param:"tokenId" validate:"required"`
+}
+
+type CreateServiceAccountRequest struct {
+	WorkspaceID string `param:"workspaceId" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+}
+
+type ListServiceAccountsRequest struct {
+	WorkspaceID string `param:"workspaceId" validate:"required"`
+}
+
+type CreateServiceAccountTokenRequest struct {
+	WorkspaceID      string   `param:"workspaceId" validate:"required"`
+	ServiceAccountID string   `param:"serviceAccountId" validate:"required"`
+	Name             string   `json:"name" validate:"required"`
+	Scopes           []string `json:"scopes" validate:"required,min=1"`
+	ExpiresInDays    int      `json:"expiresInDays" validate:"required,min=1,max=365"`
+}
+
+type ListServiceAccountTokensRequest struct {
+	WorkspaceID      string `param:"workspaceId" validate:"required"`
+	ServiceAccountID string `param:"serviceAccountId" validate:"required"`
+}
+
+type RevokeServiceAccountTokenRequest struct {
+	WorkspaceID      string `param:"workspaceId" validate:"required"`
+	ServiceAccountID string `param:"serviceAccountId" validate:"required"`
+	TokenID          string `param:"tokenId" validate:"required"`
+}
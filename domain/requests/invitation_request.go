@@ -2,7 +2,7 @@ package requests
 
 type CreateInvitationRequest struct {
 	WorkspaceID   string `json:"workspaceId" validate:"required"`
-	InviteeEmail  string `json:"inviteeEmail" validate:"required"`
+	InviteeEmail  string `json:"inviteeEmail" validate:"required,email"`
 	Role          string `json:"role" validate:"required,oneof=MODERATOR MEMBER"`
 	CustomMessage string `json:"customMessage"`
 }
@@ -18,3 +18,11 @@ type UserResponseInvitationRequest struct {
 	InvitationID string `json:"invitationId" validate:"required"`
 	Action       string `json:"action" validate:"required"`
 }
+
+type RevokeInvitationRequest struct {
+	InvitationID string `param:"invitationId" validate:"required"`
+}
+
+type ResendInvitationRequest struct {
+	InvitationID string `param:"invitationId" validate:"required"`
+}
@@ -5,3 +5,15 @@ type UpdateMemberPositionRequest struct {
 	UserID    string `json:"userId" validate:"required"`
 	Position  string `json:"position" validate:"required"`
 }
+
+type UpdateMemberRoleRequest struct {
+	ProjectID string `param:"projectId" validate:"required"`
+	UserID    string `json:"userId" validate:"required"`
+	Role      string `json:"role" validate:"required"`
+}
+
+type RemoveMemberRequest struct {
+	ProjectID        string `param:"projectId" validate:"required"`
+	UserID           string `param:"userId" validate:"required"`
+	ReassignToUserID string `query:"reassignToUserId"`
+}
@@ -1,10 +1,15 @@
 package requests
 
 type CreateProjectRequest struct {
-	Name          string `json:"name" validate:"required"`
-	WorkspaceID   string `json:"workspaceId" validate:"required"`
-	ProjectPrefix string `json:"projectPrefix" validate:"required"`
-	Description   string `json:"description"`
+	Name            string  `json:"name" validate:"required"`
+	WorkspaceID     string  `json:"workspaceId" validate:"required"`
+	ProjectPrefix   string  `json:"projectPrefix" validate:"required"`
+	Description     string  `json:"description"`
+	TemplateID      *string `json:"templateId"`
+	SourceProjectID *string `json:"sourceProjectId"`
+	IncludeEpics    bool    `json:"includeEpics"`
+	// OwnerPosition is the position of the owner in a project created from a template, it defaults to the first one
+	OwnerPosition *string `json:"ownerPosition"`
 }
 
 type ListMyProjectsPathParams struct {
@@ -49,12 +54,16 @@ type AddProjectMembersRequestMember struct {
 type ListProjectMembersRequest struct {
 	ProjectID string `param:"projectId" validate:"required"`
 	Keyword   string `query:"keyword"`
+	// IncludeDeactivated also lists members whose account has been deactivated
+	IncludeDeactivated bool `query:"includeDeactivated"`
 	PaginationRequest
 }
 
 type UpdateWorkflowsRequest struct {
-	ProjectID string                           `param:"projectId" validate:"required"`
-	Workflows []UpdateWorkflowsRequestWorkflow `json:"workflows" validate:"required,dive"`
+	ProjectID      string                                `param:"projectId" validate:"required"`
+	Workflows      []UpdateWorkflowsRequestWorkflow      `json:"workflows" validate:"required,dive"`
+	StatusMappings []UpdateWorkflowsRequestStatusMapping `json:"statusMappings" validate:"dive"`
+	DryRun         bool                                  `json:"dryRun"`
 }
 
 type UpdateWorkflowsRequestWorkflow struct {
@@ -64,6 +73,11 @@ type UpdateWorkflowsRequestWorkflow struct {
 	IsDone           bool     `json:"isDone"`
 }
 
+type UpdateWorkflowsRequestStatusMapping struct {
+	FromStatus string `json:"fromStatus" validate:"required"`
+	ToStatus   string `json:"toStatus" validate:"required"`
+}
+
 type ListWorkflowsPathParams struct {
 	ProjectID string `param:"projectId" validate:"required"`
 }
@@ -71,13 +85,46 @@ type ListWorkflowsPathParams struct {
 type UpdateAttributeTemplatesRequest struct {
 	ProjectID          string                                     `param:"projectId" validate:"required"`
 	AttributeTemplates []UpdateAttributeTemplatesRequestAttribute `json:"attributesTemplates" validate:"required,dive"`
+	DryRun             bool                                       `json:"dryRun"`
 }
 
 type UpdateAttributeTemplatesRequestAttribute struct {
-	Name string `json:"name" validate:"required"`
-	Type string `json:"type" validate:"required"`
+	Name         string  `json:"name" validate:"required"`
+	Type         string  `json:"type" validate:"required"`
+	PreviousName *string `json:"previousName"`
 }
 
 type ListAttributeTemplatesPathParams struct {
 	ProjectID string `param:"projectId" validate:"required"`
 }
+
+type ArchiveProjectPathParams struct {
+	ProjectID string `param:"projectId" validate:"required"`
+}
+
+type UnarchiveProjectPathParams struct {
+	ProjectID string `param:"projectId" validate:"required"`
+}
+
+type RequestProjectDeletionPathParams struct {
+	ProjectID string `param:"projectId" validate:"required"`
+}
+
+type DeleteProjectRequest struct {
+	ProjectID         string `param:"projectId" validate:"required"`
+	ConfirmationToken string `query:"confirmationToken" validate:"required"`
+}
+
+type TransferProjectOwnershipRequest struct {
+	ProjectID      string `param:"projectId" validate:"required"`
+	NewOwnerUserID string `json:"newOwnerUserId" validate:"required"`
+}
+
+type ListProjectPermissionsPathParams struct {
+	ProjectID string `param:"projectId" validate:"required"`
+}
+
+type UpdateProjectPermissionsRequest struct {
+	ProjectID   string              `param:"projectId" validate:"required"`
+	Permissions map[string][]string `json:"permissions" validate:"required"`
+}
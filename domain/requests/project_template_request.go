@@ -0,0 +1,18 @@
+package requests
+
+type CreateProjectTemplateRequest struct {
+	WorkspaceID  string `param:"workspaceId" validate:"required"`
+	ProjectID    string `json:"projectId" validate:"required"`
+	Name         string `json:"name" validate:"required"`
+	Description  string `json:"description"`
+	IncludeEpics bool   `json:"includeEpics"`
+}
+
+type ListProjectTemplatesPathParams struct {
+	WorkspaceID string `param:"workspaceId" validate:"required"`
+}
+
+type DeleteProjectTemplateRequest struct {
+	WorkspaceID string `param:"workspaceId" validate:"required"`
+	TemplateID  string `param:"templateId" validate:"required"`
+}
@@ -0,0 +1,22 @@
+package requests
+
+type ListPromptTemplateVersionsRequest struct {
+	Key string `param:"key" validate:"required"`
+}
+
+type UpdatePromptTemplateRequest struct {
+	Key      string `param:"key" validate:"required"`
+	Template string `json:"template" validate:"required,max=20000"`
+}
+
+type PreviewPromptTemplateRequest struct {
+	Key string `param:"key" validate:"required"`
+	// Template is previewed before it is saved, the version in use is previewed when it is empty
+	Template string `json:"template" validate:"max=20000"`
+}
+
+type RollbackPromptTemplateRequest struct {
+	Key string `param:"key" validate:"required"`
+	// Version 0 is the built-in template
+	Version *int `json:"version" validate:"required,min=0"`
+}
@@ -42,3 +42,18 @@ type DeleteSprintRequest struct {
 	ProjectID string `param:"projectId" validate:"required"`
 	SprintID  string `param:"sprintId" validate:"required"`
 }
+
+type GenerateSprintSummaryRequest struct {
+	ProjectID string `param:"projectId" validate:"required"`
+	SprintID  string `param:"sprintId" validate:"required"`
+}
+
+type EditSprintSummaryRequest struct {
+	ProjectID          string   `param:"projectId" validate:"required"`
+	SprintID           string   `param:"sprintId" validate:"required"`
+	Overview           string   `json:"overview" validate:"required"`
+	Shipped            []string `json:"shipped"`
+	Slipped            []string `json:"slipped"`
+	Risks              []string `json:"risks"`
+	RetroTalkingPoints []string `json:"retroTalkingPoints"`
+}
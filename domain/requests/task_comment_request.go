@@ -10,3 +10,8 @@ type ListTaskCommentPathParams struct {
 	ProjectID string `param:"projectId" validate:"required"`
 	TaskRef   string `param:"taskRef" validate:"required"`
 }
+
+type SummarizeTaskRequest struct {
+	ProjectID string `param:"projectId" validate:"required"`
+	TaskRef   string `param:"taskRef" validate:"required"`
+}
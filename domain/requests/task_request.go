@@ -49,6 +49,8 @@ type SearchTaskParams struct {
 	Statuses        []string `query:"statuses"`
 	SearchKeyword   *string  `query:"searchKeyword"`
 	Types           []string `query:"types"`
+	Priorities      []string `query:"priorities"`
+	IsOverdue       *bool    `query:"isOverdue"` // Task past its due date and not done
 }
 
 type GetChildrenTasksParams struct {
@@ -133,5 +135,46 @@ type UpdateTaskAttributesRequestAttribute struct {
 }
 
 type GenerateDescriptionRequest struct {
-	Prompt string `query:"prompt"`
+	Prompt string `query:"prompt" validate:"required"`
+	// ProjectID and Type are optional, they are only used to fill the prompt template
+	ProjectID string `query:"projectId"`
+	Type      string `query:"type"`
+}
+
+type ProposeSubTasksRequest struct {
+	ProjectID string `param:"projectId" validate:"required"`
+	TaskRef   string `param:"taskRef" validate:"required"`
+	// MaxSubTasks limits the number of proposed sub-tasks, it defaults to 8
+	MaxSubTasks int `json:"maxSubTasks" validate:"omitempty,min=1,max=20"`
+}
+
+type AcceptSubTasksRequest struct {
+	ProjectID string                         `param:"projectId" validate:"required"`
+	TaskRef   string                         `param:"taskRef" validate:"required"`
+	SubTasks  []AcceptSubTasksRequestSubTask `json:"subTasks" validate:"required,min=1,max=20,dive"`
+}
+
+// AcceptSubTasksRequestSubTask is a proposed sub-task chosen by the user, it may have been edited after the proposal.
+type AcceptSubTasksRequestSubTask struct {
+	Title       string `json:"title" validate:"required"`
+	Description string `json:"description"`
+	Position    string `json:"position" validate:"required"`
+	Point       *int   `json:"point" validate:"omitempty,min=0"`
+}
+
+type GetPossibleDuplicatesRequest struct {
+	ProjectID string `param:"projectId" validate:"required"`
+	TaskRef   string `param:"taskRef" validate:"required"`
+}
+
+type EstimatePointsRequest struct {
+	ProjectID string `param:"projectId" validate:"required"`
+	TaskRef   string `param:"taskRef" validate:"required"`
+	// Positions to estimate, it defaults to the positions of the assignees or all positions of the project
+	Positions []string `json:"positions" validate:"omitempty,max=20,dive,required"`
+}
+
+type NaturalLanguageSearchTaskRequest struct {
+	ProjectID string `param:"projectId" validate:"required"`
+	Query     string `json:"query" validate:"required,max=500"`
 }
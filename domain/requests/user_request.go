@@ -1,9 +1,10 @@
 package requests
 
 type RegisterRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
-	FullName string `json:"fullName" validate:"required"`
+	Email           string `json:"email" validate:"required,email"`
+	Password        string `json:"password" validate:"required"`
+	FullName        string `json:"fullName" validate:"required"`
+	InvitationToken string `json:"invitationToken"`
 }
 
 type LoginRequest struct {
@@ -25,3 +26,62 @@ type UpdateUserProfileRequest struct {
 	DisplayName string  `json:"displayName" validate:"required"`
 	ProfileUrl  *string `json:"profileUrl"`
 }
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" validate:"required"`
+	NewPassword     string `json:"newPassword" validate:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type OIDCLoginRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
+
+type LoginWithTwoFactorRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	// Code is either a code from the authenticator app or a recovery code
+	Code string `json:"code" validate:"required"`
+}
+
+type EnableTwoFactorRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type RegenerateRecoveryCodesRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type DeactivateUserRequest struct {
+	UserID string `param:"userId" validate:"required"`
+}
+
+type ReactivateUserRequest struct {
+	UserID string `param:"userId" validate:"required"`
+}
+
+type AnonymizeUserRequest struct {
+	UserID string `param:"userId" validate:"required"`
+}
+
+type ExportUserDataRequest struct {
+	// UserID is empty when users export their own data
+	UserID string `param:"userId"`
+	Format string `query:"format" validate:"omitempty,oneof=json zip"`
+}
@@ -7,5 +7,33 @@ type CreateWorkspaceRequest struct {
 type ListWorkspaceMemberRequest struct {
 	WorkspaceID string `param:"workspaceId" validate:"required"`
 	Keyword     string `json:"keyword"`
+	// IncludeDeactivated also lists members whose account has been deactivated
+	IncludeDeactivated bool `query:"includeDeactivated"`
 	PaginationRequest
 }
+
+type RenameWorkspaceRequest struct {
+	WorkspaceID string `param:"workspaceId" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+}
+
+type UpdateWorkspaceMemberRoleRequest struct {
+	WorkspaceID string `param:"workspaceId" validate:"required"`
+	UserID      string `json:"userId" validate:"required"`
+	Role        string `json:"role" validate:"required"`
+}
+
+type RemoveWorkspaceMemberRequest struct {
+	WorkspaceID string `param:"workspaceId" validate:"required"`
+	UserID      string `param:"userId" validate:"required"`
+}
+
+type TransferWorkspaceOwnershipRequest struct {
+	WorkspaceID    string `param:"workspaceId" validate:"required"`
+	NewOwnerUserID string `json:"newOwnerUserId" validate:"required"`
+}
+
+type UpdateWorkspaceTwoFactorRequirementRequest struct {
+	WorkspaceID string `param:"workspaceId" validate:"required"`
+	Required    *bool  `json:"required" validate:"required"`
+}
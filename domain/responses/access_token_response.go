@@ -0,0 +1,25 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+)
+
+type CreateAccessTokenResponse struct {
+	AccessToken *models.AccessToken `json:"accessToken"`
+	// Token is only returned once, it can not be read again after creation
+	Token string `json:"token"`
+}
+
+type RevokeAccessTokenResponse struct {
+	Message string `json:"message"`
+}
+
+type ServiceAccountResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	WorkspaceID string    `json:"workspaceId"`
+	CreatedBy   string    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
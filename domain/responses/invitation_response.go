@@ -42,6 +42,7 @@ type InvitationForWorkspaceOwnerResponse struct {
 	InviteeDisplayName string     `json:"inviteeDisplayName"`
 	InviteeFullName    string     `json:"inviteeFullName"`
 	InviteeUserID      string     `json:"inviteeUserId"`
+	InviteeEmail       string     `json:"inviteeEmail"`
 	InviterDisplayName string     `json:"inviterDisplayName"`
 	InviterFullName    string     `json:"inviterFullName"`
 	InviterUserID      string     `json:"inviterUserId"`
@@ -53,3 +54,12 @@ type InvitationForWorkspaceOwnerResponse struct {
 type UserResponseInvitationResponse struct {
 	Message string `json:"message"`
 }
+
+type RevokeInvitationResponse struct {
+	Message string `json:"message"`
+}
+
+type ResendInvitationResponse struct {
+	Message   string `json:"message"`
+	ExpiredAt string `json:"expiredAt"`
+}
@@ -3,3 +3,9 @@ package responses
 type UpdateMemberPositionResponse struct {
 	Message string `json:"message"`
 }
+
+type RemoveMemberResponse struct {
+	Message          string   `json:"message"`
+	AssigneeTaskRefs []string `json:"assigneeTaskRefs"`
+	ApprovalTaskRefs []string `json:"approvalTaskRefs"`
+}
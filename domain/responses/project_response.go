@@ -12,6 +12,7 @@ type CreateProjectResponse struct {
 	Name          string `json:"name"`
 	ProjectPrefix string `json:"projectPrefix"`
 	Description   string `json:"description"`
+	SetupStatus   string `json:"setupStatus"`
 }
 
 type ListProjectsResponse struct {
@@ -65,21 +66,56 @@ type ListProjectMembersResponse struct {
 }
 
 type ListProjectMembersResponseMember struct {
-	UserID      string     `json:"userId"`
-	Email       string     `json:"email"`
-	FullName    string     `json:"fullName"`
-	DisplayName string     `json:"displayName"`
-	ProfileUrl  string     `json:"profileUrl"`
-	Role        string     `json:"role"`
-	Position    string     `json:"position"`
-	JoinedAt    time.Time  `json:"joinedAt"`
-	RemovedAt   *time.Time `json:"removedAt"`
+	UserID        string     `json:"userId"`
+	Email         string     `json:"email"`
+	FullName      string     `json:"fullName"`
+	DisplayName   string     `json:"displayName"`
+	ProfileUrl    string     `json:"profileUrl"`
+	Role          string     `json:"role"`
+	Position      string     `json:"position"`
+	JoinedAt      time.Time  `json:"joinedAt"`
+	RemovedAt     *time.Time `json:"removedAt"`
+	DeactivatedAt *time.Time `json:"deactivatedAt,omitempty"`
 }
 
 type UpdateWorkflowsResponse struct {
-	Message string `json:"message"`
+	Message    string                             `json:"message"`
+	DryRun     bool                               `json:"dryRun"`
+	Migrations []UpdateWorkflowsResponseMigration `json:"migrations"`
+}
+
+type UpdateWorkflowsResponseMigration struct {
+	FromStatus string   `json:"fromStatus"`
+	ToStatus   string   `json:"toStatus"`
+	TaskCount  int      `json:"taskCount"`
+	TaskRefs   []string `json:"taskRefs"`
 }
 
 type UpdateAttributeTemplatesResponse struct {
+	Message    string                                      `json:"message"`
+	DryRun     bool                                        `json:"dryRun"`
+	Migrations []UpdateAttributeTemplatesResponseMigration `json:"migrations"`
+}
+
+type UpdateAttributeTemplatesResponseMigration struct {
+	Action          string   `json:"action"`
+	Name            string   `json:"name"`
+	PreviousName    string   `json:"previousName"`
+	Type            string   `json:"type"`
+	PreviousType    string   `json:"previousType"`
+	TaskCount       int      `json:"taskCount"`
+	ClearedTaskRefs []string `json:"clearedTaskRefs"`
+}
+
+type RequestProjectDeletionResponse struct {
+	ConfirmationToken string    `json:"confirmationToken"`
+	ExpiresAt         time.Time `json:"expiresAt"`
+}
+
+type DeleteProjectResponse struct {
+	Message string `json:"message"`
+}
+
+type TransferProjectOwnershipResponse struct {
 	Message string `json:"message"`
 }
@@ -0,0 +1,5 @@
+package responses
+
+type DeleteProjectTemplateResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,19 @@
+package responses
+
+import "time"
+
+type PromptTemplateResponse struct {
+	Key string `json:"key"`
+	// Version is 0 while the built-in template is in use
+	Version   int        `json:"version"`
+	Template  string     `json:"template"`
+	IsBuiltIn bool       `json:"isBuiltIn"`
+	Variables []string   `json:"variables"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+	UpdatedBy *string    `json:"updatedBy"`
+}
+
+type PreviewPromptTemplateResponse struct {
+	Prompt     string `json:"prompt"`
+	SampleData any    `json:"sampleData"`
+}
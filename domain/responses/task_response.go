@@ -4,7 +4,6 @@ import (
 	"time"
 
 	"github.com/cnc-csku/task-nexus/task-management/domain/models"
-	"github.com/google/generative-ai-go/genai"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -92,5 +91,70 @@ type GetChildrenTasksResponse struct {
 }
 
 type GenerateDescriptionResponse struct {
-	Description []genai.Part `json:"description"`
+	Description []models.DescriptionBlock `json:"description"`
+}
+
+type ProposeSubTasksResponse struct {
+	ParentTaskRef string            `json:"parentTaskRef"`
+	SubTasks      []ProposedSubTask `json:"subTasks"`
+}
+
+type ProposedSubTask struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Position    string `json:"position"`
+	Point       int    `json:"point"`
+}
+
+type CreateTaskResponse struct {
+	*models.Task
+	// PossibleDuplicates are open tasks of the project similar to the created task, most similar first
+	PossibleDuplicates []PossibleDuplicateTask `json:"possibleDuplicates"`
+}
+
+type PossibleDuplicateTask struct {
+	TaskRef    string  `json:"taskRef"`
+	Title      string  `json:"title"`
+	Type       string  `json:"type"`
+	Status     string  `json:"status"`
+	Similarity float64 `json:"similarity"`
+}
+
+type EstimatePointsResponse struct {
+	TaskRef      string                     `json:"taskRef"`
+	Estimates    []PointEstimate            `json:"estimates"`
+	SimilarTasks []PointEstimateSimilarTask `json:"similarTasks"`
+}
+
+type PointEstimate struct {
+	Position      string `json:"position"`
+	Point         int    `json:"point"`
+	Justification string `json:"justification"`
+}
+
+// PointEstimateSimilarTask is a done task the estimate is based on, Points holds its final points by position.
+type PointEstimateSimilarTask struct {
+	TaskRef string         `json:"taskRef"`
+	Title   string         `json:"title"`
+	Type    string         `json:"type"`
+	Points  map[string]int `json:"points"`
+}
+
+type NaturalLanguageSearchTaskResponse struct {
+	// Filter is the interpreted search, its fields are the query parameters of the task search so it can be edited and run again
+	Filter SearchTaskFilter     `json:"filter"`
+	Tasks  []SearchTaskResponse `json:"tasks"`
+}
+
+type SearchTaskFilter struct {
+	SprintIDs       []string `json:"sprintIds"`
+	IsTaskInBacklog *bool    `json:"isTaskInBacklog"`
+	EpicTaskID      *string  `json:"epicTaskId"`
+	UserIDs         []string `json:"userIds"`
+	Positions       []string `json:"positions"`
+	Statuses        []string `json:"statuses"`
+	SearchKeyword   *string  `json:"searchKeyword"`
+	Types           []string `json:"types"`
+	Priorities      []string `json:"priorities"`
+	IsOverdue       *bool    `json:"isOverdue"`
 }
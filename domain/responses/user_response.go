@@ -1,24 +1,123 @@
 package responses
 
-import "time"
+import (
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+)
 
 type UserResponse struct {
-	ID          string    `json:"id"`
-	Email       string    `json:"email"`
-	FullName    string    `json:"fullName"`
-	DisplayName string    `json:"displayName"`
-	ProfileUrl  string    `json:"profileUrl"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID               string    `json:"id"`
+	Email            string    `json:"email"`
+	FullName         string    `json:"fullName"`
+	DisplayName      string    `json:"displayName"`
+	ProfileUrl       string    `json:"profileUrl"`
+	EmailVerified    bool      `json:"emailVerified"`
+	TwoFactorEnabled bool      `json:"twoFactorEnabled"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
 }
 
 type UserWithTokenResponse struct {
 	UserResponse
 	Token         string    `json:"token"`
 	TokenExpireAt time.Time `json:"tokenExpireAt"`
+	// Warning describes a non-fatal failure, e.g. the account was created but the invitation could not be accepted
+	Warning string `json:"warning,omitempty"`
+}
+
+// LoginResponse holds either the user with the access token, or the challenge to complete
+// with a two-factor code when the user has two-factor authentication enabled.
+type LoginResponse struct {
+	*UserWithTokenResponse
+	TwoFactorChallenge *TwoFactorChallengeResponse `json:"twoFactorChallenge,omitempty"`
+}
+
+type TwoFactorChallengeResponse struct {
+	ChallengeToken string    `json:"challengeToken"`
+	ExpireAt       time.Time `json:"expireAt"`
 }
 
 type ListUserResponse struct {
 	Users              []UserResponse     `json:"users"`
 	PaginationResponse PaginationResponse `json:"pagination"`
 }
+
+type ChangePasswordResponse struct {
+	Message       string    `json:"message"`
+	Token         string    `json:"token"`
+	TokenExpireAt time.Time `json:"tokenExpireAt"`
+}
+
+type ForgotPasswordResponse struct {
+	Message string `json:"message"`
+}
+
+type ResetPasswordResponse struct {
+	Message string `json:"message"`
+}
+
+type VerifyEmailResponse struct {
+	Message string `json:"message"`
+}
+
+type SendVerificationEmailResponse struct {
+	Message string `json:"message"`
+}
+
+type OIDCAuthorizationURLResponse struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+	State            string `json:"state"`
+}
+
+type EnrollTwoFactorResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type DisableTwoFactorResponse struct {
+	Message string `json:"message"`
+}
+
+type UserAccountStatusResponse struct {
+	Message       string     `json:"message"`
+	UserID        string     `json:"userId"`
+	DeactivatedAt *time.Time `json:"deactivatedAt"`
+	AnonymizedAt  *time.Time `json:"anonymizedAt"`
+}
+
+// UserDataExport holds everything stored about a user, it is the content of the data export.
+type UserDataExport struct {
+	ExportedAt           time.Time                `json:"exportedAt"`
+	Profile              UserDataExportProfile    `json:"profile"`
+	WorkspaceMemberships []models.WorkspaceMember `json:"workspaceMemberships"`
+	ProjectMemberships   []*models.ProjectMember  `json:"projectMemberships"`
+	Tasks                []*models.Task           `json:"tasks"`
+	Comments             []*models.TaskComment    `json:"comments"`
+}
+
+type UserDataExportProfile struct {
+	ID               string            `json:"id"`
+	Email            string            `json:"email"`
+	FullName         string            `json:"fullName"`
+	DisplayName      string            `json:"displayName"`
+	ProfileUrl       string            `json:"profileUrl"`
+	EmailVerifiedAt  *time.Time        `json:"emailVerifiedAt"`
+	TwoFactorEnabled bool              `json:"twoFactorEnabled"`
+	OIDCIdentities   []models.OIDCLink `json:"oidcIdentities"`
+	DeactivatedAt    *time.Time        `json:"deactivatedAt"`
+	AnonymizedAt     *time.Time        `json:"anonymizedAt"`
+	CreatedAt        time.Time         `json:"createdAt"`
+	UpdatedAt        time.Time         `json:"updatedAt"`
+}
+
+// UserDataExportFile is the encoded export, it is sent as a download.
+type UserDataExportFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
@@ -9,10 +9,11 @@ type ListOwnWorkspaceResponse struct {
 }
 
 type ListOwnWorkspaceResponseWorkspace struct {
-	ID       string    `json:"id"`
-	Name     string    `json:"name"`
-	Role     string    `json:"role"`
-	JoinedAt time.Time `json:"joinedAt"`
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	Role             string    `json:"role"`
+	RequireTwoFactor bool      `json:"requireTwoFactor"`
+	JoinedAt         time.Time `json:"joinedAt"`
 }
 
 type ListWorkspaceMembersResponse struct {
@@ -21,12 +22,22 @@ type ListWorkspaceMembersResponse struct {
 }
 
 type ListWorkspaceMembersResponseWorkspaceMember struct {
-	WorkspaceMemberID string    `json:"workspaceMemberId"`
-	UserID            string    `json:"userId"`
-	Role              string    `json:"role"`
-	JoinedAt          time.Time `json:"joinedAt"`
-	Email             string    `json:"email"`
-	FullName          string    `json:"fullName"`
-	DisplayName       string    `json:"displayName"`
-	ProfileUrl        string    `json:"profileUrl"`
+	WorkspaceMemberID string     `json:"workspaceMemberId"`
+	UserID            string     `json:"userId"`
+	Role              string     `json:"role"`
+	JoinedAt          time.Time  `json:"joinedAt"`
+	Email             string     `json:"email"`
+	FullName          string     `json:"fullName"`
+	DisplayName       string     `json:"displayName"`
+	ProfileUrl        string     `json:"profileUrl"`
+	DeactivatedAt     *time.Time `json:"deactivatedAt,omitempty"`
+}
+
+type RemoveWorkspaceMemberResponse struct {
+	Message             string   `json:"message"`
+	RemovedFromProjects []string `json:"removedFromProjects"`
+}
+
+type TransferWorkspaceOwnershipResponse struct {
+	Message string `json:"message"`
 }
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/responses"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type AccessTokenService interface {
+	Create(ctx context.Context, req *requests.CreateAccessTokenRequest, userID string) (*responses.CreateAccessTokenResponse, *errutils.Error)
+	List(ctx context.Context, userID string) ([]*models.AccessToken, *errutils.Error)
+	Revoke(ctx context.Context, req *requests.RevokeAccessTokenRequest, userID string) (*responses.RevokeAccessTokenResponse, *errutils.Error)
+	// Authenticate resolves a personal access token or service account token into the claims of its owner.
+	// routePath is the matched Echo route, it is checked against the token scopes.
+	Authenticate(ctx context.Context, token string, method string, routePath string) (*models.UserCustomClaims, *errutils.Error)
+}
+
+type accessTokenServiceImpl struct {
+	accessTokenRepo repositories.AccessTokenRepository
+	userRepo        repositories.UserRepository
+}
+
+func NewAccessTokenService(
+	accessTokenRepo repositories.AccessTokenRepository,
+	userRepo repositories.UserRepository,
+) AccessTokenService {
+	return &accessTokenServiceImpl{
+		accessTokenRepo: accessTokenRepo,
+		userRepo:        userRepo,
+	}
+}
+
+func (a *accessTokenServiceImpl) Create(ctx context.Context, req *requests.CreateAccessTokenRequest, userID string) (*responses.CreateAccessTokenResponse, *errutils.Error) {
+	user, svcErr := a.findPerson(ctx, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	return createAccessToken(ctx, a.accessTokenRepo, user.ID, user.ID, req.Name, req.Scopes, req.ExpiresInDays)
+}
+
+func (a *accessTokenServiceImpl) List(ctx context.Context, userID string) ([]*models.AccessToken, *errutils.Error) {
+	user, svcErr := a.findPerson(ctx, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	accessTokens, err := a.accessTokenRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return accessTokens, nil
+}
+
+func (a *accessTokenServiceImpl) Revoke(ctx context.Context, req *requests.RevokeAccessTokenRequest, userID string) (*responses.RevokeAccessTokenResponse, *errutils.Error) {
+	user, svcErr := a.findPerson(ctx, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	return revokeAccessToken(ctx, a.accessTokenRepo, user.ID, req.TokenID)
+}
+
+func (a *accessTokenServiceImpl) Authenticate(ctx context.Context, token string, method string, routePath string) (*models.UserCustomClaims, *errutils.Error) {
+	accessToken, err := a.accessTokenRepo.FindByTokenHash(ctx, hashAccessToken(token))
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if accessToken == nil || !accessToken.IsActive() {
+		return nil, errutils.NewError(exceptions.ErrInvalidToken, errutils.Unauthorized)
+	}
+
+	if !accessToken.Allows(method, routePath) {
+		return nil, errutils.NewError(exceptions.ErrAccessTokenScopeDenied, errutils.Forbidden).WithDebugMessage(method + " " + routePath)
+	}
+
+	user, err := a.userRepo.FindByID(ctx, accessToken.UserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidToken, errutils.Unauthorized)
+	} else if user.IsDeactivated() {
+		return nil, errutils.NewError(exceptions.ErrUserDeactivated, errutils.Forbidden)
+	}
+
+	// Tracking the last use is best effort, it must not fail the request
+	if err := a.accessTokenRepo.UpdateLastUsedAt(ctx, accessToken.ID, time.Now()); err != nil {
+		log.Printf("❌ Failed to update last used time of access token %s: %v", accessToken.ID.Hex(), err)
+	}
+
+	profileUrl := user.DefaultProfileUrl
+	if user.UploadedProfileUrl != nil {
+		profileUrl = *user.UploadedProfileUrl
+	}
+
+	return &models.UserCustomClaims{
+		ID:            user.ID.Hex(),
+		FullName:      user.FullName,
+		DisplayName:   user.DisplayName,
+		Email:         user.Email,
+		ProfileUrl:    profileUrl,
+		AccessTokenID: accessToken.ID.Hex(),
+	}, nil
+}
+
+// findPerson returns the requester, service accounts have their tokens managed by the workspace instead.
+func (a *accessTokenServiceImpl) findPerson(ctx context.Context, userID string) (*models.User, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	user, err := a.userRepo.FindByID(ctx, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.NotFound)
+	} else if user.IsServiceAccount() {
+		return nil, errutils.NewError(exceptions.ErrServiceAccountCannotManageTokens, errutils.Forbidden)
+	}
+
+	return user, nil
+}
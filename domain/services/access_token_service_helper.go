@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"time"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/responses"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// accessTokenDisplayLength is the number of leading characters kept so a token can be recognised in listings.
+const accessTokenDisplayLength = 12
+
+func hashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func parseAccessTokenScopes(rawScopes []string) ([]models.AccessTokenScope, *errutils.Error) {
+	scopes := make([]models.AccessTokenScope, 0, len(rawScopes))
+	for _, rawScope := range rawScopes {
+		scope := models.AccessTokenScope(rawScope)
+		if !scope.IsValid() {
+			return nil, errutils.NewError(exceptions.ErrInvalidAccessTokenScope, errutils.BadRequest).WithFields(rawScope)
+		}
+
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes, nil
+}
+
+// createAccessToken stores a new token for the owner and returns the plain token, which is never stored.
+func createAccessToken(
+	ctx context.Context,
+	accessTokenRepo repositories.AccessTokenRepository,
+	ownerID bson.ObjectID,
+	createdBy bson.ObjectID,
+	name string,
+	rawScopes []string,
+	expiresInDays int,
+) (*responses.CreateAccessTokenResponse, *errutils.Error) {
+	scopes, svcErr := parseAccessTokenScopes(rawScopes)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	random, err := randomToken()
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+	token := models.AccessTokenPrefix + random
+
+	accessToken, err := accessTokenRepo.Create(ctx, &repositories.CreateAccessTokenRequest{
+		UserID:      ownerID,
+		Name:        name,
+		TokenHash:   hashAccessToken(token),
+		TokenPrefix: token[:accessTokenDisplayLength],
+		Scopes:      scopes,
+		ExpiresAt:   time.Now().AddDate(0, 0, expiresInDays),
+		CreatedBy:   createdBy,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.CreateAccessTokenResponse{
+		AccessToken: accessToken,
+		Token:       token,
+	}, nil
+}
+
+// revokeAccessToken revokes the token when it belongs to the owner.
+func revokeAccessToken(ctx context.Context, accessTokenRepo repositories.AccessTokenRepository, ownerID bson.ObjectID, tokenID string) (*responses.RevokeAccessTokenResponse, *errutils.Error) {
+	bsonTokenID, err := bson.ObjectIDFromHex(tokenID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	accessToken, err := accessTokenRepo.FindByID(ctx, bsonTokenID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if accessToken == nil || accessToken.UserID != ownerID || accessToken.RevokedAt != nil {
+		return nil, errutils.NewError(exceptions.ErrAccessTokenNotFound, errutils.NotFound)
+	}
+
+	err = accessTokenRepo.Revoke(ctx, accessToken.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.RevokeAccessTokenResponse{
+		Message: "Access token revoked successfully",
+	}, nil
+}
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
@@ -21,6 +24,9 @@ type InvitationService interface {
 	ListForUser(ctx context.Context, userID string) (*responses.ListInvitationForUserResponse, *errutils.Error)
 	ListForWorkspaceOwner(ctx context.Context, req *requests.ListInvitationForWorkspaceOwnerParams, userID string) (*responses.ListInvitationForWorkspaceOwnerResponse, *errutils.Error)
 	UserResponse(ctx context.Context, req *requests.UserResponseInvitationRequest, userID string) (*responses.UserResponseInvitationResponse, *errutils.Error)
+	Revoke(ctx context.Context, req *requests.RevokeInvitationRequest, userID string) (*responses.RevokeInvitationResponse, *errutils.Error)
+	Resend(ctx context.Context, req *requests.ResendInvitationRequest, userID string) (*responses.ResendInvitationResponse, *errutils.Error)
+	ExpireOverdue(ctx context.Context) (int64, *errutils.Error)
 }
 
 type invitationServiceImpl struct {
@@ -28,6 +34,7 @@ type invitationServiceImpl struct {
 	workspaceRepo       repositories.WorkspaceRepository
 	invitationRepo      repositories.InvitationRepository
 	workspaceMemberRepo repositories.WorkspaceMemberRepository
+	mailRepo            repositories.MailRepository
 	config              *config.Config
 }
 
@@ -36,6 +43,7 @@ func NewInvitationService(
 	workspaceRepo repositories.WorkspaceRepository,
 	invitationRepo repositories.InvitationRepository,
 	workspaceMemberRepo repositories.WorkspaceMemberRepository,
+	mailRepo repositories.MailRepository,
 	config *config.Config,
 ) InvitationService {
 	return &invitationServiceImpl{
@@ -43,6 +51,7 @@ func NewInvitationService(
 		workspaceRepo:       workspaceRepo,
 		invitationRepo:      invitationRepo,
 		workspaceMemberRepo: workspaceMemberRepo,
+		mailRepo:            mailRepo,
 		config:              config,
 	}
 }
@@ -58,14 +67,6 @@ func (i *invitationServiceImpl) Create(ctx context.Context, req *requests.Create
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
 	}
 
-	// Find user by email
-	user, err := i.userRepo.FindByEmail(ctx, req.InviteeEmail)
-	if err != nil {
-		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
-	} else if user == nil {
-		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.BadRequest).WithDebugMessage("Invitee not found")
-	}
-
 	// Check if the inviter is the owner of the workspace
 	inviter, err := i.workspaceMemberRepo.FindByWorkspaceIDAndUserID(ctx, bsonWorkspaceID, bsonInviterUserID)
 	if err != nil {
@@ -73,7 +74,15 @@ func (i *invitationServiceImpl) Create(ctx context.Context, req *requests.Create
 	} else if inviter == nil {
 		return nil, errutils.NewError(exceptions.ErrRequesterNotFoundInWorkspace, errutils.BadRequest).WithDebugMessage("Inviter not found in workspace")
 	} else if inviter.Role != models.WorkspaceMemberRoleOwner {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("Inviter is not an owner")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Inviter is not an owner")
+	}
+
+	// Find user by email, invitees without an account are invited by email instead
+	user, err := i.userRepo.FindByEmail(ctx, req.InviteeEmail)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return i.createEmailInvitation(ctx, req, bsonWorkspaceID, bsonInviterUserID)
 	}
 
 	// Check if the invitee is already a member of the workspace
@@ -103,7 +112,7 @@ func (i *invitationServiceImpl) Create(ctx context.Context, req *requests.Create
 		CreatedBy:     bsonInviterUserID,
 	}
 
-	err = i.invitationRepo.Create(ctx, createInvitationReq)
+	_, err = i.invitationRepo.Create(ctx, createInvitationReq)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	}
@@ -113,6 +122,78 @@ func (i *invitationServiceImpl) Create(ctx context.Context, req *requests.Create
 	}, nil
 }
 
+func (i *invitationServiceImpl) createEmailInvitation(ctx context.Context, req *requests.CreateInvitationRequest, workspaceID bson.ObjectID, inviterUserID bson.ObjectID) (*responses.CreateInvitationResponse, *errutils.Error) {
+	inviteeEmail := strings.ToLower(strings.TrimSpace(req.InviteeEmail))
+
+	// Check if the email is already invited to the workspace
+	invitation, err := i.invitationRepo.FindByWorkspaceIDAndInviteeEmail(ctx, workspaceID, inviteeEmail)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if invitation != nil {
+		return nil, errutils.NewError(exceptions.ErrInvitationAlreadySent, errutils.BadRequest).WithDebugMessage("Invitee is already invited to the workspace")
+	}
+
+	invitation, err = i.invitationRepo.Create(ctx, &repositories.CreateInvitationRequest{
+		WorkspaceID:   workspaceID,
+		InviteeEmail:  &inviteeEmail,
+		Role:          models.InvitationRole(req.Role),
+		Status:        models.InvitationStatusPending,
+		ExpiredAt:     time.Now().Add(constant.InvitationExpirationIn),
+		CustomMessage: req.CustomMessage,
+		CreatedBy:     inviterUserID,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	if svcErr := i.sendInvitationMail(ctx, invitation); svcErr != nil {
+		return nil, svcErr
+	}
+
+	return &responses.CreateInvitationResponse{
+		Message: "Invitation email sent successfully",
+	}, nil
+}
+
+func (i *invitationServiceImpl) sendInvitationMail(ctx context.Context, invitation *models.Invitation) *errutils.Error {
+	workspace, err := i.workspaceRepo.FindByID(ctx, invitation.WorkspaceID)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if workspace == nil {
+		return errutils.NewError(exceptions.ErrWorkspaceNotFound, errutils.NotFound).WithDebugMessage("workspace of the invitation not found")
+	}
+
+	inviter, err := i.userRepo.FindByID(ctx, invitation.CreatedBy)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if inviter == nil {
+		return errutils.NewError(exceptions.ErrUserNotFound, errutils.NotFound).WithDebugMessage("inviter of the invitation not found")
+	}
+
+	token, err := generateInvitationToken(i.config.JWT.InvitationTokenSecret, invitation, *invitation.InviteeEmail)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	var customMessage string
+	if invitation.CustomMessage != nil {
+		customMessage = *invitation.CustomMessage
+	}
+
+	registerURL := fmt.Sprintf("%s/register?invitationToken=%s", strings.TrimRight(i.config.FrontendURL, "/"), url.QueryEscape(token))
+
+	err = i.mailRepo.Send(ctx, &repositories.SendMailRequest{
+		To:      []string{*invitation.InviteeEmail},
+		Subject: fmt.Sprintf("You have been invited to %s", workspace.Name),
+		Body:    buildInvitationMailBody(workspace.Name, inviter.DisplayName, customMessage, registerURL, invitation.ExpiredAt.Format(constant.TimeFormat)),
+	})
+	if err != nil {
+		return errutils.NewError(exceptions.ErrSendInvitationMailFailed, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return nil
+}
+
 func (i *invitationServiceImpl) ListForUser(ctx context.Context, userID string) (*responses.ListInvitationForUserResponse, *errutils.Error) {
 	bsonUserID, err := bson.ObjectIDFromHex(userID)
 	if err != nil {
@@ -137,7 +218,7 @@ func (i *invitationServiceImpl) ListForUser(ctx context.Context, userID string)
 		}
 
 		var status = invitation.Status
-		if status == models.InvitationStatusPending && invitation.ExpiredAt.Before(time.Now()) {
+		if isInvitationExpired(&invitation) {
 			status = models.InvitationStatusExpired
 		}
 
@@ -153,7 +234,7 @@ func (i *invitationServiceImpl) ListForUser(ctx context.Context, userID string)
 			InviterFullName:    inviter.FullName,
 			InviterUserID:      invitation.CreatedBy.Hex(),
 			ExpiredAt:          invitation.ExpiredAt.Format(constant.TimeFormat),
-			IsExpired:          time.Now().After(invitation.ExpiredAt),
+			IsExpired:          isInvitationExpired(&invitation),
 			RespondedAt:        invitation.RespondedAt,
 		})
 	}
@@ -218,7 +299,7 @@ func (i *invitationServiceImpl) ListForWorkspaceOwner(ctx context.Context, req *
 	} else if member == nil {
 		return nil, errutils.NewError(exceptions.ErrMemberNotFoundInWorkspace, errutils.BadRequest).WithDebugMessage("User not found in workspace")
 	} else if member.Role != models.WorkspaceMemberRoleOwner {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not an owner")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not an owner")
 	}
 
 	invitations, totalInvitation, err := i.invitationRepo.SearchInvitationForEachWorkspace(ctx, &repositories.SearchInvitationForEachWorkspaceRequest{
@@ -248,13 +329,29 @@ func (i *invitationServiceImpl) ListForWorkspaceOwner(ctx context.Context, req *
 			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 		}
 
-		invitee, err := i.userRepo.FindByID(ctx, invitation.InviteeUserID)
-		if err != nil {
-			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		// Email invitations have no invitee user until the invitee registers
+		invitee := &models.User{}
+		if !invitation.InviteeUserID.IsZero() {
+			invitee, err = i.userRepo.FindByID(ctx, invitation.InviteeUserID)
+			if err != nil {
+				return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+			} else if invitee == nil {
+				invitee = &models.User{}
+			}
+		}
+
+		var inviteeEmail = invitee.Email
+		if invitation.InviteeEmail != nil {
+			inviteeEmail = *invitation.InviteeEmail
+		}
+
+		var inviteeUserID string
+		if !invitation.InviteeUserID.IsZero() {
+			inviteeUserID = invitation.InviteeUserID.Hex()
 		}
 
 		var status = invitation.Status
-		if invitation.ExpiredAt.Before(time.Now()) {
+		if isInvitationExpired(&invitation) {
 			status = models.InvitationStatusExpired
 		}
 
@@ -268,12 +365,13 @@ func (i *invitationServiceImpl) ListForWorkspaceOwner(ctx context.Context, req *
 			InvitedAt:          invitation.CreatedAt.Format(constant.TimeFormat),
 			InviteeDisplayName: invitee.DisplayName,
 			InviteeFullName:    invitee.FullName,
-			InviteeUserID:      invitation.InviteeUserID.Hex(),
+			InviteeUserID:      inviteeUserID,
+			InviteeEmail:       inviteeEmail,
 			InviterDisplayName: inviter.DisplayName,
 			InviterFullName:    inviter.FullName,
 			InviterUserID:      invitation.CreatedBy.Hex(),
 			ExpiredAt:          invitation.ExpiredAt.Format(constant.TimeFormat),
-			IsExpired:          time.Now().After(invitation.ExpiredAt),
+			IsExpired:          isInvitationExpired(&invitation),
 			RespondedAt:        invitation.RespondedAt,
 		})
 	}
@@ -308,7 +406,11 @@ func (i *invitationServiceImpl) UserResponse(ctx context.Context, req *requests.
 	}
 
 	if invitation.InviteeUserID.Hex() != bsonUserID.Hex() {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("Permission denied")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Permission denied")
+	}
+
+	if isInvitationExpired(invitation) {
+		return nil, errutils.NewError(exceptions.ErrInvitationExpired, errutils.BadRequest).WithDebugMessage("Invitation has expired")
 	}
 
 	if invitation.Status != models.InvitationStatusPending {
@@ -316,18 +418,11 @@ func (i *invitationServiceImpl) UserResponse(ctx context.Context, req *requests.
 	}
 
 	if req.Action == constant.InvitationActionAccept {
-		var role models.WorkspaceMemberRole
-		if invitation.Role == models.InvitationRoleModerator {
-			role = models.WorkspaceMemberRoleModerator
-		} else {
-			role = models.WorkspaceMemberRoleMember
-		}
-
 		// Add the invitee as a member of the workspace
 		createWorkspaceMemberReq := &repositories.CreateWorkspaceMemberRequest{
 			WorkspaceID: invitation.WorkspaceID,
 			UserID:      invitation.InviteeUserID,
-			Role:        role,
+			Role:        invitationRoleToWorkspaceMemberRole(invitation.Role),
 		}
 
 		_, err = i.workspaceMemberRepo.Create(ctx, createWorkspaceMemberReq)
@@ -358,3 +453,89 @@ func (i *invitationServiceImpl) UserResponse(ctx context.Context, req *requests.
 		return nil, errutils.NewError(exceptions.ErrInvalidInvitationAction, errutils.BadRequest).WithDebugMessage("Invalid invitation action")
 	}
 }
+
+func (i *invitationServiceImpl) Revoke(ctx context.Context, req *requests.RevokeInvitationRequest, userID string) (*responses.RevokeInvitationResponse, *errutils.Error) {
+	invitation, svcErr := i.findInvitationAsWorkspaceOwner(ctx, req.InvitationID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if invitation.Status != models.InvitationStatusPending {
+		return nil, errutils.NewError(exceptions.ErrInvitationNotPending, errutils.BadRequest).WithDebugMessage("Only pending invitations can be revoked")
+	}
+
+	err := i.invitationRepo.UpdateStatus(ctx, invitation.ID, models.InvitationStatusRevoked)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.RevokeInvitationResponse{
+		Message: "Invitation revoked successfully",
+	}, nil
+}
+
+func (i *invitationServiceImpl) Resend(ctx context.Context, req *requests.ResendInvitationRequest, userID string) (*responses.ResendInvitationResponse, *errutils.Error) {
+	invitation, svcErr := i.findInvitationAsWorkspaceOwner(ctx, req.InvitationID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	// Only invitations nobody has acted on can be resent, answered or revoked ones need a new invitation
+	if invitation.Status != models.InvitationStatusPending && invitation.Status != models.InvitationStatusExpired {
+		return nil, errutils.NewError(exceptions.ErrInvitationCannotBeResent, errutils.BadRequest).WithFields(invitation.Status.String())
+	}
+
+	invitation, err := i.invitationRepo.Renew(ctx, invitation.ID, time.Now().Add(constant.InvitationExpirationIn))
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	// Invitees without an account need a fresh registration link, others see the invitation in the app again
+	if invitation.InviteeEmail != nil && invitation.InviteeUserID.IsZero() {
+		if svcErr := i.sendInvitationMail(ctx, invitation); svcErr != nil {
+			return nil, svcErr
+		}
+	}
+
+	return &responses.ResendInvitationResponse{
+		Message:   "Invitation resent successfully",
+		ExpiredAt: invitation.ExpiredAt.Format(constant.TimeFormat),
+	}, nil
+}
+
+func (i *invitationServiceImpl) ExpireOverdue(ctx context.Context) (int64, *errutils.Error) {
+	expiredCount, err := i.invitationRepo.ExpireOverdue(ctx)
+	if err != nil {
+		return 0, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return expiredCount, nil
+}
+
+func (i *invitationServiceImpl) findInvitationAsWorkspaceOwner(ctx context.Context, invitationID string, userID string) (*models.Invitation, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonInvitationID, err := bson.ObjectIDFromHex(invitationID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	invitation, err := i.invitationRepo.FindByID(ctx, bsonInvitationID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if invitation == nil {
+		return nil, errutils.NewError(exceptions.ErrInvitationNotFound, errutils.NotFound).WithDebugMessage("Invitation not found")
+	}
+
+	member, err := i.workspaceMemberRepo.FindByWorkspaceIDAndUserID(ctx, invitation.WorkspaceID, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil || member.Role != models.WorkspaceMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not an owner")
+	}
+
+	return invitation, nil
+}
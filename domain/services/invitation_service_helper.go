@@ -0,0 +1,56 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func generateInvitationToken(secret string, invitation *models.Invitation, email string) (string, error) {
+	return signToken(secret, models.InvitationTokenClaims{
+		InvitationID: invitation.ID.Hex(),
+		Email:        email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   invitation.ID.Hex(),
+			ExpiresAt: jwt.NewNumericDate(invitation.ExpiredAt),
+			IssuedAt:  jwt.NewNumericDate(invitation.CreatedAt),
+		},
+	})
+}
+
+func parseInvitationToken(secret string, tokenString string) (*models.InvitationTokenClaims, error) {
+	claims := &models.InvitationTokenClaims{}
+	if err := parseToken(secret, tokenString, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func invitationRoleToWorkspaceMemberRole(role models.InvitationRole) models.WorkspaceMemberRole {
+	if role == models.InvitationRoleModerator {
+		return models.WorkspaceMemberRoleModerator
+	}
+	return models.WorkspaceMemberRoleMember
+}
+
+func buildInvitationMailBody(workspaceName string, inviterName string, customMessage string, registerURL string, expiredAt string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s has invited you to join the %s workspace on Task Nexus.\n\n", inviterName, workspaceName)
+	if customMessage != "" {
+		fmt.Fprintf(&sb, "%s\n\n", customMessage)
+	}
+	fmt.Fprintf(&sb, "Create your account using the link below:\n%s\n\n", registerURL)
+	fmt.Fprintf(&sb, "This invitation expires on %s.\n", expiredAt)
+
+	return sb.String()
+}
+
+// isInvitationExpired also covers pending invitations the expiry job has not picked up yet.
+func isInvitationExpired(invitation *models.Invitation) bool {
+	return invitation.Status == models.InvitationStatusExpired ||
+		(invitation.Status == models.InvitationStatusPending && invitation.ExpiredAt.Before(time.Now()))
+}
@@ -2,34 +2,41 @@ package services
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
 	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
 	"github.com/cnc-csku/task-nexus/task-management/domain/models"
 	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
 	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/responses"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 type ProjectMemberService interface {
 	UpdatePosition(ctx context.Context, req *requests.UpdateMemberPositionRequest, userID string) (*models.ProjectMember, *errutils.Error)
+	UpdateRole(ctx context.Context, req *requests.UpdateMemberRoleRequest, userID string) (*models.ProjectMember, *errutils.Error)
+	Remove(ctx context.Context, req *requests.RemoveMemberRequest, userID string) (*responses.RemoveMemberResponse, *errutils.Error)
 }
 
 type projectMemberServiceImpl struct {
 	userRepo          repositories.UserRepository
 	projectRepo       repositories.ProjectRepository
 	projectMemberRepo repositories.ProjectMemberRepository
+	taskRepo          repositories.TaskRepository
 }
 
 func NewProjectMemberService(
 	userRepo repositories.UserRepository,
 	projectRepo repositories.ProjectRepository,
 	projectMemberRepo repositories.ProjectMemberRepository,
+	taskRepo repositories.TaskRepository,
 ) ProjectMemberService {
 	return &projectMemberServiceImpl{
 		userRepo:          userRepo,
 		projectRepo:       projectRepo,
 		projectMemberRepo: projectMemberRepo,
+		taskRepo:          taskRepo,
 	}
 }
 
@@ -47,14 +54,12 @@ func (s *projectMemberServiceImpl) UpdatePosition(ctx context.Context, req *requ
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
 	}
 
-	// Check if the requester is the owner or moderator of the project
+	// Check if the requester is a member of the project, role permissions are checked by the permission middleware
 	member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonRequesterUserID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
 		return nil, errutils.NewError(exceptions.ErrMemberNotFoundInProject, errutils.BadRequest).WithDebugMessage("Member not found in project")
-	} else if member.Role != models.ProjectMemberRoleOwner && member.Role != models.ProjectMemberRoleModerator {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Only owner and moderator can update member position")
 	} else if req.Position == string(models.ProjectMemberRoleOwner) && member.Role != models.ProjectMemberRoleOwner {
 		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Only owner can update member position to owner")
 	}
@@ -80,3 +85,204 @@ func (s *projectMemberServiceImpl) UpdatePosition(ctx context.Context, req *requ
 
 	return member, nil
 }
+
+func (s *projectMemberServiceImpl) UpdateRole(ctx context.Context, req *requests.UpdateMemberRoleRequest, userID string) (*models.ProjectMember, *errutils.Error) {
+	bsonRequesterUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+	bsonProjectID, err := bson.ObjectIDFromHex(req.ProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+	bsonTargetUserID, err := bson.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	// Ownership can only be changed through ownership transfer
+	role := models.ProjectMemberRole(req.Role)
+	if !role.IsValid() || role == models.ProjectMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrInvalidProjectMemberRole, errutils.BadRequest).WithDebugMessage("Role must be MODERATOR or MEMBER")
+	}
+
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, bsonProjectID); svcErr != nil {
+		return nil, svcErr
+	}
+
+	// Check if the requester is a member of the project, role permissions are checked by the permission middleware
+	requester, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonRequesterUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if requester == nil {
+		return nil, errutils.NewError(exceptions.ErrMemberNotFoundInProject, errutils.BadRequest).WithDebugMessage("Member not found in project")
+	}
+
+	member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonTargetUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, errutils.NewError(exceptions.ErrMemberNotFoundInProject, errutils.BadRequest).WithDebugMessage("Member not found in project")
+	} else if member.Role == models.ProjectMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrCannotModifyProjectOwner, errutils.BadRequest)
+	}
+
+	member, err = s.projectMemberRepo.UpdateRoleByID(ctx, &repositories.UpdateRoleRequest{
+		ID:   member.ID,
+		Role: role,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return member, nil
+}
+
+func (s *projectMemberServiceImpl) Remove(ctx context.Context, req *requests.RemoveMemberRequest, userID string) (*responses.RemoveMemberResponse, *errutils.Error) {
+	bsonRequesterUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+	bsonProjectID, err := bson.ObjectIDFromHex(req.ProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+	bsonTargetUserID, err := bson.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	project, err := s.projectRepo.FindByProjectID(ctx, bsonProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if project == nil {
+		return nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.BadRequest)
+	}
+
+	if svcErr := ensureProjectIsActive(project); svcErr != nil {
+		return nil, svcErr
+	}
+
+	// Check if the requester is a member of the project, role permissions are checked by the permission middleware
+	requester, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonRequesterUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if requester == nil {
+		return nil, errutils.NewError(exceptions.ErrMemberNotFoundInProject, errutils.BadRequest).WithDebugMessage("Member not found in project")
+	}
+
+	member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonTargetUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, errutils.NewError(exceptions.ErrMemberNotFoundInProject, errutils.BadRequest).WithDebugMessage("Member not found in project")
+	} else if member.Role == models.ProjectMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrCannotModifyProjectOwner, errutils.BadRequest)
+	} else if member.Role == models.ProjectMemberRoleModerator && !project.HasPermission(requester.Role, models.ProjectPermissionManageMemberRoles) {
+		// Removing a moderator takes away their role, so it needs the same permission as changing it
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage(fmt.Sprintf("Role %s does not have permission %s", requester.Role, models.ProjectPermissionManageMemberRoles))
+	}
+
+	// Work is handed over to the reassignee when one is given, otherwise it is left unassigned
+	var reassignee *bson.ObjectID
+	if req.ReassignToUserID != "" {
+		bsonReassigneeUserID, err := bson.ObjectIDFromHex(req.ReassignToUserID)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+		}
+
+		if bsonReassigneeUserID == bsonTargetUserID {
+			return nil, errutils.NewError(exceptions.ErrInvalidReassignee, errutils.BadRequest).WithDebugMessage("Cannot reassign to the member being removed")
+		}
+
+		reassigneeMember, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonReassigneeUserID)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		} else if reassigneeMember == nil {
+			return nil, errutils.NewError(exceptions.ErrInvalidReassignee, errutils.BadRequest).WithDebugMessage("Reassignee is not a member of the project")
+		}
+
+		reassignee = &bsonReassigneeUserID
+	}
+
+	tasks, err := s.taskRepo.FindByProjectIDAndAssigneeOrApprover(ctx, bsonProjectID, bsonTargetUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	var (
+		updateTaskRequests = make([]repositories.UpdateTaskAssigneesAndApprovalsRequest, 0, len(tasks))
+		assigneeTaskRefs   = make([]string, 0)
+		approvalTaskRefs   = make([]string, 0)
+	)
+	for _, task := range tasks {
+		isAssignee := false
+		assignees := make([]models.TaskAssignee, 0, len(task.Assignees))
+		for _, assignee := range task.Assignees {
+			if assignee.UserID != nil && *assignee.UserID == bsonTargetUserID {
+				assignee.UserID = reassignee
+				isAssignee = true
+			}
+			assignees = append(assignees, assignee)
+		}
+
+		hasReassigneeApproval := false
+		if reassignee != nil {
+			for _, approval := range task.Approvals {
+				if approval.UserID == *reassignee {
+					hasReassigneeApproval = true
+					break
+				}
+			}
+		}
+
+		// Approvals already given are kept as history, pending ones are handed over or dropped
+		isPendingApprover := false
+		approvals := make([]models.TaskApproval, 0, len(task.Approvals))
+		for _, approval := range task.Approvals {
+			if approval.UserID == bsonTargetUserID && !approval.IsApproved {
+				isPendingApprover = true
+				if reassignee == nil || hasReassigneeApproval {
+					continue
+				}
+				approval.UserID = *reassignee
+			}
+			approvals = append(approvals, approval)
+		}
+
+		if !isAssignee && !isPendingApprover {
+			continue
+		}
+
+		if isAssignee {
+			assigneeTaskRefs = append(assigneeTaskRefs, task.TaskRef)
+		}
+		if isPendingApprover {
+			approvalTaskRefs = append(approvalTaskRefs, task.TaskRef)
+		}
+
+		updateTaskRequests = append(updateTaskRequests, repositories.UpdateTaskAssigneesAndApprovalsRequest{
+			ID:        task.ID,
+			Assignees: assignees,
+			Approvals: approvals,
+			UpdatedBy: bsonRequesterUserID,
+		})
+	}
+
+	// Tasks are updated before the member is removed, so a failed request can be retried
+	err = s.taskRepo.BulkUpdateAssigneesAndApprovals(ctx, updateTaskRequests)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	_, err = s.projectMemberRepo.RemoveByID(ctx, member.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.RemoveMemberResponse{
+		Message:          "Member removed successfully",
+		AssigneeTaskRefs: assigneeTaskRefs,
+		ApprovalTaskRefs: approvalTaskRefs,
+	}, nil
+}
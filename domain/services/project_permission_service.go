@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ProjectPermissionService interface {
+	Authorize(ctx context.Context, projectID string, userID string, permission models.ProjectPermission) (*models.ProjectMember, *errutils.Error)
+	List(ctx context.Context, req *requests.ListProjectPermissionsPathParams, userID string) (models.ProjectPermissionMatrix, *errutils.Error)
+	Update(ctx context.Context, req *requests.UpdateProjectPermissionsRequest, userID string) (models.ProjectPermissionMatrix, *errutils.Error)
+}
+
+type projectPermissionServiceImpl struct {
+	projectRepo       repositories.ProjectRepository
+	projectMemberRepo repositories.ProjectMemberRepository
+	workspaceRepo     repositories.WorkspaceRepository
+	userRepo          repositories.UserRepository
+}
+
+func NewProjectPermissionService(
+	projectRepo repositories.ProjectRepository,
+	projectMemberRepo repositories.ProjectMemberRepository,
+	workspaceRepo repositories.WorkspaceRepository,
+	userRepo repositories.UserRepository,
+) ProjectPermissionService {
+	return &projectPermissionServiceImpl{
+		projectRepo:       projectRepo,
+		projectMemberRepo: projectMemberRepo,
+		workspaceRepo:     workspaceRepo,
+		userRepo:          userRepo,
+	}
+}
+
+func (s *projectPermissionServiceImpl) Authorize(ctx context.Context, projectID string, userID string, permission models.ProjectPermission) (*models.ProjectMember, *errutils.Error) {
+	project, member, svcErr := s.findProjectAndMember(ctx, projectID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if !project.HasPermission(member.Role, permission) {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage(fmt.Sprintf("Role %s does not have permission %s", member.Role, permission))
+	}
+
+	return member, nil
+}
+
+func (s *projectPermissionServiceImpl) List(ctx context.Context, req *requests.ListProjectPermissionsPathParams, userID string) (models.ProjectPermissionMatrix, *errutils.Error) {
+	project, _, svcErr := s.findProjectAndMember(ctx, req.ProjectID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	return project.GetPermissionMatrix(), nil
+}
+
+func (s *projectPermissionServiceImpl) Update(ctx context.Context, req *requests.UpdateProjectPermissionsRequest, userID string) (models.ProjectPermissionMatrix, *errutils.Error) {
+	project, member, svcErr := s.findProjectAndMember(ctx, req.ProjectID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if member.Role != models.ProjectMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Only owner can update project permissions")
+	}
+
+	// The owner is implicitly granted every permission, so it is always stored alongside the configured roles
+	permissions := make(models.ProjectPermissionMatrix, len(req.Permissions))
+	for rawPermission, rawRoles := range req.Permissions {
+		permission := models.ProjectPermission(rawPermission)
+		if !permission.IsValid() {
+			return nil, errutils.NewError(exceptions.ErrInvalidProjectPermission, errutils.BadRequest).WithFields(rawPermission)
+		}
+
+		roles := []models.ProjectMemberRole{models.ProjectMemberRoleOwner}
+		for _, rawRole := range rawRoles {
+			role := models.ProjectMemberRole(rawRole)
+			if !role.IsValid() {
+				return nil, errutils.NewError(exceptions.ErrInvalidProjectMemberRole, errutils.BadRequest).WithFields(rawRole)
+			} else if role == models.ProjectMemberRoleOwner {
+				continue
+			}
+			roles = append(roles, role)
+		}
+
+		permissions[permission] = roles
+	}
+
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	updatedProject, err := s.projectRepo.UpdatePermissions(ctx, &repositories.UpdateProjectPermissionsRequest{
+		ProjectID:   project.ID,
+		Permissions: permissions,
+		UpdatedBy:   bsonUserID,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return updatedProject.GetPermissionMatrix(), nil
+}
+
+func (s *projectPermissionServiceImpl) findProjectAndMember(ctx context.Context, projectID string, userID string) (*models.Project, *models.ProjectMember, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonProjectID, err := bson.ObjectIDFromHex(projectID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	project, err := s.projectRepo.FindByProjectID(ctx, bsonProjectID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if project == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.NotFound)
+	}
+
+	member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	workspace, err := s.workspaceRepo.FindByID(ctx, project.WorkspaceID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if workspace == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrWorkspaceNotFound, errutils.NotFound)
+	}
+
+	if svcErr := checkWorkspaceTwoFactorRequirement(ctx, s.userRepo, workspace, bsonUserID); svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	return project, member, nil
+}
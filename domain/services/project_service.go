@@ -2,8 +2,14 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
 	"math"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/cnc-csku/task-nexus-go-lib/utils/array"
 	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
@@ -31,16 +37,29 @@ type ProjectService interface {
 	ListWorkflows(ctx context.Context, req *requests.ListWorkflowsPathParams) ([]models.ProjectWorkflow, *errutils.Error)
 	UpdateAttributeTemplates(ctx context.Context, req *requests.UpdateAttributeTemplatesRequest, userID string) (*responses.UpdateAttributeTemplatesResponse, *errutils.Error)
 	ListAttributeTemplates(ctx context.Context, req *requests.ListAttributeTemplatesPathParams) ([]models.ProjectAttributeTemplate, *errutils.Error)
+	Archive(ctx context.Context, req *requests.ArchiveProjectPathParams, userID string) (*models.Project, *errutils.Error)
+	Unarchive(ctx context.Context, req *requests.UnarchiveProjectPathParams, userID string) (*models.Project, *errutils.Error)
+	RequestDeletion(ctx context.Context, req *requests.RequestProjectDeletionPathParams, userID string) (*responses.RequestProjectDeletionResponse, *errutils.Error)
+	Delete(ctx context.Context, req *requests.DeleteProjectRequest, userID string) (*responses.DeleteProjectResponse, *errutils.Error)
+	TransferOwnership(ctx context.Context, req *requests.TransferProjectOwnershipRequest, userID string) (*responses.TransferProjectOwnershipResponse, *errutils.Error)
 }
 
+// projectDeletionTokenTTL is how long a confirmation token issued by RequestDeletion stays valid.
+const projectDeletionTokenTTL = 10 * time.Minute
+
 type projectServiceImpl struct {
-	userRepo            repositories.UserRepository
-	workspaceRepo       repositories.WorkspaceRepository
-	workspaceMemberRepo repositories.WorkspaceMemberRepository
-	projectRepo         repositories.ProjectRepository
-	projectMemberRepo   repositories.ProjectMemberRepository
-	config              *config.Config
-	taskRepo            repositories.TaskRepository
+	userRepo                 repositories.UserRepository
+	workspaceRepo            repositories.WorkspaceRepository
+	workspaceMemberRepo      repositories.WorkspaceMemberRepository
+	projectRepo              repositories.ProjectRepository
+	projectMemberRepo        repositories.ProjectMemberRepository
+	config                   *config.Config
+	taskRepo                 repositories.TaskRepository
+	projectTemplateRepo      repositories.ProjectTemplateRepository
+	sprintRepo               repositories.SprintRepository
+	taskCommentRepo          repositories.TaskCommentRepository
+	projectDeletionTokenRepo repositories.ProjectDeletionTokenCacheRepository
+	taskEmbeddingRepo        repositories.TaskEmbeddingRepository
 }
 
 func NewProjectService(
@@ -51,15 +70,25 @@ func NewProjectService(
 	projectMemberRepo repositories.ProjectMemberRepository,
 	config *config.Config,
 	taskRepo repositories.TaskRepository,
+	projectTemplateRepo repositories.ProjectTemplateRepository,
+	sprintRepo repositories.SprintRepository,
+	taskCommentRepo repositories.TaskCommentRepository,
+	projectDeletionTokenRepo repositories.ProjectDeletionTokenCacheRepository,
+	taskEmbeddingRepo repositories.TaskEmbeddingRepository,
 ) ProjectService {
 	return &projectServiceImpl{
-		userRepo:            userRepo,
-		workspaceRepo:       workspaceRepo,
-		workspaceMemberRepo: workspaceMemberRepo,
-		projectRepo:         projectRepo,
-		projectMemberRepo:   projectMemberRepo,
-		config:              config,
-		taskRepo:            taskRepo,
+		userRepo:                 userRepo,
+		workspaceRepo:            workspaceRepo,
+		workspaceMemberRepo:      workspaceMemberRepo,
+		projectRepo:              projectRepo,
+		projectMemberRepo:        projectMemberRepo,
+		config:                   config,
+		taskRepo:                 taskRepo,
+		projectTemplateRepo:      projectTemplateRepo,
+		sprintRepo:               sprintRepo,
+		taskCommentRepo:          taskCommentRepo,
+		projectDeletionTokenRepo: projectDeletionTokenRepo,
+		taskEmbeddingRepo:        taskEmbeddingRepo,
 	}
 }
 
@@ -80,7 +109,7 @@ func (p *projectServiceImpl) Create(ctx context.Context, req *requests.CreatePro
 	} else if member == nil {
 		return nil, errutils.NewError(exceptions.ErrMemberNotFoundInWorkspace, errutils.BadRequest)
 	} else if member.Role != models.WorkspaceMemberRoleOwner && member.Role != models.WorkspaceMemberRoleModerator {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest)
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden)
 	}
 
 	// Check if project's name already exists
@@ -107,6 +136,84 @@ func (p *projectServiceImpl) Create(ctx context.Context, req *requests.CreatePro
 		Role:   models.ProjectMemberRoleOwner,
 	}
 
+	if req.TemplateID != nil && req.SourceProjectID != nil {
+		return nil, errutils.NewError(exceptions.ErrMultipleProjectSourcesProvided, errutils.BadRequest)
+	}
+
+	var (
+		workflows          = models.GetDefaultWorkflows()
+		positions          = models.GetDefaultPositions()
+		attributeTemplates = []models.ProjectAttributeTemplate{}
+		epics              []models.ProjectTemplateEpic
+		setupStatus        models.ProjectSetupStatus
+	)
+	if req.TemplateID != nil {
+		bsonTemplateID, err := bson.ObjectIDFromHex(*req.TemplateID)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+		}
+
+		template, err := p.projectTemplateRepo.FindByID(ctx, bsonTemplateID)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+		} else if template == nil || template.WorkspaceID != bsonWorkspaceID {
+			return nil, errutils.NewError(exceptions.ErrProjectTemplateNotFound, errutils.NotFound)
+		}
+
+		workflows = template.Workflows
+		positions = template.Positions
+		attributeTemplates = template.AttributeTemplates
+		epics = template.Epics
+
+		// The owner takes the chosen or the first position of the template, without one the setup wizard still runs
+		if req.OwnerPosition != nil {
+			if !slices.Contains(template.Positions, *req.OwnerPosition) {
+				return nil, errutils.NewError(exceptions.ErrPositionNotInProject, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("position %q is not defined in the template", *req.OwnerPosition))
+			}
+			owner.Position = *req.OwnerPosition
+		} else if len(template.Positions) > 0 {
+			owner.Position = template.Positions[0]
+		}
+		if owner.Position != "" {
+			setupStatus = models.ProjectSetupStatusCompleted
+		}
+	} else if req.SourceProjectID != nil {
+		bsonSourceProjectID, err := bson.ObjectIDFromHex(*req.SourceProjectID)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+		}
+
+		sourceProject, err := p.projectRepo.FindByProjectID(ctx, bsonSourceProjectID)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+		} else if sourceProject == nil {
+			return nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.NotFound)
+		} else if sourceProject.WorkspaceID != bsonWorkspaceID {
+			return nil, errutils.NewError(exceptions.ErrProjectNotInWorkspace, errutils.BadRequest)
+		}
+
+		sourceMember, err := p.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonSourceProjectID, bsonUserID)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+		} else if sourceMember == nil {
+			return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the source project")
+		}
+
+		if req.IncludeEpics {
+			var svcErr *errutils.Error
+			epics, svcErr = getProjectTemplateEpics(ctx, p.taskRepo, bsonSourceProjectID)
+			if svcErr != nil {
+				return nil, svcErr
+			}
+		}
+
+		workflows = sourceProject.Workflows
+		positions = sourceProject.Positions
+		attributeTemplates = sourceProject.AttributeTemplates
+		owner.Position = sourceMember.Position
+		setupStatus = models.ProjectSetupStatusCompleted
+	}
+
 	project := &repositories.CreateProjectRequest{
 		WorkspaceID:        bsonWorkspaceID,
 		Name:               req.Name,
@@ -114,9 +221,10 @@ func (p *projectServiceImpl) Create(ctx context.Context, req *requests.CreatePro
 		Description:        req.Description,
 		Status:             models.ProjectStatusActive,
 		Owner:              owner,
-		Workflows:          models.GetDefaultWorkflows(),
-		Positions:          models.GetDefaultPositions(),
-		AttributeTemplates: []models.ProjectAttributeTemplate{},
+		Workflows:          workflows,
+		Positions:          positions,
+		AttributeTemplates: attributeTemplates,
+		SetupStatus:        setupStatus,
 		CreatedBy:          bsonUserID,
 	}
 
@@ -125,12 +233,17 @@ func (p *projectServiceImpl) Create(ctx context.Context, req *requests.CreatePro
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
 	}
 
+	if svcErr := p.createEpicsFromTemplate(ctx, createdProject, epics, bsonUserID); svcErr != nil {
+		return nil, svcErr
+	}
+
 	res := &responses.CreateProjectResponse{
 		ID:            createdProject.ID.Hex(),
 		WorkspaceID:   createdProject.WorkspaceID.Hex(),
 		Name:          createdProject.Name,
 		ProjectPrefix: createdProject.ProjectPrefix,
 		Description:   createdProject.Description,
+		SetupStatus:   createdProject.SetupStatus.String(),
 	}
 
 	return res, nil
@@ -239,7 +352,7 @@ func (p *projectServiceImpl) GetProjectDetail(ctx context.Context, req *requests
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
 	}
 
 	project, err := p.projectRepo.FindByProjectID(ctx, bsonProjectID)
@@ -308,7 +421,7 @@ func (p *projectServiceImpl) UpdateDetail(ctx context.Context, req *requests.Upd
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("Requester is not owner of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Requester is not owner of the project")
 	}
 
 	updatedProject, err := p.projectRepo.UpdateDetail(ctx, &repositories.UpdateProjectDetailRequest{
@@ -338,14 +451,12 @@ func (p *projectServiceImpl) UpdateSetupStatus(ctx context.Context, req *request
 		return nil, errutils.NewError(exceptions.ErrInvalidProjectSetupStatus, errutils.BadRequest).WithDebugMessage("Invalid project setup status")
 	}
 
-	// Check if the user is owner of the project
+	// Check if the user is a member of the project, role permissions are checked by the permission middleware
 	member, err := p.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
 	} else if member == nil {
 		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.BadRequest).WithDebugMessage("User not found")
-	} else if member.Role != models.ProjectMemberRoleOwner {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("Requester is not owner of the project")
 	}
 
 	project, err := p.projectRepo.UpdateSetupStatus(ctx, &repositories.UpdateProjectSetupStatus{
@@ -374,14 +485,12 @@ func (p *projectServiceImpl) UpdatePositions(ctx context.Context, req *requests.
 		return nil, errutils.NewError(exceptions.ErrNoPositionProvided, errutils.BadRequest).WithDebugMessage("No position provided")
 	}
 
-	// Check if the user is owner or moderator of the project
+	// Check if the user is a member of the project, role permissions are checked by the permission middleware
 	member, err := p.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
 	} else if member == nil {
 		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.BadRequest)
-	} else if member.Role != models.ProjectMemberRoleOwner && member.Role != models.ProjectMemberRoleModerator {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest)
 	}
 
 	currentPositions, err := p.projectRepo.FindPositionByProjectID(ctx, bsonProjectID)
@@ -461,14 +570,12 @@ func (p *projectServiceImpl) AddMembers(ctx context.Context, req *requests.AddPr
 		}, nil
 	}
 
-	// Check if the user is owner or moderator of the project
+	// Check if the user is a member of the project, role permissions are checked by the permission middleware
 	member, err := p.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
 	} else if member == nil {
 		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.BadRequest)
-	} else if member.Role != models.ProjectMemberRoleOwner && member.Role != models.ProjectMemberRoleModerator {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest)
 	}
 
 	project, err := p.projectRepo.FindByProjectID(ctx, bsonProjectID)
@@ -559,8 +666,9 @@ func (p *projectServiceImpl) ListMembers(ctx context.Context, req *requests.List
 	}
 
 	users, totalUser, err := p.userRepo.SearchWithUserIDs(ctx, &repositories.SearchUserWithUserIDsRequest{
-		UserIDs: userIDs,
-		Keyword: req.Keyword,
+		UserIDs:            userIDs,
+		Keyword:            req.Keyword,
+		IncludeDeactivated: req.IncludeDeactivated,
 		PaginationRequest: repositories.PaginationRequest{
 			Page:     req.PaginationRequest.Page,
 			PageSize: req.PaginationRequest.PageSize,
@@ -586,14 +694,15 @@ func (p *projectServiceImpl) ListMembers(ctx context.Context, req *requests.List
 			}
 
 			memberResp = append(memberResp, responses.ListProjectMembersResponseMember{
-				UserID:      user.ID.Hex(),
-				Email:       user.Email,
-				FullName:    user.FullName,
-				DisplayName: user.DisplayName,
-				ProfileUrl:  profileUrl,
-				Role:        member.Role.String(),
-				Position:    member.Position,
-				JoinedAt:    member.JoinedAt,
+				UserID:        user.ID.Hex(),
+				Email:         user.Email,
+				FullName:      user.FullName,
+				DisplayName:   user.DisplayName,
+				ProfileUrl:    profileUrl,
+				Role:          member.Role.String(),
+				Position:      member.Position,
+				JoinedAt:      member.JoinedAt,
+				DeactivatedAt: user.DeactivatedAt,
 			})
 		}
 	}
@@ -631,14 +740,12 @@ func (p *projectServiceImpl) UpdateWorkflows(ctx context.Context, req *requests.
 		return nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.NotFound)
 	}
 
-	// Check if the user is owner or moderator of the project
+	// Check if the user is a member of the project, role permissions are checked by the permission middleware
 	member, err := p.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest)
-	} else if member.Role != models.ProjectMemberRoleOwner && member.Role != models.ProjectMemberRoleModerator {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest)
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden)
 	}
 
 	currentWorkflows, err := p.projectRepo.FindWorkflowByProjectID(ctx, bsonProjectID)
@@ -658,21 +765,59 @@ func (p *projectServiceImpl) UpdateWorkflows(ctx context.Context, req *requests.
 		}
 	}
 
-	// Check if the deleted workflows are used by any task
+	// The mapping target must be kept, while the mapping source must be removed
+	statusMappings := make(map[string]string, len(req.StatusMappings))
+	for _, mapping := range req.StatusMappings {
+		if array.ContainAny(inputtedStatus, []string{mapping.FromStatus}) || !array.ContainAny(inputtedStatus, []string{mapping.ToStatus}) {
+			return nil, errutils.NewError(exceptions.ErrInvalidStatusMapping, errutils.BadRequest).WithDebugMessage("Status mapping must map a removed status to an existing status").WithFields(mapping.FromStatus)
+		} else if _, exists := statusMappings[mapping.FromStatus]; exists {
+			return nil, errutils.NewError(exceptions.ErrInvalidStatusMapping, errutils.BadRequest).WithDebugMessage("Status is mapped more than once").WithFields(mapping.FromStatus)
+		}
+
+		statusMappings[mapping.FromStatus] = mapping.ToStatus
+		if !array.ContainAny(deletedWorkflows, []string{mapping.FromStatus}) {
+			deletedWorkflows = append(deletedWorkflows, mapping.FromStatus)
+		}
+	}
+
+	// Check if the deleted workflows are used by any task without a mapping target
 	tasks, err := p.taskRepo.FindByProjectIDAndStatuses(ctx, bsonProjectID, deletedWorkflows)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
-	} else if len(tasks) > 0 {
-		statusMap := make(map[string]struct{})
-		errFields := make([]string, 0)
-		for _, task := range tasks {
+	}
+
+	statusMap := make(map[string]struct{})
+	errFields := make([]string, 0)
+	tasksByStatus := make(map[string][]*models.Task)
+	for _, task := range tasks {
+		if _, mapped := statusMappings[task.Status]; !mapped {
 			if _, exists := statusMap[task.Status]; !exists {
 				statusMap[task.Status] = struct{}{}
 				errFields = append(errFields, task.Status)
 			}
+			continue
 		}
 
-		return nil, errutils.NewError(exceptions.ErrWorkflowUsedByTask, errutils.BadRequest).WithDebugMessage("Workflow is used by task").WithFields(errFields...)
+		tasksByStatus[task.Status] = append(tasksByStatus[task.Status], task)
+	}
+
+	if len(errFields) > 0 {
+		return nil, errutils.NewError(exceptions.ErrWorkflowUsedByTask, errutils.BadRequest).WithDebugMessage("Workflow is used by task, provide a status mapping to migrate the tasks").WithFields(errFields...)
+	}
+
+	migrations := make([]responses.UpdateWorkflowsResponseMigration, 0, len(req.StatusMappings))
+	for _, mapping := range req.StatusMappings {
+		taskRefs := make([]string, 0, len(tasksByStatus[mapping.FromStatus]))
+		for _, task := range tasksByStatus[mapping.FromStatus] {
+			taskRefs = append(taskRefs, task.TaskRef)
+		}
+
+		migrations = append(migrations, responses.UpdateWorkflowsResponseMigration{
+			FromStatus: mapping.FromStatus,
+			ToStatus:   mapping.ToStatus,
+			TaskCount:  len(taskRefs),
+			TaskRefs:   taskRefs,
+		})
 	}
 
 	var (
@@ -712,13 +857,44 @@ func (p *projectServiceImpl) UpdateWorkflows(ctx context.Context, req *requests.
 		return nil, errutils.NewError(exceptions.ErrNoIsDoneWorkflow, errutils.BadRequest).WithDebugMessage("No is done workflow")
 	}
 
+	if req.DryRun {
+		return &responses.UpdateWorkflowsResponse{
+			Message:    "Workflow migration preview",
+			DryRun:     true,
+			Migrations: migrations,
+		}, nil
+	}
+
+	// Migrate the tasks first, so a failed update can be retried with the same request
+	for _, mapping := range req.StatusMappings {
+		mappedTasks := tasksByStatus[mapping.FromStatus]
+		if len(mappedTasks) == 0 {
+			continue
+		}
+
+		taskIDs := make([]bson.ObjectID, 0, len(mappedTasks))
+		for _, task := range mappedTasks {
+			taskIDs = append(taskIDs, task.ID)
+		}
+
+		err = p.taskRepo.UpdateManyTasksStatus(ctx, &repositories.UpdateManyTasksStatusRequest{
+			TaskIDs:   taskIDs,
+			Status:    mapping.ToStatus,
+			UpdatedBy: bsonUserID,
+		})
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+		}
+	}
+
 	err = p.projectRepo.UpdateWorkflows(ctx, bsonProjectID, workflows)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
 	}
 
 	return &responses.UpdateWorkflowsResponse{
-		Message: "Workflow added successfully",
+		Message:    "Workflow added successfully",
+		Migrations: migrations,
 	}, nil
 }
 
@@ -767,22 +943,166 @@ func (p *projectServiceImpl) UpdateAttributeTemplates(ctx context.Context, req *
 		return nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.NotFound)
 	}
 
-	// Check if the user is owner or moderator of the project
+	// Check if the user is a member of the project, role permissions are checked by the permission middleware
 	member, err := p.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest)
-	} else if member.Role != models.ProjectMemberRoleOwner && member.Role != models.ProjectMemberRoleModerator {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest)
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden)
+	}
+
+	currentTemplateMap := make(map[string]models.ProjectAttributeTemplate, len(project.AttributeTemplates))
+	for _, attributeTemplate := range project.AttributeTemplates {
+		currentTemplateMap[attributeTemplate.Name] = attributeTemplate
+	}
+
+	attributeNames := make(map[string]struct{}, len(req.AttributeTemplates))
+	claimedTemplates := make(map[string]struct{}, len(req.AttributeTemplates))
+	for _, attributeTemplate := range req.AttributeTemplates {
+		if _, exists := attributeNames[attributeTemplate.Name]; exists {
+			return nil, errutils.NewError(exceptions.ErrDuplicateAttributeName, errutils.BadRequest).WithFields(attributeTemplate.Name)
+		}
+		attributeNames[attributeTemplate.Name] = struct{}{}
+
+		if attributeTemplate.PreviousName != nil {
+			if _, exists := currentTemplateMap[*attributeTemplate.PreviousName]; !exists {
+				return nil, errutils.NewError(exceptions.ErrInvalidPreviousAttribute, errutils.BadRequest).WithDebugMessage("Previous attribute not found").WithFields(*attributeTemplate.PreviousName)
+			} else if _, claimed := claimedTemplates[*attributeTemplate.PreviousName]; claimed {
+				return nil, errutils.NewError(exceptions.ErrInvalidPreviousAttribute, errutils.BadRequest).WithDebugMessage("Previous attribute is used more than once").WithFields(*attributeTemplate.PreviousName)
+			}
+			claimedTemplates[*attributeTemplate.PreviousName] = struct{}{}
+		}
 	}
 
 	var attributeTemplates []models.ProjectAttributeTemplate
+	// Maps the current attribute key to its new template, nil means the attribute is removed
+	migrationTargets := make(map[string]*models.ProjectAttributeTemplate)
 	for _, attributeTemplate := range req.AttributeTemplates {
-		attributeTemplates = append(attributeTemplates, models.ProjectAttributeTemplate{
+		newTemplate := models.ProjectAttributeTemplate{
 			Name: attributeTemplate.Name,
 			Type: models.KeyValuePairType(strings.ToUpper(attributeTemplate.Type)),
-		})
+		}
+		attributeTemplates = append(attributeTemplates, newTemplate)
+
+		previousName := newTemplate.Name
+		if attributeTemplate.PreviousName != nil {
+			previousName = *attributeTemplate.PreviousName
+		} else if _, claimed := claimedTemplates[previousName]; claimed {
+			continue
+		}
+
+		previousTemplate, exists := currentTemplateMap[previousName]
+		if !exists {
+			continue
+		}
+		claimedTemplates[previousName] = struct{}{}
+
+		if previousTemplate.Name != newTemplate.Name || previousTemplate.Type != newTemplate.Type {
+			migrationTargets[previousName] = &newTemplate
+		}
+	}
+
+	migrations := make([]*responses.UpdateAttributeTemplatesResponseMigration, 0)
+	migrationMap := make(map[string]*responses.UpdateAttributeTemplatesResponseMigration)
+	for _, previousTemplate := range project.AttributeTemplates {
+		if _, claimed := claimedTemplates[previousTemplate.Name]; !claimed {
+			migrationTargets[previousTemplate.Name] = nil
+		}
+
+		target, exists := migrationTargets[previousTemplate.Name]
+		if !exists {
+			continue
+		}
+
+		migration := &responses.UpdateAttributeTemplatesResponseMigration{
+			Action:          constant.AttributeMigrationActionRemove,
+			PreviousName:    previousTemplate.Name,
+			PreviousType:    previousTemplate.Type.String(),
+			ClearedTaskRefs: []string{},
+		}
+		if target != nil {
+			migration.Name = target.Name
+			migration.Type = target.Type.String()
+
+			isRenamed := target.Name != previousTemplate.Name
+			isConverted := target.Type != previousTemplate.Type
+			switch {
+			case isRenamed && isConverted:
+				migration.Action = constant.AttributeMigrationActionRenameConvert
+			case isRenamed:
+				migration.Action = constant.AttributeMigrationActionRename
+			default:
+				migration.Action = constant.AttributeMigrationActionConvert
+			}
+		}
+
+		migrations = append(migrations, migration)
+		migrationMap[previousTemplate.Name] = migration
+	}
+
+	updateTasksReq := make([]repositories.UpdateTaskAttributesRequest, 0)
+	if len(migrationTargets) > 0 {
+		migratedKeys := make([]string, 0, len(migrationTargets))
+		for key := range migrationTargets {
+			migratedKeys = append(migratedKeys, key)
+		}
+
+		tasks, err := p.taskRepo.FindByProjectIDAndAttributeKeys(ctx, bsonProjectID, migratedKeys)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+		}
+
+		for _, task := range tasks {
+			attributes := make([]models.TaskAttribute, 0, len(task.Attributes))
+			for _, attribute := range task.Attributes {
+				target, exists := migrationTargets[attribute.Key]
+				if !exists {
+					attributes = append(attributes, attribute)
+					continue
+				}
+
+				migration := migrationMap[attribute.Key]
+				migration.TaskCount++
+				if target == nil {
+					continue
+				}
+
+				value, ok := convertAttributeValue(attribute.Value, target.Type)
+				if !ok {
+					migration.ClearedTaskRefs = append(migration.ClearedTaskRefs, task.TaskRef)
+				}
+
+				attributes = append(attributes, models.TaskAttribute{
+					Key:   target.Name,
+					Value: value,
+				})
+			}
+
+			updateTasksReq = append(updateTasksReq, repositories.UpdateTaskAttributesRequest{
+				ID:         task.ID,
+				Attributes: attributes,
+				UpdatedBy:  bsonUserID,
+			})
+		}
+	}
+
+	migrationResp := make([]responses.UpdateAttributeTemplatesResponseMigration, 0, len(migrations))
+	for _, migration := range migrations {
+		migrationResp = append(migrationResp, *migration)
+	}
+
+	if req.DryRun {
+		return &responses.UpdateAttributeTemplatesResponse{
+			Message:    "Attribute template migration preview",
+			DryRun:     true,
+			Migrations: migrationResp,
+		}, nil
+	}
+
+	// Migrate the tasks first, so a failed update can be retried with the same request
+	err = p.taskRepo.BulkUpdateAttributes(ctx, updateTasksReq)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
 	}
 
 	err = p.projectRepo.UpdateAttributeTemplates(ctx, bsonProjectID, attributeTemplates)
@@ -791,7 +1111,8 @@ func (p *projectServiceImpl) UpdateAttributeTemplates(ctx context.Context, req *
 	}
 
 	return &responses.UpdateAttributeTemplatesResponse{
-		Message: "Attribute template updated successfully",
+		Message:    "Attribute template updated successfully",
+		Migrations: migrationResp,
 	}, nil
 }
 
@@ -815,3 +1136,268 @@ func (p *projectServiceImpl) ListAttributeTemplates(ctx context.Context, req *re
 
 	return attributeTemplates, nil
 }
+
+func (p *projectServiceImpl) createEpicsFromTemplate(ctx context.Context, project *models.Project, epics []models.ProjectTemplateEpic, userID bson.ObjectID) *errutils.Error {
+	if len(epics) == 0 {
+		return nil
+	}
+
+	var defaultWorkflow *models.ProjectWorkflow
+	for _, workflow := range project.Workflows {
+		if workflow.IsDefault {
+			defaultWorkflow = &workflow
+			break
+		}
+	}
+	if defaultWorkflow == nil {
+		return errutils.NewError(exceptions.ErrDefaultWorkflowNotFound, errutils.InternalError)
+	}
+
+	for i, epic := range epics {
+		priority := epic.Priority
+		if !priority.IsValid() {
+			priority = models.TaskPriorityMedium
+		}
+
+		_, err := p.taskRepo.Create(ctx, &repositories.CreateTaskRequest{
+			TaskRef:     fmt.Sprintf("%s-%d", project.ProjectPrefix, project.TaskRunningNumber+i),
+			ProjectID:   project.ID,
+			Title:       epic.Title,
+			Description: epic.Description,
+			Type:        models.TaskTypeEpic,
+			Status:      defaultWorkflow.Status,
+			Priority:    priority,
+			Sprint:      &models.TaskSprint{},
+			Assignees:   []models.TaskAssignee{},
+			Approvals:   []models.TaskApproval{},
+			Attributes:  []models.TaskAttribute{},
+			CreatedBy:   userID,
+		})
+		if err != nil {
+			return errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+		}
+
+		err = p.projectRepo.IncrementTaskRunningNumber(ctx, project.ID)
+		if err != nil {
+			return errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (p *projectServiceImpl) Archive(ctx context.Context, req *requests.ArchiveProjectPathParams, userID string) (*models.Project, *errutils.Error) {
+	project, _, svcErr := p.findProjectAndMember(ctx, req.ProjectID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if project.Status == models.ProjectStatusInactive {
+		return nil, errutils.NewError(exceptions.ErrProjectAlreadyArchived, errutils.BadRequest)
+	}
+
+	return p.updateStatus(ctx, project.ID, models.ProjectStatusInactive, userID)
+}
+
+func (p *projectServiceImpl) Unarchive(ctx context.Context, req *requests.UnarchiveProjectPathParams, userID string) (*models.Project, *errutils.Error) {
+	project, _, svcErr := p.findProjectAndMember(ctx, req.ProjectID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if project.Status != models.ProjectStatusInactive {
+		return nil, errutils.NewError(exceptions.ErrProjectNotArchived, errutils.BadRequest)
+	}
+
+	return p.updateStatus(ctx, project.ID, models.ProjectStatusActive, userID)
+}
+
+func (p *projectServiceImpl) RequestDeletion(ctx context.Context, req *requests.RequestProjectDeletionPathParams, userID string) (*responses.RequestProjectDeletionResponse, *errutils.Error) {
+	project, member, svcErr := p.findProjectAndMember(ctx, req.ProjectID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	err := p.projectDeletionTokenRepo.Set(ctx, &repositories.SetProjectDeletionTokenRequest{
+		ProjectID: project.ID,
+		UserID:    member.UserID,
+		Token:     token,
+		TTL:       projectDeletionTokenTTL,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.RequestProjectDeletionResponse{
+		ConfirmationToken: token,
+		ExpiresAt:         time.Now().Add(projectDeletionTokenTTL),
+	}, nil
+}
+
+func (p *projectServiceImpl) Delete(ctx context.Context, req *requests.DeleteProjectRequest, userID string) (*responses.DeleteProjectResponse, *errutils.Error) {
+	project, member, svcErr := p.findProjectAndMember(ctx, req.ProjectID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	// The token is consumed even when it does not match, so it cannot be guessed
+	token, err := p.projectDeletionTokenRepo.GetAndDelete(ctx, project.ID, member.UserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(req.ConfirmationToken)) != 1 {
+		return nil, errutils.NewError(exceptions.ErrInvalidConfirmationToken, errutils.BadRequest)
+	}
+
+	tasks, err := p.taskRepo.FindByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	taskIDs := make([]bson.ObjectID, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+
+	// should be in transaction, to be implemented
+	err = p.taskCommentRepo.DeleteByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	err = p.taskEmbeddingRepo.DeleteByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	err = p.taskRepo.DeleteByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	err = p.sprintRepo.DeleteByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	err = p.projectRepo.Delete(ctx, project.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	err = p.projectMemberRepo.DeleteByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.DeleteProjectResponse{
+		Message: "Project deleted successfully",
+	}, nil
+}
+
+func (p *projectServiceImpl) TransferOwnership(ctx context.Context, req *requests.TransferProjectOwnershipRequest, userID string) (*responses.TransferProjectOwnershipResponse, *errutils.Error) {
+	project, member, svcErr := p.findProjectAndMember(ctx, req.ProjectID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	bsonNewOwnerUserID, err := bson.ObjectIDFromHex(req.NewOwnerUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	// The requester is not necessarily the owner when the permission is granted to other roles
+	owner := member
+	if member.Role != models.ProjectMemberRoleOwner {
+		owner, err = p.projectMemberRepo.FindProjectOwnerByProjectID(ctx, project.ID)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		} else if owner == nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage("Project has no owner")
+		}
+	}
+
+	if bsonNewOwnerUserID == owner.UserID {
+		return nil, errutils.NewError(exceptions.ErrCannotTransferToSelf, errutils.BadRequest)
+	}
+
+	newOwner, err := p.projectMemberRepo.FindByProjectIDAndUserID(ctx, project.ID, bsonNewOwnerUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if newOwner == nil || newOwner.RemovedAt != nil {
+		return nil, errutils.NewError(exceptions.ErrMemberNotFoundInProject, errutils.BadRequest)
+	}
+
+	// Promote the new owner first, so the project is never left without an owner
+	_, err = p.projectMemberRepo.UpdateRoleByID(ctx, &repositories.UpdateRoleRequest{
+		ID:   newOwner.ID,
+		Role: models.ProjectMemberRoleOwner,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	_, err = p.projectMemberRepo.UpdateRoleByID(ctx, &repositories.UpdateRoleRequest{
+		ID:   owner.ID,
+		Role: models.ProjectMemberRoleModerator,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.TransferProjectOwnershipResponse{
+		Message: "Project ownership transferred successfully",
+	}, nil
+}
+
+// findProjectAndMember finds the project and the requester's membership, role permissions are checked by the permission middleware
+func (p *projectServiceImpl) findProjectAndMember(ctx context.Context, projectID string, userID string) (*models.Project, *models.ProjectMember, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonProjectID, err := bson.ObjectIDFromHex(projectID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	project, err := p.projectRepo.FindByProjectID(ctx, bsonProjectID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if project == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.NotFound)
+	}
+
+	member, err := p.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	return project, member, nil
+}
+
+func (p *projectServiceImpl) updateStatus(ctx context.Context, projectID bson.ObjectID, status models.ProjectStatus, userID string) (*models.Project, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	project, err := p.projectRepo.UpdateStatus(ctx, &repositories.UpdateProjectStatusRequest{
+		ProjectID: projectID,
+		Status:    status,
+		UpdatedBy: bsonUserID,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return project, nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// convertAttributeValue converts a stored task attribute value to the given attribute type.
+// It returns false when the value cannot be represented in the new type.
+func convertAttributeValue(value any, toType models.KeyValuePairType) (any, bool) {
+	if value == nil {
+		return nil, true
+	}
+
+	switch v := value.(type) {
+	case bson.DateTime:
+		value = v.Time()
+	case int:
+		value = float64(v)
+	case int32:
+		value = float64(v)
+	case int64:
+		value = float64(v)
+	}
+
+	switch toType {
+	case models.KeyValuePairTypeString:
+		switch v := value.(type) {
+		case string:
+			return v, true
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		case bool:
+			return strconv.FormatBool(v), true
+		case time.Time:
+			return v.Format(time.RFC3339), true
+		}
+	case models.KeyValuePairTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, true
+		case string:
+			if number, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return number, true
+			}
+		case bool:
+			if v {
+				return float64(1), true
+			}
+			return float64(0), true
+		}
+	case models.KeyValuePairTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, true
+		case string:
+			if boolean, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return boolean, true
+			}
+		case float64:
+			return v != 0, true
+		}
+	case models.KeyValuePairTypeDate:
+		switch v := value.(type) {
+		case time.Time:
+			return v, true
+		case string:
+			if date, err := time.Parse(time.RFC3339, strings.TrimSpace(v)); err == nil {
+				return date, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func getProjectTemplateEpics(ctx context.Context, taskRepo repositories.TaskRepository, projectID bson.ObjectID) ([]models.ProjectTemplateEpic, *errutils.Error) {
+	epicTasks, err := taskRepo.FindByProjectIDAndType(ctx, projectID, models.TaskTypeEpic)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+	}
+
+	epics := make([]models.ProjectTemplateEpic, 0, len(epicTasks))
+	for _, epicTask := range epicTasks {
+		epics = append(epics, models.ProjectTemplateEpic{
+			Title:       epicTask.Title,
+			Description: epicTask.Description,
+			Priority:    epicTask.Priority,
+		})
+	}
+
+	return epics, nil
+}
+
+// ensureProjectIsActive rejects changes to an archived project, which is read-only until it is unarchived.
+func ensureProjectIsActive(project *models.Project) *errutils.Error {
+	if project.Status == models.ProjectStatusInactive {
+		return errutils.NewError(exceptions.ErrProjectArchived, errutils.BadRequest).WithDebugMessage("Project is archived")
+	}
+
+	return nil
+}
+
+func checkProjectIsActive(ctx context.Context, projectRepo repositories.ProjectRepository, projectID bson.ObjectID) *errutils.Error {
+	project, err := projectRepo.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if project == nil {
+		return errutils.NewError(exceptions.ErrProjectNotFound, errutils.BadRequest)
+	}
+
+	return ensureProjectIsActive(project)
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/responses"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ProjectTemplateService interface {
+	Create(ctx context.Context, req *requests.CreateProjectTemplateRequest, userID string) (*models.ProjectTemplate, *errutils.Error)
+	List(ctx context.Context, req *requests.ListProjectTemplatesPathParams, userID string) ([]*models.ProjectTemplate, *errutils.Error)
+	Delete(ctx context.Context, req *requests.DeleteProjectTemplateRequest, userID string) (*responses.DeleteProjectTemplateResponse, *errutils.Error)
+}
+
+type projectTemplateServiceImpl struct {
+	workspaceMemberRepo repositories.WorkspaceMemberRepository
+	projectRepo         repositories.ProjectRepository
+	projectTemplateRepo repositories.ProjectTemplateRepository
+	taskRepo            repositories.TaskRepository
+}
+
+func NewProjectTemplateService(
+	workspaceMemberRepo repositories.WorkspaceMemberRepository,
+	projectRepo repositories.ProjectRepository,
+	projectTemplateRepo repositories.ProjectTemplateRepository,
+	taskRepo repositories.TaskRepository,
+) ProjectTemplateService {
+	return &projectTemplateServiceImpl{
+		workspaceMemberRepo: workspaceMemberRepo,
+		projectRepo:         projectRepo,
+		projectTemplateRepo: projectTemplateRepo,
+		taskRepo:            taskRepo,
+	}
+}
+
+func (p *projectTemplateServiceImpl) Create(ctx context.Context, req *requests.CreateProjectTemplateRequest, userID string) (*models.ProjectTemplate, *errutils.Error) {
+	bsonWorkspaceID, err := bson.ObjectIDFromHex(req.WorkspaceID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonProjectID, err := bson.ObjectIDFromHex(req.ProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	if svcErr := p.checkWorkspaceAdmin(ctx, bsonWorkspaceID, bsonUserID); svcErr != nil {
+		return nil, svcErr
+	}
+
+	project, err := p.projectRepo.FindByProjectID(ctx, bsonProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+	} else if project == nil {
+		return nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.NotFound)
+	} else if project.WorkspaceID != bsonWorkspaceID {
+		return nil, errutils.NewError(exceptions.ErrProjectNotInWorkspace, errutils.BadRequest)
+	}
+
+	existsTemplate, err := p.projectTemplateRepo.FindByWorkspaceIDAndName(ctx, bsonWorkspaceID, req.Name)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+	} else if existsTemplate != nil {
+		return nil, errutils.NewError(exceptions.ErrProjectTemplateNameAlreadyExists, errutils.BadRequest)
+	}
+
+	epics := []models.ProjectTemplateEpic{}
+	if req.IncludeEpics {
+		var svcErr *errutils.Error
+		epics, svcErr = getProjectTemplateEpics(ctx, p.taskRepo, bsonProjectID)
+		if svcErr != nil {
+			return nil, svcErr
+		}
+	}
+
+	template, err := p.projectTemplateRepo.Create(ctx, &repositories.CreateProjectTemplateRequest{
+		WorkspaceID:        bsonWorkspaceID,
+		Name:               req.Name,
+		Description:        req.Description,
+		Positions:          project.Positions,
+		Workflows:          project.Workflows,
+		AttributeTemplates: project.AttributeTemplates,
+		Epics:              epics,
+		CreatedBy:          bsonUserID,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+	}
+
+	return template, nil
+}
+
+func (p *projectTemplateServiceImpl) List(ctx context.Context, req *requests.ListProjectTemplatesPathParams, userID string) ([]*models.ProjectTemplate, *errutils.Error) {
+	bsonWorkspaceID, err := bson.ObjectIDFromHex(req.WorkspaceID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	member, err := p.workspaceMemberRepo.FindByWorkspaceIDAndUserID(ctx, bsonWorkspaceID, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, errutils.NewError(exceptions.ErrMemberNotFoundInWorkspace, errutils.BadRequest)
+	}
+
+	templates, err := p.projectTemplateRepo.FindByWorkspaceID(ctx, bsonWorkspaceID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+	}
+
+	return templates, nil
+}
+
+func (p *projectTemplateServiceImpl) Delete(ctx context.Context, req *requests.DeleteProjectTemplateRequest, userID string) (*responses.DeleteProjectTemplateResponse, *errutils.Error) {
+	bsonWorkspaceID, err := bson.ObjectIDFromHex(req.WorkspaceID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonTemplateID, err := bson.ObjectIDFromHex(req.TemplateID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	if svcErr := p.checkWorkspaceAdmin(ctx, bsonWorkspaceID, bsonUserID); svcErr != nil {
+		return nil, svcErr
+	}
+
+	template, err := p.projectTemplateRepo.FindByID(ctx, bsonTemplateID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+	} else if template == nil || template.WorkspaceID != bsonWorkspaceID {
+		return nil, errutils.NewError(exceptions.ErrProjectTemplateNotFound, errutils.NotFound)
+	}
+
+	err = p.projectTemplateRepo.Delete(ctx, bsonTemplateID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.DeleteProjectTemplateResponse{
+		Message: "Project template deleted successfully",
+	}, nil
+}
+
+func (p *projectTemplateServiceImpl) checkWorkspaceAdmin(ctx context.Context, workspaceID bson.ObjectID, userID bson.ObjectID) *errutils.Error {
+	member, err := p.workspaceMemberRepo.FindByWorkspaceIDAndUserID(ctx, workspaceID, userID)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return errutils.NewError(exceptions.ErrMemberNotFoundInWorkspace, errutils.BadRequest)
+	} else if member.Role != models.WorkspaceMemberRoleOwner && member.Role != models.WorkspaceMemberRoleModerator {
+		return errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden)
+	}
+
+	return nil
+}
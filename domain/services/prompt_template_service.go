@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/responses"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type PromptTemplateService interface {
+	List(ctx context.Context, userID string) ([]responses.PromptTemplateResponse, *errutils.Error)
+	ListVersions(ctx context.Context, req *requests.ListPromptTemplateVersionsRequest, userID string) ([]*models.PromptTemplate, *errutils.Error)
+	// Update saves the template as a new version, it is rejected when it does not render the sample data
+	Update(ctx context.Context, req *requests.UpdatePromptTemplateRequest, userID string) (*responses.PromptTemplateResponse, *errutils.Error)
+	Preview(ctx context.Context, req *requests.PreviewPromptTemplateRequest, userID string) (*responses.PreviewPromptTemplateResponse, *errutils.Error)
+	// Rollback saves an earlier version as a new version, so the history is kept
+	Rollback(ctx context.Context, req *requests.RollbackPromptTemplateRequest, userID string) (*responses.PromptTemplateResponse, *errutils.Error)
+}
+
+// promptTemplateCreateAttempts is how many times a new version is numbered again after losing a race
+const promptTemplateCreateAttempts = 3
+
+type promptTemplateServiceImpl struct {
+	config             *config.Config
+	userRepo           repositories.UserRepository
+	promptTemplateRepo repositories.PromptTemplateRepository
+}
+
+func NewPromptTemplateService(
+	config *config.Config,
+	userRepo repositories.UserRepository,
+	promptTemplateRepo repositories.PromptTemplateRepository,
+) PromptTemplateService {
+	return &promptTemplateServiceImpl{
+		config:             config,
+		userRepo:           userRepo,
+		promptTemplateRepo: promptTemplateRepo,
+	}
+}
+
+func (p *promptTemplateServiceImpl) List(ctx context.Context, userID string) ([]responses.PromptTemplateResponse, *errutils.Error) {
+	if _, svcErr := findAdmin(ctx, p.userRepo, p.config.AdminEmails, userID); svcErr != nil {
+		return nil, svcErr
+	}
+
+	keys := models.GetPromptTemplateKeys()
+
+	templates := make([]responses.PromptTemplateResponse, 0, len(keys))
+	for _, key := range keys {
+		latest, err := p.promptTemplateRepo.FindLatestByKey(ctx, key)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
+
+		templates = append(templates, buildPromptTemplateResponse(key, latest))
+	}
+
+	return templates, nil
+}
+
+func (p *promptTemplateServiceImpl) ListVersions(ctx context.Context, req *requests.ListPromptTemplateVersionsRequest, userID string) ([]*models.PromptTemplate, *errutils.Error) {
+	if _, svcErr := findAdmin(ctx, p.userRepo, p.config.AdminEmails, userID); svcErr != nil {
+		return nil, svcErr
+	}
+
+	key, svcErr := parsePromptTemplateKey(req.Key)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	versions, err := p.promptTemplateRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return versions, nil
+}
+
+func (p *promptTemplateServiceImpl) Update(ctx context.Context, req *requests.UpdatePromptTemplateRequest, userID string) (*responses.PromptTemplateResponse, *errutils.Error) {
+	admin, svcErr := findAdmin(ctx, p.userRepo, p.config.AdminEmails, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	key, svcErr := parsePromptTemplateKey(req.Key)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if _, svcErr := previewPromptTemplate(key, req.Template); svcErr != nil {
+		return nil, svcErr
+	}
+
+	return p.createVersion(ctx, key, req.Template, nil, admin.ID)
+}
+
+func (p *promptTemplateServiceImpl) Preview(ctx context.Context, req *requests.PreviewPromptTemplateRequest, userID string) (*responses.PreviewPromptTemplateResponse, *errutils.Error) {
+	if _, svcErr := findAdmin(ctx, p.userRepo, p.config.AdminEmails, userID); svcErr != nil {
+		return nil, svcErr
+	}
+
+	key, svcErr := parsePromptTemplateKey(req.Key)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	text := req.Template
+	if strings.TrimSpace(text) == "" {
+		latest, err := p.promptTemplateRepo.FindLatestByKey(ctx, key)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
+		text = buildPromptTemplateResponse(key, latest).Template
+	}
+
+	prompt, svcErr := previewPromptTemplate(key, text)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	return &responses.PreviewPromptTemplateResponse{
+		Prompt:     prompt,
+		SampleData: promptTemplateSampleData[key],
+	}, nil
+}
+
+func (p *promptTemplateServiceImpl) Rollback(ctx context.Context, req *requests.RollbackPromptTemplateRequest, userID string) (*responses.PromptTemplateResponse, *errutils.Error) {
+	admin, svcErr := findAdmin(ctx, p.userRepo, p.config.AdminEmails, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	key, svcErr := parsePromptTemplateKey(req.Key)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	latest, err := p.promptTemplateRepo.FindLatestByKey(ctx, key)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	current := buildPromptTemplateResponse(key, latest)
+	if current.Version == *req.Version {
+		return nil, errutils.NewError(exceptions.ErrPromptTemplateVersionInUse, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("version %d of %s is in use", *req.Version, key))
+	}
+
+	text := defaultPromptTemplates[key]
+	if *req.Version > 0 {
+		target, err := p.promptTemplateRepo.FindByKeyAndVersion(ctx, key, *req.Version)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		} else if target == nil {
+			return nil, errutils.NewError(exceptions.ErrPromptTemplateVersionNotFound, errutils.NotFound).WithDebugMessage(fmt.Sprintf("version %d of %s not found", *req.Version, key))
+		}
+		text = target.Template
+	}
+
+	return p.createVersion(ctx, key, text, req.Version, admin.ID)
+}
+
+// createVersion saves the text as the next version of the key, retrying when another save takes the same version first
+func (p *promptTemplateServiceImpl) createVersion(ctx context.Context, key models.PromptTemplateKey, text string, restoredFromVersion *int, createdBy bson.ObjectID) (*responses.PromptTemplateResponse, *errutils.Error) {
+	for range promptTemplateCreateAttempts {
+		latest, err := p.promptTemplateRepo.FindLatestByKey(ctx, key)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
+
+		version := 1
+		if latest != nil {
+			version = latest.Version + 1
+		}
+
+		created, err := p.promptTemplateRepo.Create(ctx, &repositories.CreatePromptTemplateRequest{
+			Key:                 key,
+			Version:             version,
+			Template:            text,
+			RestoredFromVersion: restoredFromVersion,
+			CreatedBy:           createdBy,
+		})
+		if errors.Is(err, repositories.ErrPromptTemplateVersionExists) {
+			continue
+		} else if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
+
+		resp := buildPromptTemplateResponse(key, created)
+		return &resp, nil
+	}
+
+	return nil, errutils.NewError(exceptions.ErrPromptTemplateVersionConflict, errutils.Conflict).WithDebugMessage(fmt.Sprintf("could not save a new version of %s after %d attempts", key, promptTemplateCreateAttempts))
+}
+
+func parsePromptTemplateKey(key string) (models.PromptTemplateKey, *errutils.Error) {
+	promptTemplateKey := models.PromptTemplateKey(key)
+	if !promptTemplateKey.IsValid() {
+		return "", errutils.NewError(exceptions.ErrInvalidPromptTemplateKey, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("invalid prompt template key: %s", key))
+	}
+
+	return promptTemplateKey, nil
+}
+
+// previewPromptTemplate renders the template with the sample data of the key, the error is shown to the admin.
+func previewPromptTemplate(key models.PromptTemplateKey, text string) (string, *errutils.Error) {
+	prompt, err := executePromptTemplate(text, promptTemplateSampleData[key])
+	if err != nil {
+		return "", errutils.NewError(exceptions.ErrInvalidPromptTemplate, errutils.BadRequest).WithMessage(fmt.Sprintf("%s: %v", exceptions.ErrInvalidPromptTemplate, err))
+	} else if strings.TrimSpace(prompt) == "" {
+		return "", errutils.NewError(exceptions.ErrInvalidPromptTemplate, errutils.BadRequest).WithMessage(fmt.Sprintf("%s: the prompt is empty", exceptions.ErrInvalidPromptTemplate))
+	}
+
+	return prompt, nil
+}
+
+// buildPromptTemplateResponse describes the version in use, the built-in template when latest is nil.
+func buildPromptTemplateResponse(key models.PromptTemplateKey, latest *models.PromptTemplate) responses.PromptTemplateResponse {
+	resp := responses.PromptTemplateResponse{
+		Key:       key.String(),
+		Template:  defaultPromptTemplates[key],
+		IsBuiltIn: true,
+		Variables: promptTemplateVariables(key),
+	}
+
+	if latest != nil {
+		updatedBy := latest.CreatedBy.Hex()
+
+		resp.Version = latest.Version
+		resp.Template = latest.Template
+		resp.IsBuiltIn = false
+		resp.UpdatedAt = &latest.CreatedAt
+		resp.UpdatedBy = &updatedBy
+	}
+
+	return resp
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+)
+
+// Prompt templates only write the request sent to the model, the system prompts hold the answer format
+// the output is validated against, so an edited template can not break the parsing of the answer.
+
+type descriptionPromptData struct {
+	// ProjectName and TaskType are empty when the editor does not send them
+	ProjectName string
+	TaskType    string
+	Title       string
+}
+
+type subTasksPromptData struct {
+	ProjectName      string
+	TaskType         string
+	Title            string
+	Description      string
+	Positions        []string
+	MaxSubTasks      int
+	ExistingSubTasks []string
+}
+
+type taskSummaryPromptData struct {
+	TaskType    string
+	TaskRef     string
+	Title       string
+	Status      string
+	Description string
+	// Comments are sorted oldest first, OmittedComments is the number of older comments left out
+	Comments        []taskSummaryPromptComment
+	OmittedComments int
+}
+
+type taskSummaryPromptComment struct {
+	CreatedAt string
+	Author    string
+	Content   string
+}
+
+var defaultPromptTemplates = map[models.PromptTemplateKey]string{
+	models.PromptTemplateKeyDescription: `Write the description of {{if .TaskType}}a {{lower .TaskType}}{{else}}a task{{end}} titled {{printf "%q" .Title}}{{if .ProjectName}} of the project {{printf "%q" .ProjectName}}{{end}}.`,
+	models.PromptTemplateKeySubTasks: `Break the following {{lower .TaskType}} of the project {{printf "%q" .ProjectName}} into at most {{.MaxSubTasks}} sub-tasks.
+Positions of the team: {{join .Positions ", "}}
+
+Title: {{.Title}}
+{{if .Description}}Description:
+{{.Description}}
+{{end}}{{if .ExistingSubTasks}}
+It already has these sub-tasks, do not propose them again:
+{{range .ExistingSubTasks}}- {{.}}
+{{end}}{{end}}`,
+	models.PromptTemplateKeyTaskSummary: `Summarize the {{lower .TaskType}} [{{.TaskRef}}] {{.Title}} (status {{printf "%q" .Status}}).
+{{if .Description}}
+Description:
+{{.Description}}
+{{end}}{{if not .Comments}}
+The task has no comments.
+{{else}}{{if .OmittedComments}}
+The {{.OmittedComments}} oldest comments are left out.
+{{end}}
+Comments, oldest first:
+{{range .Comments}}- {{.CreatedAt}}, {{.Author}}: {{.Content}}
+{{end}}{{end}}`,
+}
+
+// promptTemplateSampleData is rendered by the preview, and by every edit to reject templates that fail.
+var promptTemplateSampleData = map[models.PromptTemplateKey]any{
+	models.PromptTemplateKeyDescription: descriptionPromptData{
+		ProjectName: "Task Nexus",
+		TaskType:    models.TaskTypeStory.String(),
+		Title:       "Export sprint reports as PDF",
+	},
+	models.PromptTemplateKeySubTasks: subTasksPromptData{
+		ProjectName:      "Task Nexus",
+		TaskType:         models.TaskTypeStory.String(),
+		Title:            "Export sprint reports as PDF",
+		Description:      "Project owners want to share the sprint report with stakeholders who have no account.",
+		Positions:        []string{"Frontend Developer", "Backend Developer", "QA"},
+		MaxSubTasks:      defaultMaxProposedSubTasks,
+		ExistingSubTasks: []string{"Design the PDF layout"},
+	},
+	models.PromptTemplateKeyTaskSummary: taskSummaryPromptData{
+		TaskType:    models.TaskTypeBug.String(),
+		TaskRef:     "TN-42",
+		Title:       "Login fails after password reset",
+		Status:      "IN_PROGRESS",
+		Description: "Users can not log in with the new password right after resetting it.",
+		Comments: []taskSummaryPromptComment{
+			{CreatedAt: "2025-03-01 09:30", Author: "Alice", Content: "Reproduced, the old session is still cached."},
+			{CreatedAt: "2025-03-01 11:05", Author: "Bob", Content: "Agreed to clear the sessions on reset. Should we also log out other devices?"},
+		},
+		OmittedComments: 0,
+	},
+}
+
+var promptTemplateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"join":  strings.Join,
+}
+
+// renderPrompt renders the latest version of the prompt template of key. The built-in template is used when
+// no version is stored, or when the stored version fails on data the sample did not cover.
+func renderPrompt(ctx context.Context, promptTemplateRepo repositories.PromptTemplateRepository, key models.PromptTemplateKey, data any) (string, *errutils.Error) {
+	stored, err := promptTemplateRepo.FindLatestByKey(ctx, key)
+	if err != nil {
+		return "", errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	if stored != nil {
+		prompt, err := executePromptTemplate(stored.Template, data)
+		if err == nil {
+			return prompt, nil
+		}
+		log.Printf("⚠️ Failed to render version %d of the %s prompt template, using the built-in one: %v", stored.Version, key, err)
+	}
+
+	prompt, err := executePromptTemplate(defaultPromptTemplates[key], data)
+	if err != nil {
+		return "", errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return prompt, nil
+}
+
+// truncatePromptText cuts text to at most limit bytes without splitting a rune, so the prompt stays valid UTF-8.
+func truncatePromptText(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+
+	return text[:limit]
+}
+
+func executePromptTemplate(text string, data any) (string, error) {
+	tmpl, err := template.New("prompt").Funcs(promptTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// promptTemplateVariables lists the fields of the data of a prompt template.
+func promptTemplateVariables(key models.PromptTemplateKey) []string {
+	dataType := reflect.TypeOf(promptTemplateSampleData[key])
+
+	variables := make([]string, 0, dataType.NumField())
+	for i := range dataType.NumField() {
+		field := dataType.Field(i)
+
+		variable := "." + field.Name
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct {
+			elemFields := make([]string, 0, field.Type.Elem().NumField())
+			for j := range field.Type.Elem().NumField() {
+				elemFields = append(elemFields, "."+field.Type.Elem().Field(j).Name)
+			}
+			variable += " (" + strings.Join(elemFields, ", ") + ")"
+		}
+
+		variables = append(variables, variable)
+	}
+
+	return variables
+}
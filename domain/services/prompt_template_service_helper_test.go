@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncatePromptText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  string
+	}{
+		{name: "shorter than the limit", text: "login", limit: 10, want: "login"},
+		{name: "exactly the limit", text: "login", limit: 5, want: "login"},
+		{name: "ascii", text: "login page", limit: 5, want: "login"},
+		{name: "on a rune boundary", text: "ทดสอบ", limit: 6, want: "ทด"},
+		{name: "inside a rune", text: "ทดสอบ", limit: 7, want: "ทด"},
+		{name: "inside the first rune", text: "ทดสอบ", limit: 2, want: ""},
+		{name: "zero limit", text: "login", limit: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncatePromptText(tt.text, tt.limit)
+			if got != tt.want {
+				t.Errorf("truncatePromptText(%q, %d) = %q, want %q", tt.text, tt.limit, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncatePromptText(%q, %d) = %q is not valid UTF-8", tt.text, tt.limit, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/responses"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ServiceAccountService interface {
+	Create(ctx context.Context, req *requests.CreateServiceAccountRequest, userID string) (*responses.ServiceAccountResponse, *errutils.Error)
+	List(ctx context.Context, req *requests.ListServiceAccountsRequest, userID string) ([]responses.ServiceAccountResponse, *errutils.Error)
+	CreateToken(ctx context.Context, req *requests.CreateServiceAccountTokenRequest, userID string) (*responses.CreateAccessTokenResponse, *errutils.Error)
+	ListTokens(ctx context.Context, req *requests.ListServiceAccountTokensRequest, userID string) ([]*models.AccessToken, *errutils.Error)
+	RevokeToken(ctx context.Context, req *requests.RevokeServiceAccountTokenRequest, userID string) (*responses.RevokeAccessTokenResponse, *errutils.Error)
+}
+
+type serviceAccountServiceImpl struct {
+	userRepo            repositories.UserRepository
+	workspaceRepo       repositories.WorkspaceRepository
+	workspaceMemberRepo repositories.WorkspaceMemberRepository
+	accessTokenRepo     repositories.AccessTokenRepository
+}
+
+func NewServiceAccountService(
+	userRepo repositories.UserRepository,
+	workspaceRepo repositories.WorkspaceRepository,
+	workspaceMemberRepo repositories.WorkspaceMemberRepository,
+	accessTokenRepo repositories.AccessTokenRepository,
+) ServiceAccountService {
+	return &serviceAccountServiceImpl{
+		userRepo:            userRepo,
+		workspaceRepo:       workspaceRepo,
+		workspaceMemberRepo: workspaceMemberRepo,
+		accessTokenRepo:     accessTokenRepo,
+	}
+}
+
+func (s *serviceAccountServiceImpl) Create(ctx context.Context, req *requests.CreateServiceAccountRequest, userID string) (*responses.ServiceAccountResponse, *errutils.Error) {
+	workspace, requester, svcErr := s.findWorkspaceAsManager(ctx, req.WorkspaceID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	// Service accounts never receive mail, the address only has to be unique
+	email := fmt.Sprintf("service-account-%s@%s.service-accounts.task-nexus.local", bson.NewObjectID().Hex(), workspace.ID.Hex())
+
+	name := strings.Trim(req.Name, " ")
+	serviceAccount, err := s.userRepo.Create(ctx, &repositories.CreateUserRequest{
+		Email:             email,
+		FullName:          name,
+		DisplayName:       name,
+		DefaultProfileUrl: buildDefaultProfileUrl(name),
+		ServiceAccount: &models.ServiceAccount{
+			WorkspaceID: workspace.ID,
+			CreatedBy:   requester.UserID,
+		},
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	// The service account joins the workspace as a member, so it can be added to projects like a person
+	_, err = s.workspaceMemberRepo.Create(ctx, &repositories.CreateWorkspaceMemberRequest{
+		WorkspaceID: workspace.ID,
+		UserID:      serviceAccount.ID,
+		Role:        models.WorkspaceMemberRoleMember,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	res := toServiceAccountResponse(serviceAccount)
+
+	return &res, nil
+}
+
+func (s *serviceAccountServiceImpl) List(ctx context.Context, req *requests.ListServiceAccountsRequest, userID string) ([]responses.ServiceAccountResponse, *errutils.Error) {
+	workspace, _, svcErr := s.findWorkspaceAsManager(ctx, req.WorkspaceID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	serviceAccounts, err := s.userRepo.FindServiceAccountsByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	res := make([]responses.ServiceAccountResponse, 0, len(serviceAccounts))
+	for _, serviceAccount := range serviceAccounts {
+		res = append(res, toServiceAccountResponse(serviceAccount))
+	}
+
+	return res, nil
+}
+
+func (s *serviceAccountServiceImpl) CreateToken(ctx context.Context, req *requests.CreateServiceAccountTokenRequest, userID string) (*responses.CreateAccessTokenResponse, *errutils.Error) {
+	workspace, requester, svcErr := s.findWorkspaceAsManager(ctx, req.WorkspaceID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	serviceAccount, svcErr := s.findServiceAccount(ctx, workspace.ID, req.ServiceAccountID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	return createAccessToken(ctx, s.accessTokenRepo, serviceAccount.ID, requester.UserID, req.Name, req.Scopes, req.ExpiresInDays)
+}
+
+func (s *serviceAccountServiceImpl) ListTokens(ctx context.Context, req *requests.ListServiceAccountTokensRequest, userID string) ([]*models.AccessToken, *errutils.Error) {
+	workspace, _, svcErr := s.findWorkspaceAsManager(ctx, req.WorkspaceID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	serviceAccount, svcErr := s.findServiceAccount(ctx, workspace.ID, req.ServiceAccountID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	accessTokens, err := s.accessTokenRepo.FindByUserID(ctx, serviceAccount.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return accessTokens, nil
+}
+
+func (s *serviceAccountServiceImpl) RevokeToken(ctx context.Context, req *requests.RevokeServiceAccountTokenRequest, userID string) (*responses.RevokeAccessTokenResponse, *errutils.Error) {
+	workspace, _, svcErr := s.findWorkspaceAsManager(ctx, req.WorkspaceID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	serviceAccount, svcErr := s.findServiceAccount(ctx, workspace.ID, req.ServiceAccountID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	return revokeAccessToken(ctx, s.accessTokenRepo, serviceAccount.ID, req.TokenID)
+}
+
+// findWorkspaceAsManager returns the workspace when the requester is its owner or a moderator.
+func (s *serviceAccountServiceImpl) findWorkspaceAsManager(ctx context.Context, workspaceID string, userID string) (*models.Workspace, *models.WorkspaceMember, *errutils.Error) {
+	bsonWorkspaceID, err := bson.ObjectIDFromHex(workspaceID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInvalidWorkspaceID, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	workspace, err := s.workspaceRepo.FindByID(ctx, bsonWorkspaceID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if workspace == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrWorkspaceNotFound, errutils.NotFound)
+	}
+
+	requester, err := s.workspaceMemberRepo.FindByWorkspaceIDAndUserID(ctx, bsonWorkspaceID, bsonUserID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if requester == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrRequesterNotFoundInWorkspace, errutils.Forbidden)
+	} else if requester.Role != models.WorkspaceMemberRoleOwner && requester.Role != models.WorkspaceMemberRoleModerator {
+		return nil, nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Only owner and moderator can manage service accounts")
+	}
+
+	if svcErr := checkWorkspaceTwoFactorRequirement(ctx, s.userRepo, workspace, bsonUserID); svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	return workspace, requester, nil
+}
+
+func (s *serviceAccountServiceImpl) findServiceAccount(ctx context.Context, workspaceID bson.ObjectID, serviceAccountID string) (*models.User, *errutils.Error) {
+	bsonServiceAccountID, err := bson.ObjectIDFromHex(serviceAccountID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	serviceAccount, err := s.userRepo.FindByID(ctx, bsonServiceAccountID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if serviceAccount == nil || !serviceAccount.IsServiceAccount() || serviceAccount.ServiceAccount.WorkspaceID != workspaceID {
+		return nil, errutils.NewError(exceptions.ErrServiceAccountNotFound, errutils.NotFound)
+	}
+
+	return serviceAccount, nil
+}
+
+func toServiceAccountResponse(serviceAccount *models.User) responses.ServiceAccountResponse {
+	return responses.ServiceAccountResponse{
+		ID:          serviceAccount.ID.Hex(),
+		Name:        serviceAccount.FullName,
+		WorkspaceID: serviceAccount.ServiceAccount.WorkspaceID.Hex(),
+		CreatedBy:   serviceAccount.ServiceAccount.CreatedBy.Hex(),
+		CreatedAt:   serviceAccount.CreatedAt,
+	}
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cnc-csku/task-nexus-go-lib/utils/array"
 	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
@@ -22,6 +24,8 @@ type SprintService interface {
 	CompleteSprint(ctx context.Context, req *requests.CompleteSprintRequest, userID string) (*models.Sprint, *errutils.Error)
 	UpdateStatus(ctx context.Context, req *requests.UpdateSprintStatusRequest, userID string) (*models.Sprint, *errutils.Error)
 	Delete(ctx context.Context, req *requests.DeleteSprintRequest, userID string) (*responses.DeleteSprintResponse, *errutils.Error)
+	GenerateSummary(ctx context.Context, req *requests.GenerateSprintSummaryRequest, userID string) (*models.Sprint, *errutils.Error)
+	EditSummary(ctx context.Context, req *requests.EditSprintSummaryRequest, userID string) (*models.Sprint, *errutils.Error)
 }
 
 type sprintServiceImpl struct {
@@ -29,6 +33,8 @@ type sprintServiceImpl struct {
 	projectRepo       repositories.ProjectRepository
 	projectMemberRepo repositories.ProjectMemberRepository
 	taskRepo          repositories.TaskRepository
+	taskCommentRepo   repositories.TaskCommentRepository
+	llmRepo           repositories.LLMRepository
 }
 
 func NewSprintService(
@@ -36,12 +42,16 @@ func NewSprintService(
 	projectRepo repositories.ProjectRepository,
 	projectMemberRepo repositories.ProjectMemberRepository,
 	taskRepo repositories.TaskRepository,
+	taskCommentRepo repositories.TaskCommentRepository,
+	llmRepo repositories.LLMRepository,
 ) SprintService {
 	return &sprintServiceImpl{
 		sprintRepo:        sprintRepo,
 		projectRepo:       projectRepo,
 		projectMemberRepo: projectMemberRepo,
 		taskRepo:          taskRepo,
+		taskCommentRepo:   taskCommentRepo,
+		llmRepo:           llmRepo,
 	}
 }
 
@@ -62,6 +72,10 @@ func (s *sprintServiceImpl) Create(ctx context.Context, req *requests.CreateSpri
 		return nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.BadRequest).WithDebugMessage("project not found")
 	}
 
+	if svcErr := ensureProjectIsActive(project); svcErr != nil {
+		return nil, svcErr
+	}
+
 	// should be in transaction, to be implemented
 	sprint := &repositories.CreateSprintRequest{
 		ProjectID: bsonProjectID,
@@ -110,13 +124,13 @@ func (s *sprintServiceImpl) GetByID(ctx context.Context, req *requests.GetSprint
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("user is not member of project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("user is not member of project")
 	}
 
 	sprint, err := s.sprintRepo.FindByID(ctx, bsonSprintID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
-	} else if sprint == nil {
+	} else if sprint == nil || sprint.ProjectID != bsonProjectID {
 		return nil, errutils.NewError(exceptions.ErrSprintNotFound, errutils.NotFound).WithDebugMessage("sprint not found")
 	}
 
@@ -128,6 +142,11 @@ func (s *sprintServiceImpl) Edit(ctx context.Context, req *requests.EditSprintRe
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
 	}
+	bsonProjectID, err := bson.ObjectIDFromHex(req.ProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
 	bsonSprintID, err := bson.ObjectIDFromHex(req.SprintID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
@@ -136,10 +155,14 @@ func (s *sprintServiceImpl) Edit(ctx context.Context, req *requests.EditSprintRe
 	sprint, err := s.sprintRepo.FindByID(ctx, bsonSprintID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
-	} else if sprint == nil {
+	} else if sprint == nil || sprint.ProjectID != bsonProjectID {
 		return nil, errutils.NewError(exceptions.ErrSprintNotFound, errutils.NotFound).WithDebugMessage("sprint not found")
 	}
 
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, sprint.ProjectID); svcErr != nil {
+		return nil, svcErr
+	}
+
 	var (
 		startDate = req.StartDate
 		endDate   = req.EndDate
@@ -202,7 +225,7 @@ func (s *sprintServiceImpl) List(ctx context.Context, req *requests.ListSprintPa
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("user is not member of project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("user is not member of project")
 	}
 
 	sprints, err := s.sprintRepo.List(ctx, &repositories.ListSprintFilter{
@@ -240,11 +263,15 @@ func (s *sprintServiceImpl) CompleteSprint(ctx context.Context, req *requests.Co
 		return nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("Project not found: %s", req.ProjectID))
 	}
 
+	if svcErr := ensureProjectIsActive(project); svcErr != nil {
+		return nil, svcErr
+	}
+
 	currentSprint, err := s.sprintRepo.FindByID(ctx, bsonCurrentSprintID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
-	} else if currentSprint == nil {
-		return nil, errutils.NewError(exceptions.ErrSprintNotFound, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("Sprint not found: %s", req.CurrentSprintID))
+	} else if currentSprint == nil || currentSprint.ProjectID != bsonProjectID {
+		return nil, errutils.NewError(exceptions.ErrSprintNotFound, errutils.NotFound).WithDebugMessage(fmt.Sprintf("Sprint not found: %s", req.CurrentSprintID))
 	}
 
 	tasks, err := s.taskRepo.FindByCurrentSprintID(ctx, bsonCurrentSprintID)
@@ -320,13 +347,17 @@ func (s *sprintServiceImpl) UpdateStatus(ctx context.Context, req *requests.Upda
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("user is not member of project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("user is not member of project")
+	}
+
+	if svcErr := ensureProjectIsActive(project); svcErr != nil {
+		return nil, svcErr
 	}
 
 	sprint, err := s.sprintRepo.FindByID(ctx, bsonSprintID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
-	} else if sprint == nil {
+	} else if sprint == nil || sprint.ProjectID != bsonProjectID {
 		return nil, errutils.NewError(exceptions.ErrSprintNotFound, errutils.NotFound).WithDebugMessage("sprint not found")
 	}
 
@@ -348,6 +379,11 @@ func (s *sprintServiceImpl) Delete(ctx context.Context, req *requests.DeleteSpri
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
 	}
 
+	bsonProjectID, err := bson.ObjectIDFromHex(req.ProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
 	bsonSprintID, err := bson.ObjectIDFromHex(req.SprintID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
@@ -356,10 +392,14 @@ func (s *sprintServiceImpl) Delete(ctx context.Context, req *requests.DeleteSpri
 	sprint, err := s.sprintRepo.FindByID(ctx, bsonSprintID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
-	} else if sprint == nil {
+	} else if sprint == nil || sprint.ProjectID != bsonProjectID {
 		return nil, errutils.NewError(exceptions.ErrSprintNotFound, errutils.NotFound).WithDebugMessage("sprint not found")
 	}
 
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, sprint.ProjectID); svcErr != nil {
+		return nil, svcErr
+	}
+
 	// should be in transaction, to be implemented
 	tasksWithCurrentSprintID, err := s.taskRepo.FindByCurrentSprintID(ctx, bsonSprintID)
 	if err != nil {
@@ -407,3 +447,96 @@ func (s *sprintServiceImpl) Delete(ctx context.Context, req *requests.DeleteSpri
 		Message: "Sprint deleted successfully",
 	}, nil
 }
+
+func (s *sprintServiceImpl) GenerateSummary(ctx context.Context, req *requests.GenerateSprintSummaryRequest, userID string) (*models.Sprint, *errutils.Error) {
+	bsonUserID, project, sprint, svcErr := s.findSummarySprint(ctx, req.ProjectID, req.SprintID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if sprint.Status == models.SprintStatusCreated {
+		return nil, errutils.NewError(exceptions.ErrSprintNotStarted, errutils.BadRequest).WithDebugMessage("a sprint can be summarized once it has started")
+	}
+
+	tasks, err := s.taskRepo.FindByCurrentSprintID(ctx, sprint.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	// Tasks moved on to a later sprint have this sprint in their previous sprints
+	carriedOverTasks, err := s.taskRepo.FindByPreviousSprintID(ctx, sprint.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	taskIDs := make([]bson.ObjectID, 0, len(tasks)+len(carriedOverTasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	for _, task := range carriedOverTasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+
+	comments, err := s.taskCommentRepo.FindByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	out, svcErr := completeValidatedJSON(ctx, s.llmRepo, &repositories.LLMCompletionRequest{
+		SystemPrompt: sprintSummarySystemPrompt,
+		Prompt:       buildSprintSummaryPrompt(project, sprint, tasks, carriedOverTasks, comments),
+		Schema:       sprintSummarySchema(),
+	}, exceptions.ErrInvalidGeneratedSprintSummary, validateSprintSummary)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	now := time.Now()
+	out.GeneratedAt = &now
+	out.GeneratedBy = &bsonUserID
+	out.UpdatedAt = now
+	out.UpdatedBy = bsonUserID
+
+	updatedSprint, err := s.sprintRepo.UpdateSummary(ctx, &repositories.UpdateSprintSummaryRequest{
+		ID:      sprint.ID,
+		Summary: out,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return updatedSprint, nil
+}
+
+func (s *sprintServiceImpl) EditSummary(ctx context.Context, req *requests.EditSprintSummaryRequest, userID string) (*models.Sprint, *errutils.Error) {
+	bsonUserID, _, sprint, svcErr := s.findSummarySprint(ctx, req.ProjectID, req.SprintID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	summary := &models.SprintSummary{
+		Overview:           strings.TrimSpace(req.Overview),
+		Shipped:            compactSummaryItems(req.Shipped),
+		Slipped:            compactSummaryItems(req.Slipped),
+		Risks:              compactSummaryItems(req.Risks),
+		RetroTalkingPoints: compactSummaryItems(req.RetroTalkingPoints),
+		UpdatedAt:          time.Now(),
+		UpdatedBy:          bsonUserID,
+	}
+
+	// A summary written by hand has never been generated
+	if sprint.Summary != nil {
+		summary.GeneratedAt = sprint.Summary.GeneratedAt
+		summary.GeneratedBy = sprint.Summary.GeneratedBy
+	}
+
+	updatedSprint, err := s.sprintRepo.UpdateSummary(ctx, &repositories.UpdateSprintSummaryRequest{
+		ID:      sprint.ID,
+		Summary: summary,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return updatedSprint, nil
+}
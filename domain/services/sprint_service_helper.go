@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// findSummarySprint returns the caller and the sprint of an active project the caller is a member of.
+func (s *sprintServiceImpl) findSummarySprint(ctx context.Context, projectID string, sprintID string, userID string) (bson.ObjectID, *models.Project, *models.Sprint, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return bson.NilObjectID, nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonProjectID, err := bson.ObjectIDFromHex(projectID)
+	if err != nil {
+		return bson.NilObjectID, nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonSprintID, err := bson.ObjectIDFromHex(sprintID)
+	if err != nil {
+		return bson.NilObjectID, nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	project, err := s.projectRepo.FindByProjectID(ctx, bsonProjectID)
+	if err != nil {
+		return bson.NilObjectID, nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if project == nil {
+		return bson.NilObjectID, nil, nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.BadRequest).WithDebugMessage("project not found")
+	}
+
+	member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
+	if err != nil {
+		return bson.NilObjectID, nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return bson.NilObjectID, nil, nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("user is not member of project")
+	}
+
+	if svcErr := ensureProjectIsActive(project); svcErr != nil {
+		return bson.NilObjectID, nil, nil, svcErr
+	}
+
+	sprint, err := s.sprintRepo.FindByID(ctx, bsonSprintID)
+	if err != nil {
+		return bson.NilObjectID, nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if sprint == nil || sprint.ProjectID != bsonProjectID {
+		return bson.NilObjectID, nil, nil, errutils.NewError(exceptions.ErrSprintNotFound, errutils.NotFound).WithDebugMessage("sprint not found")
+	}
+
+	return bsonUserID, project, sprint, nil
+}
+
+const (
+	// sprintSummaryMaxTasks is the number of tasks of each list sent to the model
+	sprintSummaryMaxTasks = 150
+	// sprintSummaryCommentsPerTask is the number of latest comments of a task sent to the model
+	sprintSummaryCommentsPerTask = 3
+	// sprintSummaryCommentLimit is the number of characters of a comment sent to the model
+	sprintSummaryCommentLimit = 300
+	// sprintSummaryStatusChangesPerTask is the number of latest status changes of a task sent to the model
+	sprintSummaryStatusChangesPerTask = 5
+)
+
+const sprintSummarySystemPrompt = `You are an experienced scrum master writing the review of a finished or running sprint.
+Answer with a JSON object {"overview": string, "shipped": [string], "slipped": [string], "risks": [string], "retroTalkingPoints": [string]}.
+"overview" is a short paragraph on how the sprint went measured against its goal.
+"shipped" lists what was delivered and "slipped" what was not, grouped into release-note style items that mention task references.
+"risks" are problems that may affect the next sprints, "retroTalkingPoints" are questions or topics for the retrospective.
+Only use facts from the given tasks and comments.`
+
+func buildSprintSummaryPrompt(project *models.Project, sprint *models.Sprint, tasks []*models.Task, carriedOverTasks []*models.Task, comments []*models.TaskComment) string {
+	var doneStatuses []string
+	for _, workflow := range project.Workflows {
+		if workflow.IsDone {
+			doneStatuses = append(doneStatuses, workflow.Status)
+		}
+	}
+
+	// Comments are sorted newest first
+	taskComments := make(map[bson.ObjectID][]*models.TaskComment)
+	for _, comment := range comments {
+		if sprint.StartDate != nil && comment.CreatedAt.Before(*sprint.StartDate) {
+			continue
+		}
+		if len(taskComments[comment.TaskID]) < sprintSummaryCommentsPerTask {
+			taskComments[comment.TaskID] = append(taskComments[comment.TaskID], comment)
+		}
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Summarize the sprint %q of the project %q (status: %s).\n", sprint.Title, project.Name, sprint.Status)
+	if sprint.SprintGoal != "" {
+		fmt.Fprintf(&sb, "Sprint goal: %s\n", sprint.SprintGoal)
+	}
+	if sprint.StartDate != nil && sprint.EndDate != nil {
+		fmt.Fprintf(&sb, "Period: %s to %s\n", sprint.StartDate.Format("2006-01-02"), sprint.EndDate.Format("2006-01-02"))
+	}
+	fmt.Fprintf(&sb, "Statuses that count as done: %s\n", strings.Join(doneStatuses, ", "))
+	sb.WriteString("Status changes during the sprint are listed below each task. Tasks without them have not changed status during the sprint, or were created before status changes were recorded.\n")
+
+	doneCount := 0
+	for _, task := range tasks {
+		if slices.Contains(doneStatuses, task.Status) {
+			doneCount++
+		}
+	}
+	fmt.Fprintf(&sb, "\nTasks of the sprint (%d, %d done):\n", len(tasks), doneCount)
+	writeSprintSummaryTasks(&sb, sprint, tasks, doneStatuses, taskComments)
+
+	if len(carriedOverTasks) > 0 {
+		fmt.Fprintf(&sb, "\nTasks carried over from this sprint to a later sprint (%d):\n", len(carriedOverTasks))
+		writeSprintSummaryTasks(&sb, sprint, carriedOverTasks, doneStatuses, taskComments)
+	}
+
+	return sb.String()
+}
+
+func writeSprintSummaryTasks(sb *strings.Builder, sprint *models.Sprint, tasks []*models.Task, doneStatuses []string, taskComments map[bson.ObjectID][]*models.TaskComment) {
+	for i, task := range tasks {
+		if i == sprintSummaryMaxTasks {
+			fmt.Fprintf(sb, "- and %d more tasks\n", len(tasks)-i)
+			break
+		}
+
+		state := "not done"
+		if slices.Contains(doneStatuses, task.Status) {
+			state = "done"
+		}
+
+		point := 0
+		for _, assignee := range task.Assignees {
+			if assignee.Point != nil {
+				point += *assignee.Point
+			}
+		}
+
+		fmt.Fprintf(sb, "- [%s] %s (%s, %s priority, status %q, %s, %d points, updated %s",
+			task.TaskRef, task.Title, task.Type, task.Priority, task.Status, state, point, task.UpdatedAt.Format("2006-01-02"))
+		if task.Sprint != nil && len(task.Sprint.PreviousSprintIDs) > 0 {
+			fmt.Fprintf(sb, ", carried over through %d earlier sprints", len(task.Sprint.PreviousSprintIDs))
+		}
+		sb.WriteString(")\n")
+
+		for _, change := range sprintStatusChanges(sprint, task) {
+			fmt.Fprintf(sb, "  status changed to %q (%s)\n", change.Status, change.ChangedAt.Format("2006-01-02"))
+		}
+
+		for _, comment := range taskComments[task.ID] {
+			content := strings.Join(strings.Fields(descriptionToText(comment.Content)), " ")
+			if len(content) > sprintSummaryCommentLimit {
+				content = truncatePromptText(content, sprintSummaryCommentLimit) + "..."
+			}
+			fmt.Fprintf(sb, "  comment (%s): %s\n", comment.CreatedAt.Format("2006-01-02"), content)
+		}
+	}
+}
+
+// sprintStatusChanges returns the latest status changes of the task made during the sprint, oldest first.
+func sprintStatusChanges(sprint *models.Sprint, task *models.Task) []models.TaskStatusChange {
+	changes := make([]models.TaskStatusChange, 0)
+	for _, change := range task.StatusChanges {
+		if sprint.StartDate != nil && change.ChangedAt.Before(*sprint.StartDate) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	if len(changes) > sprintSummaryStatusChangesPerTask {
+		changes = changes[len(changes)-sprintSummaryStatusChangesPerTask:]
+	}
+
+	return changes
+}
+
+func validateSprintSummary(summary *models.SprintSummary) error {
+	summary.Overview = strings.TrimSpace(summary.Overview)
+	if summary.Overview == "" {
+		return errors.New("the overview is empty")
+	}
+
+	summary.Shipped = compactSummaryItems(summary.Shipped)
+	summary.Slipped = compactSummaryItems(summary.Slipped)
+	summary.Risks = compactSummaryItems(summary.Risks)
+	summary.RetroTalkingPoints = compactSummaryItems(summary.RetroTalkingPoints)
+
+	if len(summary.RetroTalkingPoints) == 0 {
+		return errors.New("there are no retro talking points")
+	}
+
+	return nil
+}
+
+// compactSummaryItems trims the items and drops the empty ones.
+func compactSummaryItems(items []string) []string {
+	compacted := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			compacted = append(compacted, item)
+		}
+	}
+	return compacted
+}
+
+func sprintSummarySchema() *repositories.LLMSchema {
+	list := func(description string) *repositories.LLMSchema {
+		return &repositories.LLMSchema{
+			Type:        repositories.LLMSchemaTypeArray,
+			Description: description,
+			Items:       &repositories.LLMSchema{Type: repositories.LLMSchemaTypeString},
+		}
+	}
+
+	return &repositories.LLMSchema{
+		Type:     repositories.LLMSchemaTypeObject,
+		Required: []string{"overview", "shipped", "slipped", "risks", "retroTalkingPoints"},
+		Properties: map[string]*repositories.LLMSchema{
+			"overview":           {Type: repositories.LLMSchemaTypeString, Description: "How the sprint went against its goal"},
+			"shipped":            list("Delivered work, as release notes"),
+			"slipped":            list("Work that was not finished"),
+			"risks":              list("Risks for the next sprints"),
+			"retroTalkingPoints": list("Topics for the retrospective"),
+		},
+	}
+}
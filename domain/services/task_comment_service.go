@@ -2,6 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
 	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
@@ -15,14 +19,20 @@ import (
 type TaskCommentService interface {
 	Create(ctx context.Context, req *requests.CreateTaskCommentRequest, userID string) (*models.TaskComment, *errutils.Error)
 	List(ctx context.Context, req *requests.ListTaskCommentPathParams, userID string) ([]responses.ListTaskCommentResponse, *errutils.Error)
+	// Summarize asks the LLM for the current state, decisions and open questions of a task from its description and comments.
+	// The summary is cached until a comment is added or the description changes.
+	Summarize(ctx context.Context, req *requests.SummarizeTaskRequest, userID string) (*models.TaskSummary, *errutils.Error)
 }
 
 type taskCommentServiceImpl struct {
-	userRepo          repositories.UserRepository
-	taskCommentRepo   repositories.TaskCommentRepository
-	taskRepo          repositories.TaskRepository
-	projectRepo       repositories.ProjectRepository
-	projectMemberRepo repositories.ProjectMemberRepository
+	userRepo           repositories.UserRepository
+	taskCommentRepo    repositories.TaskCommentRepository
+	taskRepo           repositories.TaskRepository
+	projectRepo        repositories.ProjectRepository
+	projectMemberRepo  repositories.ProjectMemberRepository
+	llmRepo            repositories.LLMRepository
+	taskSummaryRepo    repositories.TaskSummaryCacheRepository
+	promptTemplateRepo repositories.PromptTemplateRepository
 }
 
 func NewTaskCommentService(
@@ -31,13 +41,19 @@ func NewTaskCommentService(
 	taskRepo repositories.TaskRepository,
 	projectRepo repositories.ProjectRepository,
 	projectMemberRepo repositories.ProjectMemberRepository,
+	llmRepo repositories.LLMRepository,
+	taskSummaryRepo repositories.TaskSummaryCacheRepository,
+	promptTemplateRepo repositories.PromptTemplateRepository,
 ) TaskCommentService {
 	return &taskCommentServiceImpl{
-		userRepo:          userRepo,
-		taskCommentRepo:   taskCommentRepo,
-		taskRepo:          taskRepo,
-		projectRepo:       projectRepo,
-		projectMemberRepo: projectMemberRepo,
+		userRepo:           userRepo,
+		taskCommentRepo:    taskCommentRepo,
+		taskRepo:           taskRepo,
+		projectRepo:        projectRepo,
+		projectMemberRepo:  projectMemberRepo,
+		llmRepo:            llmRepo,
+		taskSummaryRepo:    taskSummaryRepo,
+		promptTemplateRepo: promptTemplateRepo,
 	}
 }
 
@@ -63,7 +79,11 @@ func (s *taskCommentServiceImpl) Create(ctx context.Context, req *requests.Creat
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, task.ProjectID); svcErr != nil {
+		return nil, svcErr
 	}
 
 	comment, err := s.taskCommentRepo.Create(ctx, &repositories.CreateTaskCommentRequest{
@@ -101,7 +121,7 @@ func (s *taskCommentServiceImpl) List(ctx context.Context, req *requests.ListTas
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
 	}
 
 	comments, err := s.taskCommentRepo.FindByTaskID(ctx, task.ID)
@@ -117,6 +137,117 @@ func (s *taskCommentServiceImpl) List(ctx context.Context, req *requests.ListTas
 	return buildTaskComments(comments, mapUsersByID(users)), nil
 }
 
+func (s *taskCommentServiceImpl) Summarize(ctx context.Context, req *requests.SummarizeTaskRequest, userID string) (*models.TaskSummary, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonProjectID, err := bson.ObjectIDFromHex(req.ProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	task, err := s.taskRepo.FindByTaskRefAndProjectID(ctx, req.TaskRef, bsonProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if task == nil {
+		return nil, errutils.NewError(exceptions.ErrTaskNotFound, errutils.BadRequest).WithDebugMessage("task not found")
+	}
+
+	member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, task.ProjectID, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	// Comments are sorted newest first
+	comments, err := s.taskCommentRepo.FindByTaskID(ctx, task.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	description := descriptionToText(task.Description)
+	if description == "" && len(comments) == 0 {
+		return nil, errutils.NewError(exceptions.ErrNothingToSummarize, errutils.BadRequest)
+	}
+
+	// A new version of the prompt template must not be hidden behind summaries made with the old one
+	promptTemplate, err := s.promptTemplateRepo.FindLatestByKey(ctx, models.PromptTemplateKeyTaskSummary)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	var promptTemplateVersion int
+	if promptTemplate != nil {
+		promptTemplateVersion = promptTemplate.Version
+	}
+
+	latestCommentAt := latestCommentTime(comments)
+	version := taskSummaryVersion(task, latestCommentAt, promptTemplateVersion)
+
+	cached, err := s.taskSummaryRepo.Get(ctx, task.ID, version)
+	if err != nil {
+		// A broken cache only costs a regeneration
+		log.Printf("⚠️ Failed to read the cached summary of task %s: %v", task.TaskRef, err)
+	} else if cached != nil {
+		return cached, nil
+	}
+
+	users, err := s.userRepo.FindByIDs(ctx, extractUserIDsFromComments(comments))
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	type taskSummaryOutput struct {
+		CurrentState  string   `json:"currentState"`
+		Decisions     []string `json:"decisions"`
+		OpenQuestions []string `json:"openQuestions"`
+	}
+
+	prompt, svcErr := renderPrompt(ctx, s.promptTemplateRepo, models.PromptTemplateKeyTaskSummary, buildTaskSummaryPromptData(task, description, comments, mapUsersByID(users)))
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	out, svcErr := completeValidatedJSON(ctx, s.llmRepo, &repositories.LLMCompletionRequest{
+		SystemPrompt: taskSummarySystemPrompt,
+		Prompt:       prompt,
+		Schema:       taskSummarySchema(),
+	}, exceptions.ErrInvalidGeneratedTaskSummary, func(out *taskSummaryOutput) error {
+		out.CurrentState = strings.TrimSpace(out.CurrentState)
+		if out.CurrentState == "" {
+			return errors.New("the current state is empty")
+		}
+		out.Decisions = compactSummaryItems(out.Decisions)
+		out.OpenQuestions = compactSummaryItems(out.OpenQuestions)
+		return nil
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	summary := &models.TaskSummary{
+		CurrentState:    out.CurrentState,
+		Decisions:       out.Decisions,
+		OpenQuestions:   out.OpenQuestions,
+		CommentCount:    len(comments),
+		LatestCommentAt: latestCommentAt,
+		GeneratedAt:     time.Now(),
+	}
+
+	if err := s.taskSummaryRepo.Set(ctx, &repositories.SetTaskSummaryRequest{
+		TaskID:  task.ID,
+		Version: version,
+		Summary: summary,
+	}); err != nil {
+		log.Printf("⚠️ Failed to cache the summary of task %s: %v", task.TaskRef, err)
+	}
+
+	return summary, nil
+}
+
 func extractUserIDsFromComments(comments []*models.TaskComment) []bson.ObjectID {
 	userIDs := make([]bson.ObjectID, 0, len(comments))
 	for _, comment := range comments {
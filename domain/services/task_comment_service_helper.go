@@ -0,0 +1,117 @@
+package services
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+)
+
+const (
+	// taskSummaryMaxComments is the number of latest comments sent to the model
+	taskSummaryMaxComments = 100
+	// taskSummaryCommentLimit is the number of characters of a comment sent to the model
+	taskSummaryCommentLimit = 1000
+	// taskSummaryDescriptionLimit is the number of characters of the description sent to the model
+	taskSummaryDescriptionLimit = 4000
+)
+
+const taskSummarySystemPrompt = `You summarize the discussion of a task in a project management tool for someone catching up on it.
+Answer with a JSON object {"currentState": string, "decisions": [string], "openQuestions": [string]}.
+"currentState" is a short paragraph on where the task stands now, later comments override earlier ones.
+"decisions" lists what was agreed, "openQuestions" lists what is still unanswered or blocked, mention who raised it when it matters.
+Only use facts from the given description and comments.`
+
+// latestCommentTime returns the latest creation or update time of the comments, nil when there are none.
+func latestCommentTime(comments []*models.TaskComment) *time.Time {
+	var latest *time.Time
+	for _, comment := range comments {
+		for _, t := range []time.Time{comment.CreatedAt, comment.UpdatedAt} {
+			if latest == nil || t.After(*latest) {
+				latest = &t
+			}
+		}
+	}
+	return latest
+}
+
+// taskSummaryVersion identifies what a summary was generated from: the prompt template version (0 for the
+// built-in one), the latest comment and the description.
+func taskSummaryVersion(task *models.Task, latestCommentAt *time.Time, promptTemplateVersion int) string {
+	var commentAt int64
+	if latestCommentAt != nil {
+		commentAt = latestCommentAt.UnixMilli()
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(task.Title))
+	hash.Write([]byte(task.Description))
+
+	return fmt.Sprintf("%d:%d:%x", promptTemplateVersion, commentAt, hash.Sum64())
+}
+
+func buildTaskSummaryPromptData(task *models.Task, description string, comments []*models.TaskComment, userMap map[string]models.User) taskSummaryPromptData {
+	if len(description) > taskSummaryDescriptionLimit {
+		description = truncatePromptText(description, taskSummaryDescriptionLimit) + "..."
+	}
+
+	data := taskSummaryPromptData{
+		TaskType:    task.Type.String(),
+		TaskRef:     task.TaskRef,
+		Title:       task.Title,
+		Status:      task.Status,
+		Description: description,
+		Comments:    make([]taskSummaryPromptComment, 0, min(len(comments), taskSummaryMaxComments)),
+	}
+
+	// Comments are sorted newest first, the model reads them oldest first
+	if len(comments) > taskSummaryMaxComments {
+		data.OmittedComments = len(comments) - taskSummaryMaxComments
+		comments = comments[:taskSummaryMaxComments]
+	}
+
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+
+		author := userMap[comment.UserID.Hex()].DisplayName
+		if author == "" {
+			author = "unknown"
+		}
+
+		content := strings.Join(strings.Fields(descriptionToText(comment.Content)), " ")
+		if len(content) > taskSummaryCommentLimit {
+			content = truncatePromptText(content, taskSummaryCommentLimit) + "..."
+		}
+
+		data.Comments = append(data.Comments, taskSummaryPromptComment{
+			CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04"),
+			Author:    author,
+			Content:   content,
+		})
+	}
+
+	return data
+}
+
+func taskSummarySchema() *repositories.LLMSchema {
+	list := func(description string) *repositories.LLMSchema {
+		return &repositories.LLMSchema{
+			Type:        repositories.LLMSchemaTypeArray,
+			Description: description,
+			Items:       &repositories.LLMSchema{Type: repositories.LLMSchemaTypeString},
+		}
+	}
+
+	return &repositories.LLMSchema{
+		Type:     repositories.LLMSchemaTypeObject,
+		Required: []string{"currentState", "decisions", "openQuestions"},
+		Properties: map[string]*repositories.LLMSchema{
+			"currentState":  {Type: repositories.LLMSchemaTypeString, Description: "Where the task stands now"},
+			"decisions":     list("What was agreed"),
+			"openQuestions": list("What is still unanswered or blocked"),
+		},
+	}
+}
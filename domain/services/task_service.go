@@ -2,29 +2,37 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cnc-csku/task-nexus-go-lib/utils/array"
 	"github.com/cnc-csku/task-nexus-go-lib/utils/conv"
 	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/config"
 	"github.com/cnc-csku/task-nexus/task-management/domain/constant"
 	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
 	"github.com/cnc-csku/task-nexus/task-management/domain/models"
 	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
 	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
 	"github.com/cnc-csku/task-nexus/task-management/domain/responses"
-	"github.com/google/generative-ai-go/genai"
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 type TaskService interface {
-	Create(ctx context.Context, req *requests.CreateTaskRequest, userID string) (*models.Task, *errutils.Error)
+	// Create returns the created task together with the open tasks of the project it may duplicate
+	Create(ctx context.Context, req *requests.CreateTaskRequest, userID string) (*responses.CreateTaskResponse, *errutils.Error)
 	GetTaskDetail(ctx context.Context, req *requests.GetTaskDetailPathParam, userId string) (*responses.GetTaskDetailResponse, *errutils.Error)
 	GetManyTaskDetail(ctx context.Context, req *requests.GetManyTaskDetailParams, userId string) ([]responses.GetTaskDetailResponse, *errutils.Error)
 	ListEpicTasks(ctx context.Context, req *requests.ListEpicTasksPathParam, userId string) ([]*models.Task, *errutils.Error)
 	SearchTask(ctx context.Context, req *requests.SearchTaskParams, userId string) ([]responses.SearchTaskResponse, *errutils.Error)
+	// NaturalLanguageSearch asks the LLM to translate the query into a search filter, then runs it like SearchTask
+	NaturalLanguageSearch(ctx context.Context, req *requests.NaturalLanguageSearchTaskRequest, userId string) (*responses.NaturalLanguageSearchTaskResponse, *errutils.Error)
 	GetChildrenTasks(ctx context.Context, req *requests.GetChildrenTasksParams, userId string) ([]responses.GetChildrenTasksResponse, *errutils.Error)
 	UpdateDetail(ctx context.Context, req *requests.UpdateTaskDetailRequest, userId string) (*models.Task, *errutils.Error)
 	UpdateTitle(ctx context.Context, req *requests.UpdateTaskTitleRequest, userId string) (*models.Task, *errutils.Error)
@@ -37,39 +45,91 @@ type TaskService interface {
 	UpdateSprint(ctx context.Context, req *requests.UpdateTaskSprintRequest, userId string) (*models.Task, *errutils.Error)
 	UpdateAttributes(ctx context.Context, req *requests.UpdateTaskAttributesRequest, userId string) (*models.Task, *errutils.Error)
 	GenerateDescription(ctx context.Context, req *requests.GenerateDescriptionRequest, userId string) (*responses.GenerateDescriptionResponse, *errutils.Error)
+	// StreamDescription generates a description like GenerateDescription, passing the text to onChunk as it is generated.
+	// It stops when ctx is done, so a disconnected client does not keep the model busy.
+	StreamDescription(ctx context.Context, req *requests.GenerateDescriptionRequest, userId string, onChunk func(chunk string) error) (*responses.GenerateDescriptionResponse, *errutils.Error)
+	// ProposeSubTasks asks the LLM to break a story, task or bug into sub-tasks, nothing is created until they are accepted
+	ProposeSubTasks(ctx context.Context, req *requests.ProposeSubTasksRequest, userId string) (*responses.ProposeSubTasksResponse, *errutils.Error)
+	AcceptSubTasks(ctx context.Context, req *requests.AcceptSubTasksRequest, userId string) ([]*models.Task, *errutils.Error)
+	GetPossibleDuplicates(ctx context.Context, req *requests.GetPossibleDuplicatesRequest, userId string) ([]responses.PossibleDuplicateTask, *errutils.Error)
+	// EstimatePoints asks the LLM for points per position, using done tasks of the project as examples
+	EstimatePoints(ctx context.Context, req *requests.EstimatePointsRequest, userId string) (*responses.EstimatePointsResponse, *errutils.Error)
 }
 
 type taskServiceImpl struct {
-	taskRepo          repositories.TaskRepository
-	projectRepo       repositories.ProjectRepository
-	projectMemberRepo repositories.ProjectMemberRepository
-	sprintRepo        repositories.SprintRepository
-	taskCommentRepo   repositories.TaskCommentRepository
-	userRepo          repositories.UserRepository
-	geminiRepo        repositories.GeminiRepository
+	config             *config.Config
+	taskRepo           repositories.TaskRepository
+	projectRepo        repositories.ProjectRepository
+	projectMemberRepo  repositories.ProjectMemberRepository
+	sprintRepo         repositories.SprintRepository
+	taskCommentRepo    repositories.TaskCommentRepository
+	userRepo           repositories.UserRepository
+	llmRepo            repositories.LLMRepository
+	taskEmbeddingRepo  repositories.TaskEmbeddingRepository
+	llmStreamRepo      repositories.LLMStreamCacheRepository
+	promptTemplateRepo repositories.PromptTemplateRepository
 }
 
 func NewTaskService(
+	config *config.Config,
 	taskRepo repositories.TaskRepository,
 	projectRepo repositories.ProjectRepository,
 	projectMemberRepo repositories.ProjectMemberRepository,
 	sprintRepo repositories.SprintRepository,
 	taskCommentRepo repositories.TaskCommentRepository,
 	userRepo repositories.UserRepository,
-	geminiRepo repositories.GeminiRepository,
+	llmRepo repositories.LLMRepository,
+	taskEmbeddingRepo repositories.TaskEmbeddingRepository,
+	llmStreamRepo repositories.LLMStreamCacheRepository,
+	promptTemplateRepo repositories.PromptTemplateRepository,
 ) TaskService {
 	return &taskServiceImpl{
-		taskRepo:          taskRepo,
-		projectRepo:       projectRepo,
-		projectMemberRepo: projectMemberRepo,
-		sprintRepo:        sprintRepo,
-		taskCommentRepo:   taskCommentRepo,
-		userRepo:          userRepo,
-		geminiRepo:        geminiRepo,
+		config:             config,
+		taskRepo:           taskRepo,
+		projectRepo:        projectRepo,
+		projectMemberRepo:  projectMemberRepo,
+		sprintRepo:         sprintRepo,
+		taskCommentRepo:    taskCommentRepo,
+		userRepo:           userRepo,
+		llmRepo:            llmRepo,
+		taskEmbeddingRepo:  taskEmbeddingRepo,
+		llmStreamRepo:      llmStreamRepo,
+		promptTemplateRepo: promptTemplateRepo,
 	}
 }
 
-func (s *taskServiceImpl) Create(ctx context.Context, req *requests.CreateTaskRequest, userID string) (*models.Task, *errutils.Error) {
+func (s *taskServiceImpl) Create(ctx context.Context, req *requests.CreateTaskRequest, userID string) (*responses.CreateTaskResponse, *errutils.Error) {
+	task, svcErr := s.create(ctx, req, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	resp := &responses.CreateTaskResponse{
+		Task:               task,
+		PossibleDuplicates: make([]responses.PossibleDuplicateTask, 0),
+	}
+
+	// The task is created even when the LLM provider is unavailable or slow, it is indexed again on its next update
+	duplicatesCtx, cancel := context.WithTimeout(ctx, s.config.LLM.EmbeddingTimeout)
+	defer cancel()
+
+	embedding, err := s.embedTask(duplicatesCtx, task)
+	if err != nil {
+		log.Printf("⚠️ Failed to embed task %s: %v", task.TaskRef, err)
+		return resp, nil
+	}
+
+	duplicates, err := s.findPossibleDuplicates(duplicatesCtx, task, embedding)
+	if err != nil {
+		log.Printf("⚠️ Failed to find possible duplicates of task %s: %v", task.TaskRef, err)
+		return resp, nil
+	}
+	resp.PossibleDuplicates = duplicates
+
+	return resp, nil
+}
+
+func (s *taskServiceImpl) create(ctx context.Context, req *requests.CreateTaskRequest, userID string) (*models.Task, *errutils.Error) {
 	bsonUserID, err := bson.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
@@ -97,7 +157,11 @@ func (s *taskServiceImpl) Create(ctx context.Context, req *requests.CreateTaskRe
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := ensureProjectIsActive(project); svcErr != nil {
+		return nil, svcErr
 	}
 
 	var priority models.TaskPriority
@@ -424,7 +488,7 @@ func (s *taskServiceImpl) GetTaskDetail(ctx context.Context, req *requests.GetTa
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
 	}
 
 	task, err := s.taskRepo.FindByTaskRefAndProjectID(ctx, req.TaskRef, bsonProjectID)
@@ -595,7 +659,7 @@ func (s *taskServiceImpl) GetManyTaskDetail(ctx context.Context, req *requests.G
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
 	}
 
 	tasks, err := s.taskRepo.FindByTaskRefsAndProjectID(ctx, req.TaskRefs, bsonProjectID)
@@ -764,7 +828,7 @@ func (s *taskServiceImpl) ListEpicTasks(ctx context.Context, req *requests.ListE
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
 	}
 
 	tasks, err := s.taskRepo.FindByProjectIDAndType(ctx, bsonProjectID, models.TaskTypeEpic)
@@ -794,6 +858,14 @@ func (s *taskServiceImpl) SearchTask(ctx context.Context, req *requests.SearchTa
 		taskTypes = append(taskTypes, models.TaskType(taskType))
 	}
 
+	var priorities []models.TaskPriority
+	for _, priority := range req.Priorities {
+		if !models.TaskPriority(priority).IsValid() {
+			return nil, errutils.NewError(exceptions.ErrInvalidTaskPriority, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("Invalid task priority: %s", priority))
+		}
+		priorities = append(priorities, models.TaskPriority(priority))
+	}
+
 	var (
 		isTaskWithNoSprint bool
 		bsonSprintIDs      []bson.ObjectID
@@ -855,7 +927,7 @@ func (s *taskServiceImpl) SearchTask(ctx context.Context, req *requests.SearchTa
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
 	}
 
 	tasks, err := s.taskRepo.Search(ctx, &repositories.SearchTaskRequest{
@@ -870,6 +942,8 @@ func (s *taskServiceImpl) SearchTask(ctx context.Context, req *requests.SearchTa
 		Statuses:           req.Statuses,
 		IsDoneStatuses:     getDoneStatuses(project),
 		SearchKeyword:      req.SearchKeyword,
+		Priorities:         priorities,
+		IsOverdue:          req.IsOverdue != nil && *req.IsOverdue,
 	})
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
@@ -985,7 +1059,7 @@ func (s *taskServiceImpl) GetChildrenTasks(ctx context.Context, req *requests.Ge
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
 	}
 
 	parentTask, err := s.taskRepo.FindByTaskRefAndProjectID(ctx, req.ParentTaskRef, bsonProjectID)
@@ -1131,7 +1205,11 @@ func (s *taskServiceImpl) UpdateDetail(ctx context.Context, req *requests.Update
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, task.ProjectID); svcErr != nil {
+		return nil, svcErr
 	}
 
 	updatedTask, err := s.taskRepo.UpdateDetail(ctx, &repositories.UpdateTaskDetailRequest{
@@ -1147,6 +1225,8 @@ func (s *taskServiceImpl) UpdateDetail(ctx context.Context, req *requests.Update
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	}
 
+	s.embedTaskInBackground(ctx, updatedTask)
+
 	return updatedTask, nil
 }
 
@@ -1172,7 +1252,11 @@ func (s *taskServiceImpl) UpdateTitle(ctx context.Context, req *requests.UpdateT
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, task.ProjectID); svcErr != nil {
+		return nil, svcErr
 	}
 
 	updatedTask, err := s.taskRepo.UpdateTitle(ctx, &repositories.UpdateTaskTitleRequest{
@@ -1184,6 +1268,8 @@ func (s *taskServiceImpl) UpdateTitle(ctx context.Context, req *requests.UpdateT
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	}
 
+	s.embedTaskInBackground(ctx, updatedTask)
+
 	return updatedTask, nil
 }
 
@@ -1209,7 +1295,11 @@ func (s *taskServiceImpl) UpdateParentID(ctx context.Context, req *requests.Upda
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, task.ProjectID); svcErr != nil {
+		return nil, svcErr
 	}
 
 	var (
@@ -1356,7 +1446,11 @@ func (s *taskServiceImpl) UpdateType(ctx context.Context, req *requests.UpdateTa
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, task.ProjectID); svcErr != nil {
+		return nil, svcErr
 	}
 
 	/*
@@ -1404,7 +1498,11 @@ func (s *taskServiceImpl) UpdateStatus(ctx context.Context, req *requests.Update
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := ensureProjectIsActive(project); svcErr != nil {
+		return nil, svcErr
 	}
 
 	task, err := s.taskRepo.FindByTaskRefAndProjectID(ctx, req.TaskID, bsonProjectID)
@@ -1511,7 +1609,11 @@ func (s *taskServiceImpl) UpdateApprovals(ctx context.Context, req *requests.Upd
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, task.ProjectID); svcErr != nil {
+		return nil, svcErr
 	}
 
 	approvals := make([]repositories.UpdateTaskApprovalsRequestApproval, 0, len(req.ApprovalUserIDs))
@@ -1556,13 +1658,17 @@ func (s *taskServiceImpl) ApproveTask(ctx context.Context, req *requests.Approve
 		return nil, errutils.NewError(exceptions.ErrTaskNotFound, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("Task not found: %s", req.TaskRef))
 	}
 
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, task.ProjectID); svcErr != nil {
+		return nil, svcErr
+	}
+
 	approvalUserIDs := make([]string, 0, len(task.Approvals))
 	for _, approval := range task.Approvals {
 		approvalUserIDs = append(approvalUserIDs, approval.UserID.Hex())
 	}
 
 	if !array.ContainAny(approvalUserIDs, []string{bsonUserID.Hex()}) {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not in the approval list")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not in the approval list")
 	}
 
 	updatedTask, err := s.taskRepo.ApproveTask(ctx, &repositories.ApproveTaskRequest{
@@ -1599,7 +1705,11 @@ func (s *taskServiceImpl) UpdateAssignees(ctx context.Context, req *requests.Upd
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, task.ProjectID); svcErr != nil {
+		return nil, svcErr
 	}
 
 	assignees := make([]repositories.UpdateTaskAssigneesRequestAssignee, 0, len(req.Assignees))
@@ -1678,7 +1788,11 @@ func (s *taskServiceImpl) UpdateSprint(ctx context.Context, req *requests.Update
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := checkProjectIsActive(ctx, s.projectRepo, task.ProjectID); svcErr != nil {
+		return nil, svcErr
 	}
 
 	var (
@@ -1803,7 +1917,11 @@ func (s *taskServiceImpl) UpdateAttributes(ctx context.Context, req *requests.Up
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	} else if member == nil {
-		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.BadRequest).WithDebugMessage("User is not a member of the project")
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	if svcErr := ensureProjectIsActive(project); svcErr != nil {
+		return nil, svcErr
 	}
 
 	attributeTemplateMap := make(map[string]models.ProjectAttributeTemplate)
@@ -1859,63 +1977,514 @@ func (s *taskServiceImpl) UpdateAttributes(ctx context.Context, req *requests.Up
 	return updatedTask, nil
 }
 
-// To be further implemented (prompt)
+// GenerateDescription returns BlockNote blocks describing a task with the given title, the blocks are validated
+// before they reach the editor.
 func (s *taskServiceImpl) GenerateDescription(ctx context.Context, req *requests.GenerateDescriptionRequest, userId string) (*responses.GenerateDescriptionResponse, *errutils.Error) {
+	prompt, svcErr := s.renderDescriptionPrompt(ctx, req, userId)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	release, svcErr := s.acquireGenerationSlot(ctx, userId)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	defer release()
+
+	generateCtx, cancel := context.WithTimeout(ctx, s.config.LLM.StreamTimeout)
+	defer cancel()
+
+	blocks, svcErr := generateDescriptionBlocks(generateCtx, s.llmRepo, &repositories.LLMCompletionRequest{
+		Prompt: prompt,
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	return &responses.GenerateDescriptionResponse{
+		Description: blocks,
+	}, nil
+}
+
+func (s *taskServiceImpl) StreamDescription(ctx context.Context, req *requests.GenerateDescriptionRequest, userId string, onChunk func(chunk string) error) (*responses.GenerateDescriptionResponse, *errutils.Error) {
+	prompt, svcErr := s.renderDescriptionPrompt(ctx, req, userId)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	release, svcErr := s.acquireGenerationSlot(ctx, userId)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	defer release()
+
+	streamCtx, cancel := context.WithTimeout(ctx, s.config.LLM.StreamTimeout)
+	defer cancel()
+
+	completion, err := s.llmRepo.Stream(streamCtx, &repositories.LLMCompletionRequest{
+		SystemPrompt: streamedDescriptionSystemPrompt,
+		Prompt:       prompt,
+	}, onChunk)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(streamCtx.Err(), context.DeadlineExceeded) {
+			return nil, errutils.NewError(exceptions.ErrGenerationTimedOut, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	blocks := descriptionBlocksFromMarkdown(completion.Text)
+	if !hasDescriptionText(blocks) {
+		return nil, errutils.NewError(exceptions.ErrInvalidGeneratedDescription, errutils.InternalServerError).WithDebugMessage("the description has no text")
+	}
+
+	return &responses.GenerateDescriptionResponse{
+		Description: blocks,
+	}, nil
+}
 
-	prompt := fmt.Sprintf(`
-	generate task description base on the task title "%s"  in this structure
+// acquireGenerationSlot counts a description generation against the concurrency limit of the user, streamed or not.
+// The returned func frees the slot.
+func (s *taskServiceImpl) acquireGenerationSlot(ctx context.Context, userId string) (func(), *errutils.Error) {
+	streamID := uuid.NewString()
 
-   [
+	acquired, err := s.llmStreamRepo.Acquire(ctx, userId, streamID, s.config.LLM.StreamConcurrencyPerUser, s.config.LLM.StreamTimeout)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if !acquired {
+		return nil, errutils.NewError(exceptions.ErrTooManyConcurrentGenerations, errutils.TooManyRequests).WithDebugMessage(fmt.Sprintf("at most %d generations at a time", s.config.LLM.StreamConcurrencyPerUser))
+	}
 
-           {
+	return func() {
+		// The slot is released even when the client is gone
+		if err := s.llmStreamRepo.Release(context.WithoutCancel(ctx), userId, streamID); err != nil {
+			log.Printf("⚠️ Failed to release LLM stream %s of %s: %v", streamID, userId, err)
+		}
+	}, nil
+}
+
+// renderDescriptionPrompt fills the description prompt template, the project is only read when the editor sends it.
+func (s *taskServiceImpl) renderDescriptionPrompt(ctx context.Context, req *requests.GenerateDescriptionRequest, userId string) (string, *errutils.Error) {
+	data := descriptionPromptData{
+		Title: req.Prompt,
+	}
 
-             id: "4bbfc57b-d00c-49f6-af09-caf198534f1f",
+	if req.Type != "" {
+		if !models.TaskType(req.Type).IsValid() {
+			return "", errutils.NewError(exceptions.ErrInvalidTaskType, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("invalid task type: %s", req.Type))
+		}
+		data.TaskType = req.Type
+	}
+
+	if req.ProjectID != "" {
+		bsonUserID, err := bson.ObjectIDFromHex(userId)
+		if err != nil {
+			return "", errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+		}
+
+		bsonProjectID, err := bson.ObjectIDFromHex(req.ProjectID)
+		if err != nil {
+			return "", errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+		}
 
-             type: "paragraph",
+		project, err := s.projectRepo.FindByProjectID(ctx, bsonProjectID)
+		if err != nil {
+			return "", errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		} else if project == nil {
+			return "", errutils.NewError(exceptions.ErrProjectNotFound, errutils.BadRequest)
+		}
+
+		member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
+		if err != nil {
+			return "", errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		} else if member == nil {
+			return "", errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+		}
+
+		data.ProjectName = project.Name
+	}
+
+	return renderPrompt(ctx, s.promptTemplateRepo, models.PromptTemplateKeyDescription, data)
+}
+
+func (s *taskServiceImpl) ProposeSubTasks(ctx context.Context, req *requests.ProposeSubTasksRequest, userId string) (*responses.ProposeSubTasksResponse, *errutils.Error) {
+	project, parentTask, svcErr := s.findSubTaskParent(ctx, req.ProjectID, req.TaskRef, userId)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	maxSubTasks := req.MaxSubTasks
+	if maxSubTasks == 0 {
+		maxSubTasks = defaultMaxProposedSubTasks
+	}
+
+	children, err := s.taskRepo.FindByParentID(ctx, parentTask.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
 
-             props: { textColor: "default", backgroundColor: "default", textAlignment: "left" },
+	type subTasksOutput struct {
+		SubTasks []responses.ProposedSubTask `json:"subTasks"`
+	}
 
-             content: [{ type: "text", text: " ", styles: {} }],
+	prompt, svcErr := renderPrompt(ctx, s.promptTemplateRepo, models.PromptTemplateKeySubTasks, buildSubTasksPromptData(project, parentTask, children, maxSubTasks))
+	if svcErr != nil {
+		return nil, svcErr
+	}
 
-             children: [],
+	out, svcErr := completeValidatedJSON(ctx, s.llmRepo, &repositories.LLMCompletionRequest{
+		SystemPrompt: subTasksSystemPrompt,
+		Prompt:       prompt,
+		Schema:       subTasksSchema(project.Positions),
+	}, exceptions.ErrInvalidGeneratedSubTasks, func(out *subTasksOutput) error {
+		return validateProposedSubTasks(out.SubTasks, project.Positions, maxSubTasks)
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
 
-           },
+	return &responses.ProposeSubTasksResponse{
+		ParentTaskRef: parentTask.TaskRef,
+		SubTasks:      out.SubTasks,
+	}, nil
+}
 
-          ....
+// AcceptSubTasks creates the chosen sub-tasks through Create, so the parent's points and children flag stay consistent.
+// Sub-tasks created before a failure are kept.
+func (s *taskServiceImpl) AcceptSubTasks(ctx context.Context, req *requests.AcceptSubTasksRequest, userId string) ([]*models.Task, *errutils.Error) {
+	project, parentTask, svcErr := s.findSubTaskParent(ctx, req.ProjectID, req.TaskRef, userId)
+	if svcErr != nil {
+		return nil, svcErr
+	}
 
-         ]
+	for _, subTask := range req.SubTasks {
+		if !slices.Contains(project.Positions, subTask.Position) {
+			return nil, errutils.NewError(exceptions.ErrPositionNotInProject, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("Position not found: %s", subTask.Position))
+		}
+	}
 
+	parentID := parentTask.ID.Hex()
+	priority := parentTask.Priority.String()
 
-		Structure Reference
+	// Sub-tasks are planned together with their parent
+	var sprintID *string
+	if parentTask.Sprint != nil && parentTask.Sprint.CurrentSprintID != nil {
+		currentSprintID := parentTask.Sprint.CurrentSprintID.Hex()
+		sprintID = &currentSprintID
+	}
 
-		type ParagraphBlock = {  id: string;  type: "paragraph";  props: DefaultProps;  content: InlineContent[];  children: Block[];};
+	createdTasks := make([]*models.Task, 0, len(req.SubTasks))
+	for _, subTask := range req.SubTasks {
+		description, err := descriptionFromText(subTask.Description)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
 
+		createdTask, svcErr := s.create(ctx, &requests.CreateTaskRequest{
+			ProjectID:   req.ProjectID,
+			Title:       strings.TrimSpace(subTask.Title),
+			Description: description,
+			ParentID:    &parentID,
+			Type:        models.TaskTypeSubTask.String(),
+			Priority:    &priority,
+			SprintID:    sprintID,
+			Assignees: []requests.CreateTaskRequestAssignee{
+				{
+					Position: subTask.Position,
+					Point:    subTask.Point,
+				},
+			},
+		}, userId)
+		if svcErr != nil {
+			return nil, svcErr
+		}
 
+		s.embedTaskInBackground(ctx, createdTask)
 
-		type HeadingBlock = {  id: string;  type: "heading";  props: {    level: 1 | 2 | 3 = 1;  } & DefaultProps;  content: InlineContent[];  children: Block[];};
+		createdTasks = append(createdTasks, createdTask)
+	}
 
+	return createdTasks, nil
+}
 
+// findSubTaskParent returns the task that sub-tasks are proposed for, it must be a story, task or bug.
+func (s *taskServiceImpl) findSubTaskParent(ctx context.Context, projectID string, taskRef string, userId string) (*models.Project, *models.Task, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
 
-		type BulletListItemBlock = {  id: string;  type: "bulletListItem";  props: DefaultProps;  content: InlineContent[];  children: Block[];};
+	bsonProjectID, err := bson.ObjectIDFromHex(projectID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
 
+	project, err := s.projectRepo.FindByProjectID(ctx, bsonProjectID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if project == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.BadRequest)
+	}
 
+	member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
 
-		type NumberedListItemBlock = {  id: string;  type: "numberedListItem";  props: DefaultProps;  content: InlineContent[];  children: Block[];};
+	if svcErr := ensureProjectIsActive(project); svcErr != nil {
+		return nil, nil, svcErr
+	}
 
-		response only JSON
-	`, req.Prompt)
+	if len(project.Positions) == 0 {
+		return nil, nil, errutils.NewError(exceptions.ErrNoPositionProvided, errutils.BadRequest)
+	}
 
-	resp, err := s.geminiRepo.GenerateTaskDescription(ctx, prompt)
+	task, err := s.taskRepo.FindByTaskRefAndProjectID(ctx, taskRef, bsonProjectID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if task == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrTaskNotFound, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("Task not found: %s", taskRef))
+	}
+
+	if svcErr := validateParentTaskType(models.TaskTypeSubTask.String(), task.Type); svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	return project, task, nil
+}
+
+func (s *taskServiceImpl) GetPossibleDuplicates(ctx context.Context, req *requests.GetPossibleDuplicatesRequest, userId string) ([]responses.PossibleDuplicateTask, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonProjectID, err := bson.ObjectIDFromHex(req.ProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	task, err := s.taskRepo.FindByTaskRefAndProjectID(ctx, req.TaskRef, bsonProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if task == nil {
+		return nil, errutils.NewError(exceptions.ErrTaskNotFound, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("Task not found: %s", req.TaskRef))
+	}
+
+	member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, task.ProjectID, bsonUserID)
 	if err != nil {
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
 	}
 
-	var parts []genai.Part
-	for _, content := range resp {
-		parts = append(parts, content.Parts...)
+	embedding, err := s.taskEmbeddingRepo.FindByTaskID(ctx, task.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
 	}
 
-	return &responses.GenerateDescriptionResponse{
-		Description: parts,
+	duplicatesCtx, cancel := context.WithTimeout(ctx, s.config.LLM.EmbeddingTimeout)
+	defer cancel()
+
+	// Tasks created before embeddings existed, or whose embedding failed, are embedded on demand
+	if embedding == nil || embedding.UpdatedAt.Before(task.UpdatedAt) {
+		embedding, err = s.embedTask(duplicatesCtx, task)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
+	}
+
+	duplicates, err := s.findPossibleDuplicates(duplicatesCtx, task, embedding)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return duplicates, nil
+}
+
+// EstimatePoints suggests points per position from the done tasks of the project, nothing is saved until
+// the assignees are updated.
+func (s *taskServiceImpl) EstimatePoints(ctx context.Context, req *requests.EstimatePointsRequest, userId string) (*responses.EstimatePointsResponse, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonProjectID, err := bson.ObjectIDFromHex(req.ProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	project, err := s.projectRepo.FindByProjectID(ctx, bsonProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if project == nil {
+		return nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.BadRequest)
+	}
+
+	member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	task, err := s.taskRepo.FindByTaskRefAndProjectID(ctx, req.TaskRef, bsonProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if task == nil {
+		return nil, errutils.NewError(exceptions.ErrTaskNotFound, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("Task not found: %s", req.TaskRef))
+	}
+
+	// The points of an epic are the sum of its tasks
+	if task.Type == models.TaskTypeEpic {
+		return nil, errutils.NewError(exceptions.ErrInvalidTaskType, errutils.BadRequest).WithDebugMessage("epics can not be estimated")
+	}
+
+	positions := req.Positions
+	if len(positions) == 0 {
+		for _, assignee := range task.Assignees {
+			if !slices.Contains(positions, assignee.Position) {
+				positions = append(positions, assignee.Position)
+			}
+		}
+	}
+	if len(positions) == 0 {
+		positions = project.Positions
+	}
+	if len(positions) == 0 {
+		return nil, errutils.NewError(exceptions.ErrNoPositionProvided, errutils.BadRequest)
+	}
+	for _, position := range positions {
+		if !slices.Contains(project.Positions, position) {
+			return nil, errutils.NewError(exceptions.ErrPositionNotInProject, errutils.BadRequest).WithDebugMessage(fmt.Sprintf("position %q is not defined in the project", position))
+		}
+	}
+
+	doneTasks, err := s.taskRepo.FindByProjectIDAndStatuses(ctx, bsonProjectID, getDoneStatuses(project))
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	history := make([]*models.Task, 0, len(doneTasks))
+	for _, doneTask := range doneTasks {
+		if doneTask.ID != task.ID && doneTask.Type != models.TaskTypeEpic && len(taskPointsByPosition(doneTask)) > 0 {
+			history = append(history, doneTask)
+		}
+	}
+	if len(history) == 0 {
+		return nil, errutils.NewError(exceptions.ErrNoPointEstimationHistory, errutils.BadRequest)
+	}
+
+	examples := s.selectPointEstimationExamples(ctx, task, history)
+
+	exampleRefs := make([]string, 0, len(examples))
+	examplesByRef := make(map[string]*models.Task, len(examples))
+	for _, example := range examples {
+		exampleRefs = append(exampleRefs, example.TaskRef)
+		examplesByRef[example.TaskRef] = example
+	}
+
+	type pointEstimateOutput struct {
+		Estimates       []responses.PointEstimate `json:"estimates"`
+		SimilarTaskRefs []string                  `json:"similarTaskRefs"`
+	}
+
+	out, svcErr := completeValidatedJSON(ctx, s.llmRepo, &repositories.LLMCompletionRequest{
+		SystemPrompt: pointEstimateSystemPrompt,
+		Prompt:       buildPointEstimatePrompt(project, task, positions, examples),
+		Schema:       pointEstimateSchema(positions, exampleRefs),
+	}, exceptions.ErrInvalidGeneratedPointEstimate, func(out *pointEstimateOutput) error {
+		var err error
+		if out.Estimates, err = validatePointEstimates(out.Estimates, positions); err != nil {
+			return err
+		}
+		out.SimilarTaskRefs, err = validateSimilarTaskRefs(out.SimilarTaskRefs, exampleRefs)
+		return err
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	similarTasks := make([]responses.PointEstimateSimilarTask, 0, len(out.SimilarTaskRefs))
+	for _, taskRef := range out.SimilarTaskRefs {
+		example := examplesByRef[taskRef]
+		similarTasks = append(similarTasks, responses.PointEstimateSimilarTask{
+			TaskRef: example.TaskRef,
+			Title:   example.Title,
+			Type:    example.Type.String(),
+			Points:  taskPointsByPosition(example),
+		})
+	}
+
+	return &responses.EstimatePointsResponse{
+		TaskRef:      task.TaskRef,
+		Estimates:    out.Estimates,
+		SimilarTasks: similarTasks,
+	}, nil
+}
+
+func (s *taskServiceImpl) NaturalLanguageSearch(ctx context.Context, req *requests.NaturalLanguageSearchTaskRequest, userId string) (*responses.NaturalLanguageSearchTaskResponse, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonProjectID, err := bson.ObjectIDFromHex(req.ProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	project, err := s.projectRepo.FindByProjectID(ctx, bsonProjectID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if project == nil {
+		return nil, errutils.NewError(exceptions.ErrProjectNotFound, errutils.BadRequest)
+	}
+
+	member, err := s.projectMemberRepo.FindByProjectIDAndUserID(ctx, bsonProjectID, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not a member of the project")
+	}
+
+	searchContext, err := s.loadTaskSearchContext(ctx, project, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	out, svcErr := completeValidatedJSON(ctx, s.llmRepo, &repositories.LLMCompletionRequest{
+		SystemPrompt: taskSearchSystemPrompt,
+		Prompt:       searchContext.buildPrompt(req.Query),
+		Schema:       searchContext.schema(),
+	}, exceptions.ErrInvalidGeneratedSearchFilter, searchContext.validate)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	filter := searchContext.toFilter(out)
+
+	tasks, svcErr := s.SearchTask(ctx, &requests.SearchTaskParams{
+		ProjectID:       req.ProjectID,
+		SprintIDs:       filter.SprintIDs,
+		IsTaskInBacklog: filter.IsTaskInBacklog,
+		EpicTaskID:      filter.EpicTaskID,
+		UserIDs:         filter.UserIDs,
+		Positions:       filter.Positions,
+		Statuses:        filter.Statuses,
+		SearchKeyword:   filter.SearchKeyword,
+		Types:           filter.Types,
+		Priorities:      filter.Priorities,
+		IsOverdue:       filter.IsOverdue,
+	}, userId)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	return &responses.NaturalLanguageSearchTaskResponse{
+		Filter: *filter,
+		Tasks:  tasks,
 	}, nil
 }
@@ -1,14 +1,26 @@
 package services
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/cnc-csku/task-nexus-go-lib/utils/array"
 	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/constant"
 	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
 	"github.com/cnc-csku/task-nexus/task-management/domain/models"
 	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/responses"
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -335,3 +347,1075 @@ func updateParentTaskPoints(
 
 	return nil
 }
+
+const (
+	// llmRepairAttempts is how many times invalid generated output is sent back to the model for repair
+	llmRepairAttempts = 2
+	// descriptionMaxDepth is the deepest nesting of blocks the description editor renders well
+	descriptionMaxDepth = 2
+)
+
+const descriptionSystemPrompt = `You write task descriptions for a project management tool.
+Answer with a JSON object {"blocks": [...]} where each block is a BlockNote block:
+{"id": string, "type": "paragraph" | "heading" | "bulletListItem" | "numberedListItem",
+ "props": {"textColor": "default", "backgroundColor": "default", "textAlignment": "left", "level": 1 | 2 | 3 (headings only)},
+ "content": [{"type": "text", "text": string, "styles": {"bold"?: true, "italic"?: true, "code"?: true}}],
+ "children": [nested blocks]}
+Keep the description short and practical: a summary paragraph, then headings with list items where useful.`
+
+var descriptionColors = []string{"default", "gray", "brown", "red", "orange", "yellow", "green", "blue", "purple", "pink"}
+
+var descriptionTextAlignments = []string{"left", "center", "right", "justify"}
+
+// generateDescriptionBlocks asks the model for description blocks that match the block schema.
+func generateDescriptionBlocks(ctx context.Context, llmRepo repositories.LLMRepository, in *repositories.LLMCompletionRequest) ([]models.DescriptionBlock, *errutils.Error) {
+	type descriptionOutput struct {
+		Blocks []models.DescriptionBlock `json:"blocks"`
+	}
+
+	request := *in
+	request.SystemPrompt = descriptionSystemPrompt
+	request.Schema = descriptionBlocksSchema()
+
+	out, svcErr := completeValidatedJSON(ctx, llmRepo, &request, exceptions.ErrInvalidGeneratedDescription, func(out *descriptionOutput) error {
+		if err := normalizeDescriptionBlocks(out.Blocks, 1); err != nil {
+			return err
+		} else if !hasDescriptionText(out.Blocks) {
+			return errors.New("the description has no text")
+		}
+		return nil
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	return out.Blocks, nil
+}
+
+// streamedDescriptionSystemPrompt asks for Markdown, JSON can not be shown to the user while it is generated.
+const streamedDescriptionSystemPrompt = `You write task descriptions for a project management tool.
+Answer in plain Markdown using only "#", "##" and "###" headings, "-" bullet lists, "1." numbered lists, paragraphs, **bold** and ` + "`code`" + `.
+Keep the description short and practical: a summary paragraph, then headings with list items where useful.`
+
+// descriptionBlocksFromMarkdown converts the Markdown subset of streamedDescriptionSystemPrompt into description blocks.
+func descriptionBlocksFromMarkdown(markdown string) []models.DescriptionBlock {
+	blocks := make([]models.DescriptionBlock, 0)
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "```") {
+			continue
+		}
+
+		block := models.DescriptionBlock{
+			ID:   uuid.NewString(),
+			Type: models.DescriptionBlockTypeParagraph,
+			Props: models.DescriptionBlockProps{
+				TextColor:       "default",
+				BackgroundColor: "default",
+				TextAlignment:   "left",
+			},
+			Children: []models.DescriptionBlock{},
+		}
+
+		if level := len(line) - len(strings.TrimLeft(line, "#")); level > 0 && strings.HasPrefix(line[level:], " ") {
+			block.Type = models.DescriptionBlockTypeHeading
+			block.Props.Level = min(level, 3)
+			line = line[level+1:]
+		} else if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") {
+			block.Type = models.DescriptionBlockTypeBulletListItem
+			line = line[2:]
+		} else if dot := strings.Index(line, ". "); dot > 0 && strings.Trim(line[:dot], "0123456789") == "" {
+			block.Type = models.DescriptionBlockTypeNumberedListItem
+			line = line[dot+2:]
+		}
+
+		block.Content = markdownInlineContent(strings.TrimSpace(line))
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+// markdownInlineContent splits a line into text content styled by its **bold** and `code` spans.
+func markdownInlineContent(line string) []models.DescriptionInlineContent {
+	contents := make([]models.DescriptionInlineContent, 0)
+	var styles models.DescriptionTextStyles
+
+	for line != "" {
+		next := strings.IndexAny(line, "*`")
+		if next < 0 {
+			next = len(line)
+		}
+
+		if text := line[:next]; text != "" {
+			contents = append(contents, models.DescriptionInlineContent{Type: "text", Text: text, Styles: styles})
+		}
+		line = line[next:]
+
+		switch {
+		case strings.HasPrefix(line, "**"):
+			styles.Bold = !styles.Bold
+			line = line[2:]
+		case strings.HasPrefix(line, "`"):
+			styles.Code = !styles.Code
+			line = line[1:]
+		case line != "":
+			// A single "*" is kept as text
+			contents = append(contents, models.DescriptionInlineContent{Type: "text", Text: "*", Styles: styles})
+			line = line[1:]
+		}
+	}
+
+	return contents
+}
+
+// completeValidatedJSON asks the model for a JSON document and checks it with validate. Output that is not valid JSON
+// or fails validation is sent back to the model together with the problem, so it can repair it.
+// invalidErr is returned when the output is still invalid after the repair attempts.
+func completeValidatedJSON[T any](
+	ctx context.Context,
+	llmRepo repositories.LLMRepository,
+	in *repositories.LLMCompletionRequest,
+	invalidErr error,
+	validate func(out *T) error,
+) (*T, *errutils.Error) {
+	request := *in
+
+	var lastErr error
+	for attempt := 0; attempt <= llmRepairAttempts; attempt++ {
+		out := new(T)
+
+		completion, err := llmRepo.CompleteJSON(ctx, &request, out)
+		if err == nil {
+			err = validate(out)
+			if err == nil {
+				return out, nil
+			}
+		} else if completion == nil {
+			// The provider failed, there is no output to repair
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
+
+		lastErr = err
+		log.Printf("⚠️ Generated output is invalid (attempt %d): %v", attempt+1, err)
+
+		request.Prompt = fmt.Sprintf("%s\n\nYour previous answer was invalid: %v\nPrevious answer:\n%s\n\nAnswer again with a corrected JSON object.", in.Prompt, err, completion.Text)
+	}
+
+	return nil, errutils.NewError(invalidErr, errutils.InternalServerError).WithDebugMessage(lastErr.Error())
+}
+
+// normalizeDescriptionBlocks validates the blocks against the block schema and fills in what the model may omit:
+// IDs, default props, empty content and children.
+func normalizeDescriptionBlocks(blocks []models.DescriptionBlock, depth int) error {
+	if depth > descriptionMaxDepth && len(blocks) > 0 {
+		return fmt.Errorf("blocks are nested deeper than %d levels", descriptionMaxDepth)
+	}
+
+	for i := range blocks {
+		block := &blocks[i]
+
+		if !block.Type.IsValid() {
+			return fmt.Errorf("block %d has an unsupported type %q", i, block.Type)
+		}
+
+		// The model can not be trusted to generate unique IDs
+		block.ID = uuid.NewString()
+
+		if block.Type == models.DescriptionBlockTypeHeading {
+			if block.Props.Level == 0 {
+				block.Props.Level = 1
+			} else if block.Props.Level < 1 || block.Props.Level > 3 {
+				return fmt.Errorf("heading %d has an invalid level %d, it must be 1, 2 or 3", i, block.Props.Level)
+			}
+		} else {
+			block.Props.Level = 0
+		}
+
+		if block.Props.TextColor == "" {
+			block.Props.TextColor = "default"
+		} else if !slices.Contains(descriptionColors, block.Props.TextColor) {
+			return fmt.Errorf("block %d has an invalid textColor %q", i, block.Props.TextColor)
+		}
+
+		if block.Props.BackgroundColor == "" {
+			block.Props.BackgroundColor = "default"
+		} else if !slices.Contains(descriptionColors, block.Props.BackgroundColor) {
+			return fmt.Errorf("block %d has an invalid backgroundColor %q", i, block.Props.BackgroundColor)
+		}
+
+		if block.Props.TextAlignment == "" {
+			block.Props.TextAlignment = "left"
+		} else if !slices.Contains(descriptionTextAlignments, block.Props.TextAlignment) {
+			return fmt.Errorf("block %d has an invalid textAlignment %q", i, block.Props.TextAlignment)
+		}
+
+		if block.Content == nil {
+			block.Content = []models.DescriptionInlineContent{}
+		}
+		for j, content := range block.Content {
+			if content.Type == "" {
+				block.Content[j].Type = "text"
+			} else if content.Type != "text" {
+				return fmt.Errorf("content %d of block %d has an unsupported type %q", j, i, content.Type)
+			}
+		}
+
+		if block.Children == nil {
+			block.Children = []models.DescriptionBlock{}
+		}
+		if err := normalizeDescriptionBlocks(block.Children, depth+1); err != nil {
+			return fmt.Errorf("children of block %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func hasDescriptionText(blocks []models.DescriptionBlock) bool {
+	for _, block := range blocks {
+		for _, content := range block.Content {
+			if strings.TrimSpace(content.Text) != "" {
+				return true
+			}
+		}
+		if hasDescriptionText(block.Children) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// descriptionBlocksSchema describes {"blocks": [...]}, the schema can not be recursive so it stops at descriptionMaxDepth.
+func descriptionBlocksSchema() *repositories.LLMSchema {
+	return &repositories.LLMSchema{
+		Type:     repositories.LLMSchemaTypeObject,
+		Required: []string{"blocks"},
+		Properties: map[string]*repositories.LLMSchema{
+			"blocks": {
+				Type:  repositories.LLMSchemaTypeArray,
+				Items: descriptionBlockSchema(1),
+			},
+		},
+	}
+}
+
+func descriptionBlockSchema(depth int) *repositories.LLMSchema {
+	schema := &repositories.LLMSchema{
+		Type:     repositories.LLMSchemaTypeObject,
+		Required: []string{"type", "content"},
+		Properties: map[string]*repositories.LLMSchema{
+			"id": {Type: repositories.LLMSchemaTypeString},
+			"type": {
+				Type: repositories.LLMSchemaTypeString,
+				Enum: []string{
+					models.DescriptionBlockTypeParagraph.String(),
+					models.DescriptionBlockTypeHeading.String(),
+					models.DescriptionBlockTypeBulletListItem.String(),
+					models.DescriptionBlockTypeNumberedListItem.String(),
+				},
+			},
+			"props": {
+				Type: repositories.LLMSchemaTypeObject,
+				Properties: map[string]*repositories.LLMSchema{
+					"textColor":       {Type: repositories.LLMSchemaTypeString, Enum: descriptionColors},
+					"backgroundColor": {Type: repositories.LLMSchemaTypeString, Enum: descriptionColors},
+					"textAlignment":   {Type: repositories.LLMSchemaTypeString, Enum: descriptionTextAlignments},
+					"level":           {Type: repositories.LLMSchemaTypeInteger, Description: "Heading level from 1 to 3, only for headings"},
+				},
+			},
+			"content": {
+				Type: repositories.LLMSchemaTypeArray,
+				Items: &repositories.LLMSchema{
+					Type:     repositories.LLMSchemaTypeObject,
+					Required: []string{"type", "text"},
+					Properties: map[string]*repositories.LLMSchema{
+						"type": {Type: repositories.LLMSchemaTypeString, Enum: []string{"text"}},
+						"text": {Type: repositories.LLMSchemaTypeString},
+						"styles": {
+							Type: repositories.LLMSchemaTypeObject,
+							Properties: map[string]*repositories.LLMSchema{
+								"bold":      {Type: repositories.LLMSchemaTypeBoolean},
+								"italic":    {Type: repositories.LLMSchemaTypeBoolean},
+								"underline": {Type: repositories.LLMSchemaTypeBoolean},
+								"strike":    {Type: repositories.LLMSchemaTypeBoolean},
+								"code":      {Type: repositories.LLMSchemaTypeBoolean},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if depth < descriptionMaxDepth {
+		schema.Properties["children"] = &repositories.LLMSchema{
+			Type:  repositories.LLMSchemaTypeArray,
+			Items: descriptionBlockSchema(depth + 1),
+		}
+	}
+
+	return schema
+}
+
+const (
+	defaultMaxProposedSubTasks = 8
+	// maxProposedSubTaskPoint keeps the model from estimating a sub-task as large as an epic
+	maxProposedSubTaskPoint = 21
+	// subTaskPromptDescriptionLimit is the number of characters of the parent description sent to the model
+	subTaskPromptDescriptionLimit = 4000
+)
+
+const subTasksSystemPrompt = `You are an experienced agile team lead breaking work items into sub-tasks.
+Each sub-task is a small, independently finishable piece of work done by one person.
+Answer with a JSON object {"subTasks": [{"title": string, "description": string, "position": string, "point": integer}]}.
+"description" is one or two plain sentences, "position" is one of the positions of the team,
+"point" is a story point estimate on the Fibonacci scale (1, 2, 3, 5, 8, 13).`
+
+func buildSubTasksPromptData(project *models.Project, parentTask *models.Task, children []*models.Task, maxSubTasks int) subTasksPromptData {
+	description := descriptionToText(parentTask.Description)
+	if len(description) > subTaskPromptDescriptionLimit {
+		description = truncatePromptText(description, subTaskPromptDescriptionLimit)
+	}
+
+	existingSubTasks := make([]string, 0, len(children))
+	for _, child := range children {
+		existingSubTasks = append(existingSubTasks, child.Title)
+	}
+
+	return subTasksPromptData{
+		ProjectName:      project.Name,
+		TaskType:         parentTask.Type.String(),
+		Title:            parentTask.Title,
+		Description:      description,
+		Positions:        project.Positions,
+		MaxSubTasks:      maxSubTasks,
+		ExistingSubTasks: existingSubTasks,
+	}
+}
+
+func validateProposedSubTasks(subTasks []responses.ProposedSubTask, positions []string, maxSubTasks int) error {
+	if len(subTasks) == 0 {
+		return errors.New("no sub-tasks were proposed")
+	} else if len(subTasks) > maxSubTasks {
+		return fmt.Errorf("%d sub-tasks were proposed, at most %d are allowed", len(subTasks), maxSubTasks)
+	}
+
+	for i := range subTasks {
+		subTask := &subTasks[i]
+		subTask.Title = strings.TrimSpace(subTask.Title)
+		subTask.Description = strings.TrimSpace(subTask.Description)
+
+		if subTask.Title == "" {
+			return fmt.Errorf("sub-task %d has no title", i)
+		} else if !slices.Contains(positions, subTask.Position) {
+			return fmt.Errorf("sub-task %d has the position %q, it must be one of %s", i, subTask.Position, strings.Join(positions, ", "))
+		} else if subTask.Point < 0 || subTask.Point > maxProposedSubTaskPoint {
+			return fmt.Errorf("sub-task %d has %d points, it must be between 0 and %d", i, subTask.Point, maxProposedSubTaskPoint)
+		}
+	}
+
+	return nil
+}
+
+func subTasksSchema(positions []string) *repositories.LLMSchema {
+	return &repositories.LLMSchema{
+		Type:     repositories.LLMSchemaTypeObject,
+		Required: []string{"subTasks"},
+		Properties: map[string]*repositories.LLMSchema{
+			"subTasks": {
+				Type: repositories.LLMSchemaTypeArray,
+				Items: &repositories.LLMSchema{
+					Type:     repositories.LLMSchemaTypeObject,
+					Required: []string{"title", "description", "position", "point"},
+					Properties: map[string]*repositories.LLMSchema{
+						"title":       {Type: repositories.LLMSchemaTypeString},
+						"description": {Type: repositories.LLMSchemaTypeString},
+						"position":    {Type: repositories.LLMSchemaTypeString, Enum: positions},
+						"point":       {Type: repositories.LLMSchemaTypeInteger},
+					},
+				},
+			},
+		},
+	}
+}
+
+const (
+	// pointEstimationMaxExamples is the number of done tasks sent to the model as examples
+	pointEstimationMaxExamples = 30
+	// pointEstimationMaxSimilarTasks is the number of examples the estimate may refer to
+	pointEstimationMaxSimilarTasks = 3
+	// maxEstimatedPoint keeps the estimate of a single position in a sensible range
+	maxEstimatedPoint = 100
+)
+
+const pointEstimateSystemPrompt = `You are an experienced agile team lead estimating story points.
+The team's done tasks and their final points by position are given as examples, estimate the new task on the same scale.
+Answer with a JSON object {"estimates": [{"position": string, "point": integer, "justification": string}], "similarTaskRefs": [string]}.
+Give one estimate for each requested position, "justification" is one short sentence comparing the task with the examples.
+"similarTaskRefs" lists the references of the examples most similar to the new task, most similar first.`
+
+// taskPointsByPosition sums the points of the assignees of each position, assignees without points are skipped.
+func taskPointsByPosition(task *models.Task) map[string]int {
+	points := make(map[string]int)
+	for _, assignee := range task.Assignees {
+		if assignee.Point != nil {
+			points[assignee.Position] += *assignee.Point
+		}
+	}
+	return points
+}
+
+// selectPointEstimationExamples returns the done tasks most similar to the task by embedding. When the task
+// can not be embedded, or for done tasks without embeddings, the latest updated tasks are used.
+func (s *taskServiceImpl) selectPointEstimationExamples(ctx context.Context, task *models.Task, history []*models.Task) []*models.Task {
+	similarities := make(map[bson.ObjectID]float64)
+
+	embedding, err := s.taskEmbeddingRepo.FindByTaskID(ctx, task.ID)
+	if err == nil && (embedding == nil || embedding.UpdatedAt.Before(task.UpdatedAt)) {
+		embedCtx, cancel := context.WithTimeout(ctx, s.config.LLM.EmbeddingTimeout)
+		embedding, err = s.embedTask(embedCtx, task)
+		cancel()
+	}
+	if err == nil {
+		var projectEmbeddings []*models.TaskEmbedding
+		projectEmbeddings, err = s.taskEmbeddingRepo.FindByProjectIDAndModel(ctx, task.ProjectID, embedding.Provider, embedding.Model)
+		for _, projectEmbedding := range projectEmbeddings {
+			similarities[projectEmbedding.TaskID] = cosineSimilarity(embedding.Vector, projectEmbedding.Vector)
+		}
+	}
+	if err != nil {
+		log.Printf("⚠️ Failed to rank estimation examples of task %s: %v", task.TaskRef, err)
+	}
+
+	examples := slices.Clone(history)
+	slices.SortFunc(examples, func(a, b *models.Task) int {
+		similarityA, okA := similarities[a.ID]
+		similarityB, okB := similarities[b.ID]
+		if okA != okB {
+			if okA {
+				return -1
+			}
+			return 1
+		} else if okA && similarityA != similarityB {
+			return cmp.Compare(similarityB, similarityA)
+		}
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+
+	if len(examples) > pointEstimationMaxExamples {
+		examples = examples[:pointEstimationMaxExamples]
+	}
+
+	return examples
+}
+
+func buildPointEstimatePrompt(project *models.Project, task *models.Task, positions []string, examples []*models.Task) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Estimate the points of a task of the project %q for the positions: %s\n\n", project.Name, strings.Join(positions, ", "))
+	sb.WriteString("Done tasks (reference, type, title, description length in characters, final points by position):\n")
+	for _, example := range examples {
+		points := taskPointsByPosition(example)
+
+		examplePositions := make([]string, 0, len(points))
+		for position := range points {
+			examplePositions = append(examplePositions, position)
+		}
+		slices.Sort(examplePositions)
+
+		pointTexts := make([]string, 0, len(examplePositions))
+		for _, position := range examplePositions {
+			pointTexts = append(pointTexts, fmt.Sprintf("%s %d", position, points[position]))
+		}
+
+		fmt.Fprintf(&sb, "- [%s] %s, %q, %d characters, %s\n",
+			example.TaskRef, example.Type, example.Title, len(descriptionToText(example.Description)), strings.Join(pointTexts, ", "))
+	}
+
+	description := descriptionToText(task.Description)
+	fmt.Fprintf(&sb, "\nNew task: %s, %q, %d characters\n", task.Type, task.Title, len(description))
+	if len(description) > subTaskPromptDescriptionLimit {
+		description = truncatePromptText(description, subTaskPromptDescriptionLimit)
+	}
+	if description != "" {
+		fmt.Fprintf(&sb, "Description:\n%s\n", description)
+	}
+
+	return sb.String()
+}
+
+// validatePointEstimates returns the estimates in the order of the positions, each position must be estimated once.
+func validatePointEstimates(estimates []responses.PointEstimate, positions []string) ([]responses.PointEstimate, error) {
+	byPosition := make(map[string]responses.PointEstimate, len(estimates))
+	for i, estimate := range estimates {
+		estimate.Justification = strings.TrimSpace(estimate.Justification)
+
+		if !slices.Contains(positions, estimate.Position) {
+			return nil, fmt.Errorf("estimate %d has the position %q, it must be one of %s", i, estimate.Position, strings.Join(positions, ", "))
+		} else if _, ok := byPosition[estimate.Position]; ok {
+			return nil, fmt.Errorf("the position %q is estimated more than once", estimate.Position)
+		} else if estimate.Point < 0 || estimate.Point > maxEstimatedPoint {
+			return nil, fmt.Errorf("estimate %d has %d points, it must be between 0 and %d", i, estimate.Point, maxEstimatedPoint)
+		} else if estimate.Justification == "" {
+			return nil, fmt.Errorf("estimate %d has no justification", i)
+		}
+
+		byPosition[estimate.Position] = estimate
+	}
+
+	ordered := make([]responses.PointEstimate, 0, len(positions))
+	for _, position := range positions {
+		estimate, ok := byPosition[position]
+		if !ok {
+			return nil, fmt.Errorf("the position %q is not estimated", position)
+		}
+		ordered = append(ordered, estimate)
+	}
+
+	return ordered, nil
+}
+
+// validateSimilarTaskRefs drops repeated references and keeps at most pointEstimationMaxSimilarTasks.
+func validateSimilarTaskRefs(taskRefs []string, exampleRefs []string) ([]string, error) {
+	unique := make([]string, 0, len(taskRefs))
+	for _, taskRef := range taskRefs {
+		if !slices.Contains(exampleRefs, taskRef) {
+			return nil, fmt.Errorf("%q is not the reference of an example", taskRef)
+		} else if !slices.Contains(unique, taskRef) {
+			unique = append(unique, taskRef)
+		}
+	}
+
+	if len(unique) == 0 {
+		return nil, errors.New("no similar tasks were given")
+	} else if len(unique) > pointEstimationMaxSimilarTasks {
+		unique = unique[:pointEstimationMaxSimilarTasks]
+	}
+
+	return unique, nil
+}
+
+func pointEstimateSchema(positions []string, exampleRefs []string) *repositories.LLMSchema {
+	return &repositories.LLMSchema{
+		Type:     repositories.LLMSchemaTypeObject,
+		Required: []string{"estimates", "similarTaskRefs"},
+		Properties: map[string]*repositories.LLMSchema{
+			"estimates": {
+				Type: repositories.LLMSchemaTypeArray,
+				Items: &repositories.LLMSchema{
+					Type:     repositories.LLMSchemaTypeObject,
+					Required: []string{"position", "point", "justification"},
+					Properties: map[string]*repositories.LLMSchema{
+						"position":      {Type: repositories.LLMSchemaTypeString, Enum: positions},
+						"point":         {Type: repositories.LLMSchemaTypeInteger},
+						"justification": {Type: repositories.LLMSchemaTypeString},
+					},
+				},
+			},
+			"similarTaskRefs": {
+				Type:  repositories.LLMSchemaTypeArray,
+				Items: &repositories.LLMSchema{Type: repositories.LLMSchemaTypeString, Enum: exampleRefs},
+			},
+		},
+	}
+}
+
+// descriptionFromText stores plain text the way the description editor does, as a list of BlockNote blocks.
+func descriptionFromText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", nil
+	}
+
+	blocks := make([]models.DescriptionBlock, 0)
+	for _, paragraph := range strings.Split(text, "\n") {
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+
+		blocks = append(blocks, models.DescriptionBlock{
+			ID:   uuid.NewString(),
+			Type: models.DescriptionBlockTypeParagraph,
+			Props: models.DescriptionBlockProps{
+				TextColor:       "default",
+				BackgroundColor: "default",
+				TextAlignment:   "left",
+			},
+			Content:  []models.DescriptionInlineContent{{Type: "text", Text: paragraph}},
+			Children: []models.DescriptionBlock{},
+		})
+	}
+
+	description, err := json.Marshal(blocks)
+	if err != nil {
+		return "", err
+	}
+
+	return string(description), nil
+}
+
+// descriptionToText returns the text of a description stored as BlockNote blocks, other descriptions are returned as is.
+func descriptionToText(description string) string {
+	var blocks []models.DescriptionBlock
+	if err := json.Unmarshal([]byte(description), &blocks); err != nil {
+		return strings.TrimSpace(description)
+	}
+
+	var sb strings.Builder
+	writeDescriptionText(&sb, blocks, 0)
+
+	return strings.TrimSpace(sb.String())
+}
+
+func writeDescriptionText(sb *strings.Builder, blocks []models.DescriptionBlock, depth int) {
+	for _, block := range blocks {
+		sb.WriteString(strings.Repeat("  ", depth))
+		switch block.Type {
+		case models.DescriptionBlockTypeHeading:
+			sb.WriteString(strings.Repeat("#", max(block.Props.Level, 1)) + " ")
+		case models.DescriptionBlockTypeBulletListItem, models.DescriptionBlockTypeNumberedListItem:
+			sb.WriteString("- ")
+		}
+		for _, content := range block.Content {
+			sb.WriteString(content.Text)
+		}
+		sb.WriteString("\n")
+
+		writeDescriptionText(sb, block.Children, depth+1)
+	}
+}
+
+// taskEmbeddingTextLimit is the number of characters of a task sent to the embedding model
+const taskEmbeddingTextLimit = 8000
+
+// embedTask stores the embedding of the title and description of the task.
+func (s *taskServiceImpl) embedTask(ctx context.Context, task *models.Task) (*models.TaskEmbedding, error) {
+	text := task.Title
+	if description := descriptionToText(task.Description); description != "" {
+		text += "\n\n" + description
+	}
+	if len(text) > taskEmbeddingTextLimit {
+		text = truncatePromptText(text, taskEmbeddingTextLimit)
+	}
+
+	embedding, err := s.llmRepo.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.taskEmbeddingRepo.Upsert(ctx, &repositories.UpsertTaskEmbeddingRequest{
+		TaskID:    task.ID,
+		ProjectID: task.ProjectID,
+		Provider:  embedding.Provider,
+		Model:     embedding.Model,
+		Vector:    embedding.Vector,
+	})
+}
+
+// embedTaskInBackground embeds the task after an update without holding up the response. The embedding
+// outlives the request, a failure only leaves the previous embedding until the next update.
+func (s *taskServiceImpl) embedTaskInBackground(ctx context.Context, task *models.Task) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.config.LLM.EmbeddingTimeout)
+
+	go func() {
+		defer cancel()
+
+		if _, err := s.embedTask(ctx, task); err != nil {
+			log.Printf("⚠️ Failed to embed task %s: %v", task.TaskRef, err)
+		}
+	}()
+}
+
+// findPossibleDuplicates compares the embedding with every embedding of the project, a brute-force search is fast
+// enough for the number of tasks in a project. Only open tasks other than the parent and children are returned.
+func (s *taskServiceImpl) findPossibleDuplicates(ctx context.Context, task *models.Task, embedding *models.TaskEmbedding) ([]responses.PossibleDuplicateTask, error) {
+	projectEmbeddings, err := s.taskEmbeddingRepo.FindByProjectIDAndModel(ctx, task.ProjectID, embedding.Provider, embedding.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		taskID     bson.ObjectID
+		similarity float64
+	}
+
+	candidates := make([]candidate, 0)
+	for _, projectEmbedding := range projectEmbeddings {
+		if projectEmbedding.TaskID == task.ID {
+			continue
+		}
+
+		similarity := cosineSimilarity(embedding.Vector, projectEmbedding.Vector)
+		if similarity >= s.config.LLM.DuplicateThreshold {
+			candidates = append(candidates, candidate{taskID: projectEmbedding.TaskID, similarity: similarity})
+		}
+	}
+
+	duplicates := make([]responses.PossibleDuplicateTask, 0)
+	if len(candidates) == 0 {
+		return duplicates, nil
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(b.similarity, a.similarity)
+	})
+
+	project, err := s.projectRepo.FindByProjectID(ctx, task.ProjectID)
+	if err != nil {
+		return nil, err
+	} else if project == nil {
+		return nil, fmt.Errorf("project not found: %s", task.ProjectID.Hex())
+	}
+
+	var doneStatuses []string
+	for _, workflow := range project.Workflows {
+		if workflow.IsDone {
+			doneStatuses = append(doneStatuses, workflow.Status)
+		}
+	}
+
+	candidateIDs := make([]bson.ObjectID, 0, len(candidates))
+	for _, c := range candidates {
+		candidateIDs = append(candidateIDs, c.taskID)
+	}
+
+	candidateTasks, err := s.taskRepo.FindByIDs(ctx, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tasksByID := make(map[bson.ObjectID]*models.Task, len(candidateTasks))
+	for _, candidateTask := range candidateTasks {
+		tasksByID[candidateTask.ID] = candidateTask
+	}
+
+	for _, c := range candidates {
+		if len(duplicates) == s.config.LLM.DuplicateLimit {
+			break
+		}
+
+		// The task may have been deleted after it was embedded
+		candidateTask, ok := tasksByID[c.taskID]
+		if !ok || slices.Contains(doneStatuses, candidateTask.Status) {
+			continue
+		}
+
+		// Sub-tasks naturally resemble their parent
+		if (task.ParentID != nil && *task.ParentID == candidateTask.ID) || (candidateTask.ParentID != nil && *candidateTask.ParentID == task.ID) {
+			continue
+		}
+
+		duplicates = append(duplicates, responses.PossibleDuplicateTask{
+			TaskRef:    candidateTask.TaskRef,
+			Title:      candidateTask.Title,
+			Type:       candidateTask.Type.String(),
+			Status:     candidateTask.Status,
+			Similarity: c.similarity,
+		})
+	}
+
+	return duplicates, nil
+}
+
+// cosineSimilarity returns 0 for vectors of different sizes or without direction.
+func cosineSimilarity(a []float32, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+const (
+	// taskSearchPromptSprints is the number of latest sprints listed to the model
+	taskSearchPromptSprints = 20
+	// taskSearchPromptEpics is the number of epics listed to the model
+	taskSearchPromptEpics = 100
+)
+
+const taskSearchSystemPrompt = `You translate task searches of a project management tool into a search filter.
+Answer with a JSON object with these fields, an empty list, false or "" means the search does not filter on it:
+"types": task types, "priorities": task priorities, "statuses": workflow statuses, "positions": positions of the assignees,
+"assigneeUserIds": ids of assigned members, "sprintIds": ids of sprints, "inBacklog": tasks without a sprint,
+"epicTaskRef": reference of the epic the tasks belong to, "withoutEpic": tasks without an epic,
+"isOverdue": tasks past their due date that are not done, "keyword": words to find in the title or reference.
+Only use values listed in the prompt. "me" is the member marked as the one searching, the current sprint is the sprint in progress.
+Only put words in "keyword" that no other field covers.`
+
+// taskSearchFilterOutput is the filter generated by the model, it is validated against taskSearchContext.
+type taskSearchFilterOutput struct {
+	Types           []string `json:"types"`
+	Priorities      []string `json:"priorities"`
+	Statuses        []string `json:"statuses"`
+	Positions       []string `json:"positions"`
+	AssigneeUserIDs []string `json:"assigneeUserIds"`
+	SprintIDs       []string `json:"sprintIds"`
+	InBacklog       bool     `json:"inBacklog"`
+	EpicTaskRef     string   `json:"epicTaskRef"`
+	WithoutEpic     bool     `json:"withoutEpic"`
+	IsOverdue       bool     `json:"isOverdue"`
+	Keyword         string   `json:"keyword"`
+}
+
+// taskSearchContext holds the values of a project a search filter may use.
+type taskSearchContext struct {
+	project  *models.Project
+	userID   bson.ObjectID
+	statuses []string
+	members  []models.User
+	sprints  []models.Sprint
+	epics    []*models.Task
+}
+
+func (s *taskServiceImpl) loadTaskSearchContext(ctx context.Context, project *models.Project, userID bson.ObjectID) (*taskSearchContext, error) {
+	projectMembers, err := s.projectMemberRepo.FindByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberUserIDs := make([]bson.ObjectID, 0, len(projectMembers))
+	for _, projectMember := range projectMembers {
+		if projectMember.RemovedAt == nil {
+			memberUserIDs = append(memberUserIDs, projectMember.UserID)
+		}
+	}
+
+	members, err := s.userRepo.FindByIDs(ctx, memberUserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	sprints, err := s.sprintRepo.FindByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Latest sprints first, they are the ones people search in
+	slices.SortFunc(sprints, func(a, b models.Sprint) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	epics, err := s.taskRepo.FindByProjectIDAndType(ctx, project.ID, models.TaskTypeEpic)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]string, 0, len(project.Workflows))
+	for _, workflow := range project.Workflows {
+		statuses = append(statuses, workflow.Status)
+	}
+
+	return &taskSearchContext{
+		project:  project,
+		userID:   userID,
+		statuses: statuses,
+		members:  members,
+		sprints:  sprints,
+		epics:    epics,
+	}, nil
+}
+
+func (c *taskSearchContext) buildPrompt(query string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Project: %s, today is %s.\n", c.project.Name, time.Now().Format("2006-01-02"))
+	fmt.Fprintf(&sb, "Types: %s\n", strings.Join(taskTypeValues(), ", "))
+	fmt.Fprintf(&sb, "Priorities: %s\n", strings.Join(taskPriorityValues(), ", "))
+	fmt.Fprintf(&sb, "Statuses: %s\n", strings.Join(c.statuses, ", "))
+	fmt.Fprintf(&sb, "Positions: %s\n", strings.Join(c.project.Positions, ", "))
+
+	sb.WriteString("\nMembers (id: name <email>):\n")
+	for _, member := range c.members {
+		fmt.Fprintf(&sb, "- %s: %s <%s>", member.ID.Hex(), member.DisplayName, member.Email)
+		if member.ID == c.userID {
+			sb.WriteString(" (me)")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(c.sprints) > 0 {
+		sb.WriteString("\nSprints (id: title, status):\n")
+		for i, sprint := range c.sprints {
+			if i == taskSearchPromptSprints {
+				break
+			}
+			fmt.Fprintf(&sb, "- %s: %s, %s\n", sprint.ID.Hex(), sprint.Title, sprint.Status)
+		}
+	}
+
+	if len(c.epics) > 0 {
+		sb.WriteString("\nEpics (reference: title):\n")
+		for i, epic := range c.epics {
+			if i == taskSearchPromptEpics {
+				break
+			}
+			fmt.Fprintf(&sb, "- %s: %s\n", epic.TaskRef, epic.Title)
+		}
+	}
+
+	fmt.Fprintf(&sb, "\nSearch: %q\n", query)
+
+	return sb.String()
+}
+
+func (c *taskSearchContext) validate(out *taskSearchFilterOutput) error {
+	checks := []struct {
+		field   string
+		values  []string
+		allowed []string
+	}{
+		{"types", out.Types, taskTypeValues()},
+		{"priorities", out.Priorities, taskPriorityValues()},
+		{"statuses", out.Statuses, c.statuses},
+		{"positions", out.Positions, c.project.Positions},
+		{"assigneeUserIds", out.AssigneeUserIDs, c.memberIDs()},
+		{"sprintIds", out.SprintIDs, c.sprintIDs()},
+	}
+
+	for _, check := range checks {
+		for _, value := range check.values {
+			if !slices.Contains(check.allowed, value) {
+				return fmt.Errorf("%s contains %q, it must be one of: %s", check.field, value, strings.Join(check.allowed, ", "))
+			}
+		}
+	}
+
+	if out.InBacklog && len(out.SprintIDs) > 0 {
+		return errors.New("inBacklog can not be combined with sprintIds")
+	}
+
+	if out.EpicTaskRef != "" {
+		if out.WithoutEpic {
+			return errors.New("epicTaskRef can not be combined with withoutEpic")
+		} else if c.findEpic(out.EpicTaskRef) == nil {
+			return fmt.Errorf("epicTaskRef %q is not an epic of the project", out.EpicTaskRef)
+		}
+	}
+
+	out.Keyword = strings.TrimSpace(out.Keyword)
+
+	return nil
+}
+
+// toFilter converts a validated output into the parameters of the task search.
+func (c *taskSearchContext) toFilter(out *taskSearchFilterOutput) *responses.SearchTaskFilter {
+	filter := &responses.SearchTaskFilter{
+		SprintIDs:  out.SprintIDs,
+		UserIDs:    out.AssigneeUserIDs,
+		Positions:  out.Positions,
+		Statuses:   out.Statuses,
+		Types:      out.Types,
+		Priorities: out.Priorities,
+	}
+
+	if out.InBacklog {
+		filter.IsTaskInBacklog = &out.InBacklog
+	}
+
+	if out.WithoutEpic {
+		withoutEpic := constant.SearchTaskParamsTaskWithNoEpicFilter
+		filter.EpicTaskID = &withoutEpic
+	} else if epic := c.findEpic(out.EpicTaskRef); epic != nil {
+		epicTaskID := epic.ID.Hex()
+		filter.EpicTaskID = &epicTaskID
+	}
+
+	if out.IsOverdue {
+		filter.IsOverdue = &out.IsOverdue
+	}
+
+	// The search keyword is a regular expression
+	if out.Keyword != "" {
+		keyword := regexp.QuoteMeta(out.Keyword)
+		filter.SearchKeyword = &keyword
+	}
+
+	return filter
+}
+
+func (c *taskSearchContext) schema() *repositories.LLMSchema {
+	list := func(values []string) *repositories.LLMSchema {
+		return &repositories.LLMSchema{
+			Type:  repositories.LLMSchemaTypeArray,
+			Items: &repositories.LLMSchema{Type: repositories.LLMSchemaTypeString, Enum: values},
+		}
+	}
+
+	return &repositories.LLMSchema{
+		Type: repositories.LLMSchemaTypeObject,
+		Required: []string{
+			"types", "priorities", "statuses", "positions", "assigneeUserIds", "sprintIds",
+			"inBacklog", "epicTaskRef", "withoutEpic", "isOverdue", "keyword",
+		},
+		Properties: map[string]*repositories.LLMSchema{
+			"types":           list(taskTypeValues()),
+			"priorities":      list(taskPriorityValues()),
+			"statuses":        list(c.statuses),
+			"positions":       list(c.project.Positions),
+			"assigneeUserIds": list(c.memberIDs()),
+			"sprintIds":       list(c.sprintIDs()),
+			"inBacklog":       {Type: repositories.LLMSchemaTypeBoolean},
+			"epicTaskRef":     {Type: repositories.LLMSchemaTypeString},
+			"withoutEpic":     {Type: repositories.LLMSchemaTypeBoolean},
+			"isOverdue":       {Type: repositories.LLMSchemaTypeBoolean},
+			"keyword":         {Type: repositories.LLMSchemaTypeString},
+		},
+	}
+}
+
+func (c *taskSearchContext) memberIDs() []string {
+	ids := make([]string, 0, len(c.members))
+	for _, member := range c.members {
+		ids = append(ids, member.ID.Hex())
+	}
+	return ids
+}
+
+func (c *taskSearchContext) sprintIDs() []string {
+	ids := make([]string, 0, len(c.sprints))
+	for _, sprint := range c.sprints {
+		ids = append(ids, sprint.ID.Hex())
+	}
+	return ids
+}
+
+func (c *taskSearchContext) findEpic(taskRef string) *models.Task {
+	for _, epic := range c.epics {
+		if epic.TaskRef == taskRef {
+			return epic
+		}
+	}
+	return nil
+}
+
+func taskTypeValues() []string {
+	return []string{
+		models.TaskTypeEpic.String(),
+		models.TaskTypeStory.String(),
+		models.TaskTypeTask.String(),
+		models.TaskTypeBug.String(),
+		models.TaskTypeSubTask.String(),
+	}
+}
+
+func taskPriorityValues() []string {
+	return []string{
+		models.TaskPriorityLow.String(),
+		models.TaskPriorityMedium.String(),
+		models.TaskPriorityHigh.String(),
+		models.TaskPriotityCritical.String(),
+	}
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+)
+
+func TestDescriptionBlocksFromMarkdown(t *testing.T) {
+	type wantBlock struct {
+		blockType models.DescriptionBlockType
+		level     int
+		content   []models.DescriptionInlineContent
+	}
+
+	text := func(text string) models.DescriptionInlineContent {
+		return models.DescriptionInlineContent{Type: "text", Text: text}
+	}
+
+	tests := []struct {
+		name     string
+		markdown string
+		want     []wantBlock
+	}{
+		{
+			name:     "paragraph",
+			markdown: "Fix the login page.",
+			want:     []wantBlock{{blockType: models.DescriptionBlockTypeParagraph, content: []models.DescriptionInlineContent{text("Fix the login page.")}}},
+		},
+		{
+			name:     "headings",
+			markdown: "# Summary\n## Steps\n#### Deep",
+			want: []wantBlock{
+				{blockType: models.DescriptionBlockTypeHeading, level: 1, content: []models.DescriptionInlineContent{text("Summary")}},
+				{blockType: models.DescriptionBlockTypeHeading, level: 2, content: []models.DescriptionInlineContent{text("Steps")}},
+				{blockType: models.DescriptionBlockTypeHeading, level: 3, content: []models.DescriptionInlineContent{text("Deep")}},
+			},
+		},
+		{
+			name:     "hash without space is a paragraph",
+			markdown: "#hashtag",
+			want:     []wantBlock{{blockType: models.DescriptionBlockTypeParagraph, content: []models.DescriptionInlineContent{text("#hashtag")}}},
+		},
+		{
+			name:     "lists",
+			markdown: "- first\n* second\n1. third\n12. fourth",
+			want: []wantBlock{
+				{blockType: models.DescriptionBlockTypeBulletListItem, content: []models.DescriptionInlineContent{text("first")}},
+				{blockType: models.DescriptionBlockTypeBulletListItem, content: []models.DescriptionInlineContent{text("second")}},
+				{blockType: models.DescriptionBlockTypeNumberedListItem, content: []models.DescriptionInlineContent{text("third")}},
+				{blockType: models.DescriptionBlockTypeNumberedListItem, content: []models.DescriptionInlineContent{text("fourth")}},
+			},
+		},
+		{
+			name:     "blank lines and code fences are skipped",
+			markdown: "```\n\n  First  \n\n```",
+			want:     []wantBlock{{blockType: models.DescriptionBlockTypeParagraph, content: []models.DescriptionInlineContent{text("First")}}},
+		},
+		{
+			name:     "inline styles",
+			markdown: "Use **bold** and `code`, keep * as text",
+			want: []wantBlock{{blockType: models.DescriptionBlockTypeParagraph, content: []models.DescriptionInlineContent{
+				text("Use "),
+				{Type: "text", Text: "bold", Styles: models.DescriptionTextStyles{Bold: true}},
+				text(" and "),
+				{Type: "text", Text: "code", Styles: models.DescriptionTextStyles{Code: true}},
+				text(", keep "),
+				text("*"),
+				text(" as text"),
+			}}},
+		},
+		{
+			name:     "empty",
+			markdown: "",
+			want:     []wantBlock{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := descriptionBlocksFromMarkdown(tt.markdown)
+			if len(blocks) != len(tt.want) {
+				t.Fatalf("descriptionBlocksFromMarkdown() returned %d blocks, want %d", len(blocks), len(tt.want))
+			}
+
+			for i, block := range blocks {
+				want := tt.want[i]
+				if block.Type != want.blockType || block.Props.Level != want.level {
+					t.Errorf("block %d is %s level %d, want %s level %d", i, block.Type, block.Props.Level, want.blockType, want.level)
+				}
+				if !reflect.DeepEqual(block.Content, want.content) {
+					t.Errorf("block %d content = %+v, want %+v", i, block.Content, want.content)
+				}
+				if block.ID == "" {
+					t.Errorf("block %d has no ID", i)
+				}
+			}
+
+			// The blocks must pass the same validation as the blocks generated as JSON
+			if err := normalizeDescriptionBlocks(blocks, 1); err != nil {
+				t.Errorf("normalizeDescriptionBlocks() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestNormalizeDescriptionBlocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		blocks  []models.DescriptionBlock
+		wantErr string
+		check   func(t *testing.T, blocks []models.DescriptionBlock)
+	}{
+		{
+			name:   "fills in defaults",
+			blocks: []models.DescriptionBlock{{ID: "model-id", Type: models.DescriptionBlockTypeHeading, Content: []models.DescriptionInlineContent{{Text: "Title"}}}},
+			check: func(t *testing.T, blocks []models.DescriptionBlock) {
+				block := blocks[0]
+				if block.ID == "" || block.ID == "model-id" {
+					t.Errorf("ID = %q, want a new ID", block.ID)
+				}
+				want := models.DescriptionBlockProps{TextColor: "default", BackgroundColor: "default", TextAlignment: "left", Level: 1}
+				if block.Props != want {
+					t.Errorf("props = %+v, want %+v", block.Props, want)
+				}
+				if block.Content[0].Type != "text" {
+					t.Errorf("content type = %q, want text", block.Content[0].Type)
+				}
+				if block.Children == nil {
+					t.Error("children are nil, want an empty slice")
+				}
+			},
+		},
+		{
+			name:   "drops the level of other blocks",
+			blocks: []models.DescriptionBlock{{Type: models.DescriptionBlockTypeParagraph, Props: models.DescriptionBlockProps{Level: 2}}},
+			check: func(t *testing.T, blocks []models.DescriptionBlock) {
+				if blocks[0].Props.Level != 0 {
+					t.Errorf("level = %d, want 0", blocks[0].Props.Level)
+				}
+				if blocks[0].Content == nil {
+					t.Error("content is nil, want an empty slice")
+				}
+			},
+		},
+		{
+			name: "nested children within the depth",
+			blocks: []models.DescriptionBlock{{
+				Type:     models.DescriptionBlockTypeBulletListItem,
+				Children: []models.DescriptionBlock{{Type: models.DescriptionBlockTypeBulletListItem}},
+			}},
+		},
+		{
+			name:    "unsupported type",
+			blocks:  []models.DescriptionBlock{{Type: "table"}},
+			wantErr: `unsupported type "table"`,
+		},
+		{
+			name:    "invalid heading level",
+			blocks:  []models.DescriptionBlock{{Type: models.DescriptionBlockTypeHeading, Props: models.DescriptionBlockProps{Level: 4}}},
+			wantErr: "invalid level 4",
+		},
+		{
+			name:    "invalid text color",
+			blocks:  []models.DescriptionBlock{{Type: models.DescriptionBlockTypeParagraph, Props: models.DescriptionBlockProps{TextColor: "#ff0000"}}},
+			wantErr: "invalid textColor",
+		},
+		{
+			name:    "invalid background color",
+			blocks:  []models.DescriptionBlock{{Type: models.DescriptionBlockTypeParagraph, Props: models.DescriptionBlockProps{BackgroundColor: "black"}}},
+			wantErr: "invalid backgroundColor",
+		},
+		{
+			name:    "invalid text alignment",
+			blocks:  []models.DescriptionBlock{{Type: models.DescriptionBlockTypeParagraph, Props: models.DescriptionBlockProps{TextAlignment: "middle"}}},
+			wantErr: "invalid textAlignment",
+		},
+		{
+			name:    "unsupported content type",
+			blocks:  []models.DescriptionBlock{{Type: models.DescriptionBlockTypeParagraph, Content: []models.DescriptionInlineContent{{Type: "link"}}}},
+			wantErr: `unsupported type "link"`,
+		},
+		{
+			name: "nested deeper than the limit",
+			blocks: []models.DescriptionBlock{{
+				Type: models.DescriptionBlockTypeBulletListItem,
+				Children: []models.DescriptionBlock{{
+					Type:     models.DescriptionBlockTypeBulletListItem,
+					Children: []models.DescriptionBlock{{Type: models.DescriptionBlockTypeBulletListItem}},
+				}},
+			}},
+			wantErr: "nested deeper than 2 levels",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := normalizeDescriptionBlocks(tt.blocks, 1)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("normalizeDescriptionBlocks() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("normalizeDescriptionBlocks() error = %v", err)
+			}
+			if tt.check != nil {
+				tt.check(t, tt.blocks)
+			}
+		})
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []float32
+		b    []float32
+		want float64
+	}{
+		{name: "same direction", a: []float32{1, 2, 3}, b: []float32{2, 4, 6}, want: 1},
+		{name: "opposite direction", a: []float32{1, 2, 3}, b: []float32{-1, -2, -3}, want: -1},
+		{name: "orthogonal", a: []float32{1, 0}, b: []float32{0, 1}, want: 0},
+		{name: "45 degrees", a: []float32{1, 0}, b: []float32{1, 1}, want: 1 / math.Sqrt2},
+		{name: "different sizes", a: []float32{1, 2}, b: []float32{1, 2, 3}, want: 0},
+		{name: "zero vector", a: []float32{0, 0}, b: []float32{1, 1}, want: 0},
+		{name: "empty", a: []float32{}, b: []float32{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("cosineSimilarity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
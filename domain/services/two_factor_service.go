@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/responses"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type TwoFactorService interface {
+	Enroll(ctx context.Context, userID string) (*responses.EnrollTwoFactorResponse, *errutils.Error)
+	Enable(ctx context.Context, req *requests.EnableTwoFactorRequest, userID string) (*responses.TwoFactorRecoveryCodesResponse, *errutils.Error)
+	Disable(ctx context.Context, req *requests.DisableTwoFactorRequest, userID string) (*responses.DisableTwoFactorResponse, *errutils.Error)
+	RegenerateRecoveryCodes(ctx context.Context, req *requests.RegenerateRecoveryCodesRequest, userID string) (*responses.TwoFactorRecoveryCodesResponse, *errutils.Error)
+}
+
+type twoFactorServiceImpl struct {
+	userRepo            repositories.UserRepository
+	workspaceRepo       repositories.WorkspaceRepository
+	workspaceMemberRepo repositories.WorkspaceMemberRepository
+}
+
+func NewTwoFactorService(
+	userRepo repositories.UserRepository,
+	workspaceRepo repositories.WorkspaceRepository,
+	workspaceMemberRepo repositories.WorkspaceMemberRepository,
+) TwoFactorService {
+	return &twoFactorServiceImpl{
+		userRepo:            userRepo,
+		workspaceRepo:       workspaceRepo,
+		workspaceMemberRepo: workspaceMemberRepo,
+	}
+}
+
+func (t *twoFactorServiceImpl) Enroll(ctx context.Context, userID string) (*responses.EnrollTwoFactorResponse, *errutils.Error) {
+	user, svcErr := t.findUser(ctx, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if user.IsTwoFactorEnabled() {
+		return nil, errutils.NewError(exceptions.ErrTwoFactorAlreadyEnabled, errutils.BadRequest)
+	}
+
+	// Starting the enrolment again replaces the pending secret
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	err = t.userRepo.SetTwoFactor(ctx, user.ID, &models.TwoFactor{
+		Secret:             secret,
+		RecoveryCodeHashes: []string{},
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.EnrollTwoFactorResponse{
+		Secret:          secret,
+		ProvisioningURI: buildTOTPProvisioningURI(secret, user.Email),
+	}, nil
+}
+
+func (t *twoFactorServiceImpl) Enable(ctx context.Context, req *requests.EnableTwoFactorRequest, userID string) (*responses.TwoFactorRecoveryCodesResponse, *errutils.Error) {
+	user, svcErr := t.findUser(ctx, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if user.IsTwoFactorEnabled() {
+		return nil, errutils.NewError(exceptions.ErrTwoFactorAlreadyEnabled, errutils.BadRequest)
+	} else if user.TwoFactor == nil {
+		return nil, errutils.NewError(exceptions.ErrTwoFactorNotEnrolled, errutils.BadRequest)
+	}
+
+	// Recovery codes can not be used here, the first code proves the authenticator app is set up
+	step, ok := matchTOTPStep(user.TwoFactor.Secret, req.Code, time.Now())
+	if !ok {
+		return nil, errutils.NewError(exceptions.ErrInvalidTwoFactorCode, errutils.BadRequest)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	enabledAt := time.Now()
+	err = t.userRepo.SetTwoFactor(ctx, user.ID, &models.TwoFactor{
+		Secret:             user.TwoFactor.Secret,
+		EnabledAt:          &enabledAt,
+		RecoveryCodeHashes: hashes,
+		LastUsedStep:       step,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.TwoFactorRecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (t *twoFactorServiceImpl) Disable(ctx context.Context, req *requests.DisableTwoFactorRequest, userID string) (*responses.DisableTwoFactorResponse, *errutils.Error) {
+	user, svcErr := t.findUser(ctx, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if !user.IsTwoFactorEnabled() {
+		return nil, errutils.NewError(exceptions.ErrTwoFactorNotEnabled, errutils.BadRequest)
+	}
+
+	if svcErr := t.checkNotRequiredByWorkspace(ctx, user.ID); svcErr != nil {
+		return nil, svcErr
+	}
+
+	if svcErr := verifyTwoFactorCode(ctx, t.userRepo, user, req.Code); svcErr != nil {
+		return nil, svcErr
+	}
+
+	err := t.userRepo.RemoveTwoFactor(ctx, user.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.DisableTwoFactorResponse{
+		Message: "Two-factor authentication disabled successfully",
+	}, nil
+}
+
+func (t *twoFactorServiceImpl) RegenerateRecoveryCodes(ctx context.Context, req *requests.RegenerateRecoveryCodesRequest, userID string) (*responses.TwoFactorRecoveryCodesResponse, *errutils.Error) {
+	user, svcErr := t.findUser(ctx, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if !user.IsTwoFactorEnabled() {
+		return nil, errutils.NewError(exceptions.ErrTwoFactorNotEnabled, errutils.BadRequest)
+	}
+
+	if svcErr := verifyTwoFactorCode(ctx, t.userRepo, user, req.Code); svcErr != nil {
+		return nil, svcErr
+	}
+
+	// Reload the user, verifying the code may have advanced the last used step
+	user, svcErr = t.findUser(ctx, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	user.TwoFactor.RecoveryCodeHashes = hashes
+	err = t.userRepo.SetTwoFactor(ctx, user.ID, user.TwoFactor)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.TwoFactorRecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (t *twoFactorServiceImpl) findUser(ctx context.Context, userID string) (*models.User, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	user, err := t.userRepo.FindByID(ctx, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.NotFound)
+	}
+
+	return user, nil
+}
+
+func (t *twoFactorServiceImpl) checkNotRequiredByWorkspace(ctx context.Context, userID bson.ObjectID) *errutils.Error {
+	members, err := t.workspaceMemberRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if len(members) == 0 {
+		return nil
+	}
+
+	workspaceIDs := make([]bson.ObjectID, 0, len(members))
+	for _, member := range members {
+		workspaceIDs = append(workspaceIDs, member.WorkspaceID)
+	}
+
+	workspaces, err := t.workspaceRepo.FindByWorkspaceIDs(ctx, workspaceIDs)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	for _, workspace := range workspaces {
+		if workspace.RequireTwoFactor {
+			return errutils.NewError(exceptions.ErrTwoFactorRequiredByWorkspace, errutils.BadRequest).WithFields(workspace.Name)
+		}
+	}
+
+	return nil
+}
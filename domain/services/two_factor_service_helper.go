@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	totpIssuer = "Task Nexus"
+	totpPeriod = 30
+	totpDigits = 6
+	// totpSkew is the number of steps accepted before and after the current one to allow for clock drift
+	totpSkew = 1
+
+	recoveryCodeCount          = 10
+	twoFactorChallengeTokenTTL = 5 * time.Minute
+	// twoFactorChallengeMaxFailures is the number of wrong codes after which the challenge is burned
+	twoFactorChallengeMaxFailures = 5
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func generateTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base32NoPadding.EncodeToString(b), nil
+}
+
+// buildTOTPProvisioningURI returns the otpauth URI that authenticator apps read from a QR code.
+func buildTOTPProvisioningURI(secret string, accountName string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", totpIssuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+
+	label := url.PathEscape(totpIssuer + ":" + accountName)
+
+	// Some authenticator apps show "+" literally, spaces are encoded as %20 instead
+	query := strings.ReplaceAll(params.Encode(), "+", "%20")
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, query)
+}
+
+// totpCode computes the RFC 6238 code of the secret for the time step.
+func totpCode(secret string, step int64) (string, error) {
+	key, err := base32NoPadding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%mod), nil
+}
+
+// matchTOTPStep returns the time step the code was generated for, or false when the code does not match.
+func matchTOTPStep(secret string, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	currentStep := now.Unix() / totpPeriod
+	for step := currentStep - totpSkew; step <= currentStep+totpSkew; step++ {
+		expected, err := totpCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// generateRecoveryCodes returns the plain codes shown to the user once and the hashes that are stored.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 6)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		raw := strings.ToLower(base32NoPadding.EncodeToString(b))
+		code := raw[:5] + "-" + raw[5:10]
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyTwoFactorCode accepts either a TOTP code or an unused recovery code of a user with two-factor enabled.
+// Both are consumed, so the same code can not be used twice.
+func verifyTwoFactorCode(ctx context.Context, userRepo repositories.UserRepository, user *models.User, code string) *errutils.Error {
+	if user.TwoFactor == nil {
+		return errutils.NewError(exceptions.ErrTwoFactorNotEnrolled, errutils.BadRequest)
+	}
+
+	if step, ok := matchTOTPStep(user.TwoFactor.Secret, code, time.Now()); ok {
+		used, err := userRepo.UseTwoFactorStep(ctx, user.ID, step)
+		if err != nil {
+			return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		} else if !used {
+			return errutils.NewError(exceptions.ErrInvalidTwoFactorCode, errutils.Unauthorized).WithDebugMessage("code has already been used")
+		}
+
+		return nil
+	}
+
+	used, err := userRepo.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code))
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if !used {
+		return errutils.NewError(exceptions.ErrInvalidTwoFactorCode, errutils.Unauthorized)
+	}
+
+	return nil
+}
+
+// checkWorkspaceTwoFactorRequirement rejects members without two-factor enabled when the workspace requires it.
+func checkWorkspaceTwoFactorRequirement(ctx context.Context, userRepo repositories.UserRepository, workspace *models.Workspace, userID bson.ObjectID) *errutils.Error {
+	if !workspace.RequireTwoFactor {
+		return nil
+	}
+
+	user, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return errutils.NewError(exceptions.ErrUserNotFound, errutils.NotFound)
+	} else if !user.IsTwoFactorEnabled() && !user.IsServiceAccount() {
+		// Service accounts only authenticate with access tokens, which are created by members who passed two-factor
+		return errutils.NewError(exceptions.ErrTwoFactorRequiredForWorkspace, errutils.Forbidden)
+	}
+
+	return nil
+}
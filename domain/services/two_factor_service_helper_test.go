@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// rfc6238Secret is the SHA1 secret of the RFC 6238 test vectors, "12345678901234567890" in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// fakeTwoFactorUserRepo keeps a single user in memory, it implements the two-factor methods like the Mongo repository.
+type fakeTwoFactorUserRepo struct {
+	repositories.UserRepository
+	user *models.User
+}
+
+func (r *fakeTwoFactorUserRepo) FindByID(ctx context.Context, userID bson.ObjectID) (*models.User, error) {
+	if r.user == nil || r.user.ID != userID {
+		return nil, nil
+	}
+
+	return r.user, nil
+}
+
+func (r *fakeTwoFactorUserRepo) UseTwoFactorStep(ctx context.Context, userID bson.ObjectID, step int64) (bool, error) {
+	if step <= r.user.TwoFactor.LastUsedStep {
+		return false, nil
+	}
+	r.user.TwoFactor.LastUsedStep = step
+
+	return true, nil
+}
+
+func (r *fakeTwoFactorUserRepo) UseRecoveryCode(ctx context.Context, userID bson.ObjectID, codeHash string) (bool, error) {
+	i := slices.Index(r.user.TwoFactor.RecoveryCodeHashes, codeHash)
+	if i < 0 {
+		return false, nil
+	}
+	r.user.TwoFactor.RecoveryCodeHashes = slices.Delete(r.user.TwoFactor.RecoveryCodeHashes, i, i+1)
+
+	return true, nil
+}
+
+// isServiceError reports whether svcErr was created from want, errutils keeps the message but not the error itself.
+func isServiceError(svcErr *errutils.Error, want error) bool {
+	if svcErr == nil || want == nil {
+		return svcErr == nil && want == nil
+	}
+
+	return svcErr.Message == want.Error()
+}
+
+func newTwoFactorUser(recoveryCodes ...string) *models.User {
+	enabledAt := time.Now()
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return &models.User{
+		ID: bson.NewObjectID(),
+		TwoFactor: &models.TwoFactor{
+			Secret:             rfc6238Secret,
+			EnabledAt:          &enabledAt,
+			RecoveryCodeHashes: hashes,
+		},
+	}
+}
+
+func mustTOTPCode(t *testing.T, at time.Time) string {
+	t.Helper()
+
+	code, err := totpCode(rfc6238Secret, at.Unix()/totpPeriod)
+	if err != nil {
+		t.Fatalf("totpCode: %v", err)
+	}
+
+	return code
+}
+
+func TestTOTPCode(t *testing.T) {
+	// The RFC 6238 SHA1 vectors, truncated to six digits
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+
+	for _, tt := range tests {
+		got, err := totpCode(rfc6238Secret, tt.unix/totpPeriod)
+		if err != nil {
+			t.Fatalf("totpCode(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("totpCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestMatchTOTPStep(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	currentStep := now.Unix() / totpPeriod
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", code: mustTOTPCode(t, now), wantStep: currentStep, wantOK: true},
+		{name: "previous step within skew", code: mustTOTPCode(t, now.Add(-totpPeriod*time.Second)), wantStep: currentStep - 1, wantOK: true},
+		{name: "next step within skew", code: mustTOTPCode(t, now.Add(totpPeriod*time.Second)), wantStep: currentStep + 1, wantOK: true},
+		{name: "surrounding spaces", code: " " + mustTOTPCode(t, now) + " ", wantStep: currentStep, wantOK: true},
+		{name: "step outside skew", code: mustTOTPCode(t, now.Add(-2*totpPeriod*time.Second)), wantOK: false},
+		{name: "wrong length", code: "12345", wantOK: false},
+		{name: "empty", code: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := matchTOTPStep(rfc6238Secret, tt.code, now)
+			if ok != tt.wantOK {
+				t.Fatalf("matchTOTPStep() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && step != tt.wantStep {
+				t.Errorf("matchTOTPStep() step = %d, want %d", step, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestVerifyTwoFactorCode(t *testing.T) {
+	const recoveryCode = "abcde-fghij"
+
+	tests := []struct {
+		name string
+		// codes are verified in order against the same user, only the last result is checked
+		codes   func(t *testing.T) []string
+		user    func() *models.User
+		wantErr error
+	}{
+		{
+			name:  "current TOTP code",
+			codes: func(t *testing.T) []string { return []string{mustTOTPCode(t, time.Now())} },
+			user:  func() *models.User { return newTwoFactorUser() },
+		},
+		{
+			name: "replayed TOTP code",
+			codes: func(t *testing.T) []string {
+				code := mustTOTPCode(t, time.Now())
+				return []string{code, code}
+			},
+			user:    func() *models.User { return newTwoFactorUser() },
+			wantErr: exceptions.ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "TOTP code of an earlier step than the last used one",
+			codes: func(t *testing.T) []string {
+				return []string{mustTOTPCode(t, time.Now()), mustTOTPCode(t, time.Now().Add(-totpPeriod*time.Second))}
+			},
+			user:    func() *models.User { return newTwoFactorUser() },
+			wantErr: exceptions.ErrInvalidTwoFactorCode,
+		},
+		{
+			name:  "recovery code",
+			codes: func(t *testing.T) []string { return []string{recoveryCode} },
+			user:  func() *models.User { return newTwoFactorUser(recoveryCode) },
+		},
+		{
+			name:  "recovery code with different formatting",
+			codes: func(t *testing.T) []string { return []string{"ABCDE FGHIJ"} },
+			user:  func() *models.User { return newTwoFactorUser(recoveryCode) },
+		},
+		{
+			name:    "reused recovery code",
+			codes:   func(t *testing.T) []string { return []string{recoveryCode, recoveryCode} },
+			user:    func() *models.User { return newTwoFactorUser(recoveryCode) },
+			wantErr: exceptions.ErrInvalidTwoFactorCode,
+		},
+		{
+			name:    "unknown code",
+			codes:   func(t *testing.T) []string { return []string{"zzzzz-zzzzz"} },
+			user:    func() *models.User { return newTwoFactorUser(recoveryCode) },
+			wantErr: exceptions.ErrInvalidTwoFactorCode,
+		},
+		{
+			name:    "not enrolled",
+			codes:   func(t *testing.T) []string { return []string{"123456"} },
+			user:    func() *models.User { return &models.User{ID: bson.NewObjectID()} },
+			wantErr: exceptions.ErrTwoFactorNotEnrolled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := tt.user()
+			userRepo := &fakeTwoFactorUserRepo{user: user}
+
+			var svcErr *errutils.Error
+			for _, code := range tt.codes(t) {
+				svcErr = verifyTwoFactorCode(context.Background(), userRepo, user, code)
+			}
+
+			if !isServiceError(svcErr, tt.wantErr) {
+				t.Errorf("verifyTwoFactorCode() error = %v, want %v", svcErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyTwoFactorCodeConsumesRecoveryCode(t *testing.T) {
+	user := newTwoFactorUser("aaaaa-bbbbb", "ccccc-ddddd")
+	userRepo := &fakeTwoFactorUserRepo{user: user}
+
+	if svcErr := verifyTwoFactorCode(context.Background(), userRepo, user, "aaaaa-bbbbb"); svcErr != nil {
+		t.Fatalf("verifyTwoFactorCode() error = %v", svcErr)
+	}
+
+	want := []string{hashRecoveryCode("ccccc-ddddd")}
+	if !slices.Equal(user.TwoFactor.RecoveryCodeHashes, want) {
+		t.Errorf("recovery code hashes = %v, want %v", user.TwoFactor.RecoveryCodeHashes, want)
+	}
+}
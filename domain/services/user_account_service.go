@@ -0,0 +1,364 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/responses"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	userDataExportFormatJSON = "json"
+	userDataExportFormatZIP  = "zip"
+
+	anonymizedUserName = "Anonymized User"
+)
+
+// UserAccountService manages the lifecycle of user accounts: deactivation by an admin,
+// anonymisation and the export of everything stored about a user.
+type UserAccountService interface {
+	Deactivate(ctx context.Context, req *requests.DeactivateUserRequest, adminID string) (*responses.UserAccountStatusResponse, *errutils.Error)
+	Reactivate(ctx context.Context, req *requests.ReactivateUserRequest, adminID string) (*responses.UserAccountStatusResponse, *errutils.Error)
+	Anonymize(ctx context.Context, req *requests.AnonymizeUserRequest, adminID string) (*responses.UserAccountStatusResponse, *errutils.Error)
+	// Export returns the data of req.UserID, or of the requester when it is empty. Only admins can export other users.
+	Export(ctx context.Context, req *requests.ExportUserDataRequest, requesterID string) (*responses.UserDataExportFile, *errutils.Error)
+}
+
+type userAccountServiceImpl struct {
+	config               *config.Config
+	userRepo             repositories.UserRepository
+	userSessionRepo      repositories.UserSessionCacheRepository
+	taskRepo             repositories.TaskRepository
+	taskCommentRepo      repositories.TaskCommentRepository
+	workspaceMemberRepo  repositories.WorkspaceMemberRepository
+	projectMemberRepo    repositories.ProjectMemberRepository
+	authAttemptAuditRepo repositories.AuthAttemptAuditRepository
+}
+
+func NewUserAccountService(
+	config *config.Config,
+	userRepo repositories.UserRepository,
+	userSessionRepo repositories.UserSessionCacheRepository,
+	taskRepo repositories.TaskRepository,
+	taskCommentRepo repositories.TaskCommentRepository,
+	workspaceMemberRepo repositories.WorkspaceMemberRepository,
+	projectMemberRepo repositories.ProjectMemberRepository,
+	authAttemptAuditRepo repositories.AuthAttemptAuditRepository,
+) UserAccountService {
+	return &userAccountServiceImpl{
+		config:               config,
+		userRepo:             userRepo,
+		userSessionRepo:      userSessionRepo,
+		taskRepo:             taskRepo,
+		taskCommentRepo:      taskCommentRepo,
+		workspaceMemberRepo:  workspaceMemberRepo,
+		projectMemberRepo:    projectMemberRepo,
+		authAttemptAuditRepo: authAttemptAuditRepo,
+	}
+}
+
+func (u *userAccountServiceImpl) Deactivate(ctx context.Context, req *requests.DeactivateUserRequest, adminID string) (*responses.UserAccountStatusResponse, *errutils.Error) {
+	admin, svcErr := findAdmin(ctx, u.userRepo, u.config.AdminEmails, adminID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	user, svcErr := u.findTargetUser(ctx, req.UserID, admin)
+	if svcErr != nil {
+		return nil, svcErr
+	} else if user.IsDeactivated() {
+		return nil, errutils.NewError(exceptions.ErrUserAlreadyDeactivated, errutils.BadRequest)
+	}
+
+	if svcErr := u.deactivate(ctx, user, admin.ID); svcErr != nil {
+		return nil, svcErr
+	}
+
+	return u.buildStatusResponse(ctx, user.ID, "User deactivated successfully")
+}
+
+func (u *userAccountServiceImpl) Reactivate(ctx context.Context, req *requests.ReactivateUserRequest, adminID string) (*responses.UserAccountStatusResponse, *errutils.Error) {
+	admin, svcErr := findAdmin(ctx, u.userRepo, u.config.AdminEmails, adminID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	user, svcErr := u.findTargetUser(ctx, req.UserID, admin)
+	if svcErr != nil {
+		return nil, svcErr
+	} else if !user.IsDeactivated() {
+		return nil, errutils.NewError(exceptions.ErrUserNotDeactivated, errutils.BadRequest)
+	} else if user.AnonymizedAt != nil {
+		// An anonymised user has no credentials left, it stays deactivated for good
+		return nil, errutils.NewError(exceptions.ErrUserAlreadyAnonymized, errutils.BadRequest)
+	}
+
+	err := u.userRepo.Reactivate(ctx, user.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return u.buildStatusResponse(ctx, user.ID, "User reactivated successfully")
+}
+
+func (u *userAccountServiceImpl) Anonymize(ctx context.Context, req *requests.AnonymizeUserRequest, adminID string) (*responses.UserAccountStatusResponse, *errutils.Error) {
+	admin, svcErr := findAdmin(ctx, u.userRepo, u.config.AdminEmails, adminID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	user, svcErr := u.findTargetUser(ctx, req.UserID, admin)
+	if svcErr != nil {
+		return nil, svcErr
+	} else if user.AnonymizedAt != nil {
+		return nil, errutils.NewError(exceptions.ErrUserAlreadyAnonymized, errutils.BadRequest)
+	}
+
+	if !user.IsDeactivated() {
+		if svcErr := u.deactivate(ctx, user, admin.ID); svcErr != nil {
+			return nil, svcErr
+		}
+	}
+
+	// The ID is kept, so tasks, comments and memberships still point to the user
+	email := fmt.Sprintf("anonymized-%s@anonymized.invalid", user.ID.Hex())
+	err := u.userRepo.Anonymize(ctx, &repositories.AnonymizeUserRequest{
+		UserID:            user.ID,
+		Email:             email,
+		FullName:          anonymizedUserName,
+		DisplayName:       anonymizedUserName,
+		DefaultProfileUrl: buildDefaultProfileUrl(anonymizedUserName),
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	// The auth attempt audit stores the email the user signed in with
+	err = u.authAttemptAuditRepo.ReplaceAccount(ctx, strings.ToLower(user.Email), email)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return u.buildStatusResponse(ctx, user.ID, "User anonymized successfully")
+}
+
+func (u *userAccountServiceImpl) Export(ctx context.Context, req *requests.ExportUserDataRequest, requesterID string) (*responses.UserDataExportFile, *errutils.Error) {
+	format := req.Format
+	if format == "" {
+		format = userDataExportFormatJSON
+	} else if format != userDataExportFormatJSON && format != userDataExportFormatZIP {
+		return nil, errutils.NewError(exceptions.ErrInvalidExportFormat, errutils.BadRequest).WithDebugMessage(format)
+	}
+
+	user, svcErr := u.findUser(ctx, requesterID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if req.UserID != "" && req.UserID != user.ID.Hex() {
+		if _, svcErr := findAdmin(ctx, u.userRepo, u.config.AdminEmails, requesterID); svcErr != nil {
+			return nil, svcErr
+		}
+
+		user, svcErr = u.findUser(ctx, req.UserID)
+		if svcErr != nil {
+			return nil, svcErr
+		}
+	}
+
+	export, svcErr := u.collectUserData(ctx, user)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	fileName := fmt.Sprintf("task-nexus-export-%s-%s", user.ID.Hex(), export.ExportedAt.Format("20060102150405"))
+
+	if format == userDataExportFormatJSON {
+		content, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
+
+		return &responses.UserDataExportFile{
+			FileName:    fileName + ".json",
+			ContentType: "application/json",
+			Content:     content,
+		}, nil
+	}
+
+	content, err := buildUserDataExportZIP(export)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.UserDataExportFile{
+		FileName:    fileName + ".zip",
+		ContentType: "application/zip",
+		Content:     content,
+	}, nil
+}
+
+// deactivate blocks the user from signing in and revokes the sessions it already has.
+func (u *userAccountServiceImpl) deactivate(ctx context.Context, user *models.User, adminID bson.ObjectID) *errutils.Error {
+	err := u.userRepo.Deactivate(ctx, user.ID, adminID)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	// Access tokens only live for accessTokenLifetime, so the marker can expire with them
+	err = u.userSessionRepo.SetRevokedAt(ctx, &repositories.SetUserSessionRevokedAtRequest{
+		UserID:    user.ID,
+		RevokedAt: time.Now(),
+		TTL:       accessTokenLifetime,
+	})
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return nil
+}
+
+func (u *userAccountServiceImpl) collectUserData(ctx context.Context, user *models.User) (*responses.UserDataExport, *errutils.Error) {
+	workspaceMemberships, err := u.workspaceMemberRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	projectMemberships, err := u.projectMemberRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	tasks, err := u.taskRepo.FindByCreatorOrAssigneeOrApprover(ctx, user.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	comments, err := u.taskCommentRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	profileUrl := user.DefaultProfileUrl
+	if user.UploadedProfileUrl != nil {
+		profileUrl = *user.UploadedProfileUrl
+	}
+
+	return &responses.UserDataExport{
+		ExportedAt: time.Now(),
+		Profile: responses.UserDataExportProfile{
+			ID:               user.ID.Hex(),
+			Email:            user.Email,
+			FullName:         user.FullName,
+			DisplayName:      user.DisplayName,
+			ProfileUrl:       profileUrl,
+			EmailVerifiedAt:  user.EmailVerifiedAt,
+			TwoFactorEnabled: user.IsTwoFactorEnabled(),
+			OIDCIdentities:   user.OIDCIdentities,
+			DeactivatedAt:    user.DeactivatedAt,
+			AnonymizedAt:     user.AnonymizedAt,
+			CreatedAt:        user.CreatedAt,
+			UpdatedAt:        user.UpdatedAt,
+		},
+		WorkspaceMemberships: workspaceMemberships,
+		ProjectMemberships:   projectMemberships,
+		Tasks:                tasks,
+		Comments:             comments,
+	}, nil
+}
+
+// buildUserDataExportZIP writes each part of the export to its own JSON file in the archive.
+func buildUserDataExportZIP(export *responses.UserDataExport) ([]byte, error) {
+	files := []struct {
+		name    string
+		content any
+	}{
+		{name: "profile.json", content: export.Profile},
+		{name: "workspace-memberships.json", content: export.WorkspaceMemberships},
+		{name: "project-memberships.json", content: export.ProjectMemberships},
+		{name: "tasks.json", content: export.Tasks},
+		{name: "comments.json", content: export.Comments},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	for _, file := range files {
+		w, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// findTargetUser returns the user an admin acts on, admins can not lock themselves out.
+func (u *userAccountServiceImpl) findTargetUser(ctx context.Context, userID string, admin *models.User) (*models.User, *errutils.Error) {
+	user, svcErr := u.findUser(ctx, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	} else if user.ID == admin.ID {
+		return nil, errutils.NewError(exceptions.ErrCannotDeactivateYourself, errutils.BadRequest)
+	}
+
+	return user, nil
+}
+
+func (u *userAccountServiceImpl) findUser(ctx context.Context, userID string) (*models.User, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	user, err := u.userRepo.FindByID(ctx, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.NotFound)
+	}
+
+	return user, nil
+}
+
+func (u *userAccountServiceImpl) buildStatusResponse(ctx context.Context, userID bson.ObjectID, message string) (*responses.UserAccountStatusResponse, *errutils.Error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.NotFound)
+	}
+
+	return &responses.UserAccountStatusResponse{
+		Message:       message,
+		UserID:        user.ID.Hex(),
+		DeactivatedAt: user.DeactivatedAt,
+		AnonymizedAt:  user.AnonymizedAt,
+	}, nil
+}
@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"math"
+	"net/url"
 	"strings"
 	"time"
 
@@ -21,19 +25,40 @@ import (
 
 type UserService interface {
 	Register(ctx context.Context, req *requests.RegisterRequest) (*responses.UserWithTokenResponse, *errutils.Error)
-	Login(ctx context.Context, req *requests.LoginRequest) (*responses.UserWithTokenResponse, *errutils.Error)
+	Login(ctx context.Context, req *requests.LoginRequest) (*responses.LoginResponse, *errutils.Error)
+	LoginWithTwoFactor(ctx context.Context, req *requests.LoginWithTwoFactorRequest) (*responses.UserWithTokenResponse, *errutils.Error)
 	FindUserByEmail(ctx context.Context, email string) (*responses.UserResponse, *errutils.Error)
 	Search(ctx context.Context, req *requests.SearchUserParams, searcherUserId string) (*responses.ListUserResponse, *errutils.Error)
 	SetupFirstUser(ctx context.Context, req *requests.RegisterRequest) (*responses.UserWithTokenResponse, *errutils.Error)
 	GetUserProfile(ctx context.Context, req *requests.GetUserProfileRequest) (*responses.UserResponse, *errutils.Error)
 	UpdateProfile(ctx context.Context, req *requests.UpdateUserProfileRequest, userID string) (*responses.UserResponse, *errutils.Error)
+	ChangePassword(ctx context.Context, req *requests.ChangePasswordRequest, userID string) (*responses.ChangePasswordResponse, *errutils.Error)
+	ForgotPassword(ctx context.Context, req *requests.ForgotPasswordRequest) (*responses.ForgotPasswordResponse, *errutils.Error)
+	ResetPassword(ctx context.Context, req *requests.ResetPasswordRequest) (*responses.ResetPasswordResponse, *errutils.Error)
+	VerifyEmail(ctx context.Context, req *requests.VerifyEmailRequest) (*responses.VerifyEmailResponse, *errutils.Error)
+	SendVerificationEmail(ctx context.Context, userID string) (*responses.SendVerificationEmailResponse, *errutils.Error)
+	GetOIDCAuthorizationURL(ctx context.Context) (*responses.OIDCAuthorizationURLResponse, *errutils.Error)
+	LoginWithOIDC(ctx context.Context, req *requests.OIDCLoginRequest) (*responses.UserWithTokenResponse, *errutils.Error)
 }
 
+const (
+	accessTokenLifetime       = 120 * time.Hour
+	passwordResetTokenTTL     = 30 * time.Minute
+	emailVerificationTokenTTL = 24 * time.Hour
+)
+
 type userServiceImpl struct {
-	config               *config.Config
-	userRepo             repositories.UserRepository
-	globalSettingRepo    repositories.GlobalSettingRepository
-	globalSettingService GlobalSettingService
+	config                 *config.Config
+	userRepo               repositories.UserRepository
+	globalSettingRepo      repositories.GlobalSettingRepository
+	globalSettingService   GlobalSettingService
+	invitationRepo         repositories.InvitationRepository
+	workspaceMemberRepo    repositories.WorkspaceMemberRepository
+	mailRepo               repositories.MailRepository
+	userSessionRepo        repositories.UserSessionCacheRepository
+	oidcRepo               repositories.OIDCRepository
+	oidcStateRepo          repositories.OIDCStateCacheRepository
+	twoFactorChallengeRepo repositories.TwoFactorChallengeCacheRepository
 }
 
 func NewUserService(
@@ -41,25 +66,41 @@ func NewUserService(
 	userRepo repositories.UserRepository,
 	globalSettingRepo repositories.GlobalSettingRepository,
 	globalSettingService GlobalSettingService,
+	invitationRepo repositories.InvitationRepository,
+	workspaceMemberRepo repositories.WorkspaceMemberRepository,
+	mailRepo repositories.MailRepository,
+	userSessionRepo repositories.UserSessionCacheRepository,
+	oidcRepo repositories.OIDCRepository,
+	oidcStateRepo repositories.OIDCStateCacheRepository,
+	twoFactorChallengeRepo repositories.TwoFactorChallengeCacheRepository,
 ) UserService {
 	return &userServiceImpl{
-		config:               config,
-		userRepo:             userRepo,
-		globalSettingRepo:    globalSettingRepo,
-		globalSettingService: globalSettingService,
+		config:                 config,
+		userRepo:               userRepo,
+		globalSettingRepo:      globalSettingRepo,
+		globalSettingService:   globalSettingService,
+		invitationRepo:         invitationRepo,
+		workspaceMemberRepo:    workspaceMemberRepo,
+		mailRepo:               mailRepo,
+		userSessionRepo:        userSessionRepo,
+		oidcRepo:               oidcRepo,
+		oidcStateRepo:          oidcStateRepo,
+		twoFactorChallengeRepo: twoFactorChallengeRepo,
 	}
 }
 
 func (u *userServiceImpl) generateJWT(user *models.User, expireAt time.Time) (string, *errutils.Error) {
+	issuedAt := time.Now()
 	claims := models.UserCustomClaims{
-		ID:          user.ID.Hex(),
-		FullName:    user.FullName,
-		DisplayName: user.DisplayName,
-		Email:       user.Email,
+		ID:            user.ID.Hex(),
+		FullName:      user.FullName,
+		DisplayName:   user.DisplayName,
+		Email:         user.Email,
+		IssuedAtMilli: issuedAt.UnixMilli(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   user.ID.Hex(),
 			ExpiresAt: jwt.NewNumericDate(expireAt),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
 		},
 	}
 
@@ -84,6 +125,16 @@ func (u *userServiceImpl) Register(ctx context.Context, req *requests.RegisterRe
 		return nil, errutils.NewError(exceptions.ErrUserAlreadyExists, errutils.BadRequest)
 	}
 
+	// Validate the invitation before creating the user, so an invalid token does not leave a half registered account
+	var invitation *models.Invitation
+	if req.InvitationToken != "" {
+		var svcErr *errutils.Error
+		invitation, svcErr = u.findInvitationByToken(ctx, req.InvitationToken, req.Email)
+		if svcErr != nil {
+			return nil, svcErr
+		}
+	}
+
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -93,13 +144,7 @@ func (u *userServiceImpl) Register(ctx context.Context, req *requests.RegisterRe
 
 	// Generate profile url
 	fullName := strings.Trim(req.FullName, " ")
-	nameParts := strings.Split(fullName, " ")
-	var defaultProfileUrl = "https://ui-avatars.com/api/?name="
-	if len(nameParts) == 1 {
-		defaultProfileUrl += nameParts[0]
-	} else {
-		defaultProfileUrl += nameParts[0] + "+" + nameParts[1]
-	}
+	defaultProfileUrl := buildDefaultProfileUrl(fullName)
 
 	createdUser, err := u.userRepo.Create(ctx, &repositories.CreateUserRequest{
 		Email:             req.Email,
@@ -112,8 +157,33 @@ func (u *userServiceImpl) Register(ctx context.Context, req *requests.RegisterRe
 		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalError)
 	}
 
+	// The account exists from here on, so later failures are reported as a warning instead of an error
+	var warning string
+	if invitation != nil {
+		if svcErr := u.joinWorkspaceByInvitation(ctx, invitation, createdUser.ID); svcErr != nil {
+			log.Printf("❌ Failed to accept invitation %s for %s: %v", invitation.ID.Hex(), createdUser.Email, svcErr)
+			warning = "Account created, but the invitation could not be accepted. Please ask for a new invitation"
+		} else {
+			// The invitation link was delivered to this address, so it is already verified
+			verifiedAt := time.Now()
+			err = u.userRepo.UpdateEmailVerifiedAt(ctx, createdUser.ID, verifiedAt)
+			if err != nil {
+				log.Printf("❌ Failed to mark email of %s as verified: %v", createdUser.Email, err)
+			} else {
+				createdUser.EmailVerifiedAt = &verifiedAt
+			}
+		}
+	}
+
+	if createdUser.EmailVerifiedAt == nil {
+		if svcErr := u.sendVerificationMail(ctx, createdUser); svcErr != nil {
+			// The account is usable without a verified email, the user can request another verification email later
+			log.Printf("❌ Failed to send verification email to %s: %v", createdUser.Email, svcErr)
+		}
+	}
+
 	// Generate JWT token
-	expireAt := time.Now().Add(time.Hour * 120)
+	expireAt := time.Now().Add(accessTokenLifetime)
 
 	token, tokenErr := u.generateJWT(createdUser, expireAt)
 	if tokenErr != nil {
@@ -122,21 +192,24 @@ func (u *userServiceImpl) Register(ctx context.Context, req *requests.RegisterRe
 
 	res := &responses.UserWithTokenResponse{
 		UserResponse: responses.UserResponse{
-			ID:          createdUser.ID.Hex(),
-			Email:       createdUser.Email,
-			FullName:    createdUser.FullName,
-			DisplayName: createdUser.DisplayName,
-			ProfileUrl:  createdUser.DefaultProfileUrl,
-			CreatedAt:   createdUser.CreatedAt,
-			UpdatedAt:   createdUser.UpdatedAt,
+			ID:               createdUser.ID.Hex(),
+			Email:            createdUser.Email,
+			FullName:         createdUser.FullName,
+			DisplayName:      createdUser.DisplayName,
+			ProfileUrl:       createdUser.DefaultProfileUrl,
+			CreatedAt:        createdUser.CreatedAt,
+			UpdatedAt:        createdUser.UpdatedAt,
+			EmailVerified:    createdUser.EmailVerifiedAt != nil,
+			TwoFactorEnabled: createdUser.IsTwoFactorEnabled(),
 		},
 		Token:         token,
 		TokenExpireAt: expireAt,
+		Warning:       warning,
 	}
 	return res, nil
 }
 
-func (u *userServiceImpl) Login(ctx context.Context, req *requests.LoginRequest) (*responses.UserWithTokenResponse, *errutils.Error) {
+func (u *userServiceImpl) Login(ctx context.Context, req *requests.LoginRequest) (*responses.LoginResponse, *errutils.Error) {
 	// Find user by email
 	user, err := u.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
@@ -153,8 +226,124 @@ func (u *userServiceImpl) Login(ctx context.Context, req *requests.LoginRequest)
 		return nil, errutils.NewError(exceptions.ErrInvalidCredentials, errutils.Unauthorized)
 	}
 
+	// Only reveal the deactivation to someone who knows the password
+	if user.IsDeactivated() {
+		return nil, errutils.NewError(exceptions.ErrUserDeactivated, errutils.Forbidden)
+	}
+
+	// With two-factor enabled the access token is only issued by LoginWithTwoFactor
+	if user.IsTwoFactorEnabled() {
+		challenge, svcErr := u.createTwoFactorChallenge(ctx, user)
+		if svcErr != nil {
+			return nil, svcErr
+		}
+
+		return &responses.LoginResponse{
+			TwoFactorChallenge: challenge,
+		}, nil
+	}
+
+	res, svcErr := u.issueAccessToken(user)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	return &responses.LoginResponse{
+		UserWithTokenResponse: res,
+	}, nil
+}
+
+func (u *userServiceImpl) LoginWithTwoFactor(ctx context.Context, req *requests.LoginWithTwoFactorRequest) (*responses.UserWithTokenResponse, *errutils.Error) {
+	claims := &models.TwoFactorChallengeTokenClaims{}
+	if err := parseToken(u.config.JWT.TwoFactorChallengeSecret, req.ChallengeToken, claims); err != nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidTwoFactorChallengeToken, errutils.Unauthorized).WithDebugMessage(err.Error())
+	}
+
+	bsonUserID, err := bson.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidTwoFactorChallengeToken, errutils.Unauthorized).WithDebugMessage(err.Error())
+	}
+
+	// The challenge is gone once it has been completed or has failed too often
+	exists, err := u.twoFactorChallengeRepo.Exists(ctx, claims.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if claims.ID == "" || !exists {
+		return nil, errutils.NewError(exceptions.ErrInvalidTwoFactorChallengeToken, errutils.Unauthorized)
+	}
+
+	user, err := u.userRepo.FindByID(ctx, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil || !user.IsTwoFactorEnabled() {
+		return nil, errutils.NewError(exceptions.ErrInvalidTwoFactorChallengeToken, errutils.Unauthorized)
+	} else if user.IsDeactivated() {
+		return nil, errutils.NewError(exceptions.ErrUserDeactivated, errutils.Forbidden)
+	}
+
+	if svcErr := verifyTwoFactorCode(ctx, u.userRepo, user, req.Code); svcErr != nil {
+		// Only a wrong code is unauthorized, other failures do not count against the challenge
+		if svcErr.Status == errutils.Unauthorized {
+			active, err := u.twoFactorChallengeRepo.RecordFailure(ctx, claims.ID, twoFactorChallengeMaxFailures)
+			if err != nil {
+				return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+			} else if !active {
+				return nil, errutils.NewError(exceptions.ErrInvalidTwoFactorChallengeToken, errutils.Unauthorized).WithDebugMessage("too many wrong codes, the challenge has been burned")
+			}
+		}
+		return nil, svcErr
+	}
+
+	// A challenge completes a single login, consuming it also settles concurrent attempts with the same challenge
+	consumed, err := u.twoFactorChallengeRepo.Consume(ctx, claims.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if !consumed {
+		return nil, errutils.NewError(exceptions.ErrInvalidTwoFactorChallengeToken, errutils.Unauthorized)
+	}
+
+	return u.issueAccessToken(user)
+}
+
+func (u *userServiceImpl) createTwoFactorChallenge(ctx context.Context, user *models.User) (*responses.TwoFactorChallengeResponse, *errutils.Error) {
+	expireAt := time.Now().Add(twoFactorChallengeTokenTTL)
+
+	challengeID, err := randomToken()
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	err = u.twoFactorChallengeRepo.Create(ctx, &repositories.CreateTwoFactorChallengeRequest{
+		ChallengeID: challengeID,
+		TTL:         twoFactorChallengeTokenTTL,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	token, err := signToken(u.config.JWT.TwoFactorChallengeSecret, models.TwoFactorChallengeTokenClaims{
+		UserID: user.ID.Hex(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        challengeID,
+			Subject:   user.ID.Hex(),
+			ExpiresAt: jwt.NewNumericDate(expireAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.TwoFactorChallengeResponse{
+		ChallengeToken: token,
+		ExpireAt:       expireAt,
+	}, nil
+}
+
+// issueAccessToken returns the user together with a new access token.
+func (u *userServiceImpl) issueAccessToken(user *models.User) (*responses.UserWithTokenResponse, *errutils.Error) {
 	// Generate JWT token
-	expireAt := time.Now().Add(time.Hour * 120)
+	expireAt := time.Now().Add(accessTokenLifetime)
 
 	token, tokenErr := u.generateJWT(user, expireAt)
 	if tokenErr != nil {
@@ -163,18 +352,20 @@ func (u *userServiceImpl) Login(ctx context.Context, req *requests.LoginRequest)
 
 	var profileUrl = user.DefaultProfileUrl
 	if user.UploadedProfileUrl != nil {
-		user.DefaultProfileUrl = *user.UploadedProfileUrl
+		profileUrl = *user.UploadedProfileUrl
 	}
 
 	res := &responses.UserWithTokenResponse{
 		UserResponse: responses.UserResponse{
-			ID:          user.ID.Hex(),
-			Email:       user.Email,
-			FullName:    user.FullName,
-			ProfileUrl:  profileUrl,
-			DisplayName: user.DisplayName,
-			CreatedAt:   user.CreatedAt,
-			UpdatedAt:   user.UpdatedAt,
+			ID:               user.ID.Hex(),
+			Email:            user.Email,
+			FullName:         user.FullName,
+			ProfileUrl:       profileUrl,
+			DisplayName:      user.DisplayName,
+			CreatedAt:        user.CreatedAt,
+			UpdatedAt:        user.UpdatedAt,
+			EmailVerified:    user.EmailVerifiedAt != nil,
+			TwoFactorEnabled: user.IsTwoFactorEnabled(),
 		},
 		Token:         token,
 		TokenExpireAt: expireAt,
@@ -198,13 +389,15 @@ func (u *userServiceImpl) FindUserByEmail(ctx context.Context, email string) (*r
 	}
 
 	res := &responses.UserResponse{
-		ID:          user.ID.Hex(),
-		Email:       user.Email,
-		FullName:    user.FullName,
-		DisplayName: user.DisplayName,
-		ProfileUrl:  profileUrl,
-		CreatedAt:   user.CreatedAt,
-		UpdatedAt:   user.UpdatedAt,
+		ID:               user.ID.Hex(),
+		Email:            user.Email,
+		FullName:         user.FullName,
+		DisplayName:      user.DisplayName,
+		ProfileUrl:       profileUrl,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		TwoFactorEnabled: user.IsTwoFactorEnabled(),
 	}
 
 	return res, nil
@@ -266,13 +459,15 @@ func (u *userServiceImpl) Search(ctx context.Context, req *requests.SearchUserPa
 		}
 
 		res.Users = append(res.Users, responses.UserResponse{
-			ID:          user.ID.Hex(),
-			Email:       user.Email,
-			FullName:    user.FullName,
-			DisplayName: user.DisplayName,
-			ProfileUrl:  profileUrl,
-			CreatedAt:   user.CreatedAt,
-			UpdatedAt:   user.UpdatedAt,
+			ID:               user.ID.Hex(),
+			Email:            user.Email,
+			FullName:         user.FullName,
+			DisplayName:      user.DisplayName,
+			ProfileUrl:       profileUrl,
+			CreatedAt:        user.CreatedAt,
+			UpdatedAt:        user.UpdatedAt,
+			EmailVerified:    user.EmailVerifiedAt != nil,
+			TwoFactorEnabled: user.IsTwoFactorEnabled(),
 		})
 	}
 
@@ -327,13 +522,15 @@ func (u *userServiceImpl) GetUserProfile(ctx context.Context, req *requests.GetU
 	}
 
 	return &responses.UserResponse{
-		ID:          user.ID.Hex(),
-		Email:       user.Email,
-		FullName:    user.FullName,
-		DisplayName: user.DisplayName,
-		ProfileUrl:  profileUrl,
-		CreatedAt:   user.CreatedAt,
-		UpdatedAt:   user.UpdatedAt,
+		ID:               user.ID.Hex(),
+		Email:            user.Email,
+		FullName:         user.FullName,
+		DisplayName:      user.DisplayName,
+		ProfileUrl:       profileUrl,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		TwoFactorEnabled: user.IsTwoFactorEnabled(),
 	}, nil
 }
 
@@ -380,12 +577,428 @@ func (u *userServiceImpl) UpdateProfile(ctx context.Context, req *requests.Updat
 	}
 
 	return &responses.UserResponse{
-		ID:          updatedUser.ID.Hex(),
-		Email:       updatedUser.Email,
-		FullName:    updatedUser.FullName,
-		DisplayName: updatedUser.DisplayName,
-		ProfileUrl:  profileUrl,
-		CreatedAt:   updatedUser.CreatedAt,
-		UpdatedAt:   updatedUser.UpdatedAt,
+		ID:               updatedUser.ID.Hex(),
+		Email:            updatedUser.Email,
+		FullName:         updatedUser.FullName,
+		DisplayName:      updatedUser.DisplayName,
+		ProfileUrl:       profileUrl,
+		CreatedAt:        updatedUser.CreatedAt,
+		UpdatedAt:        updatedUser.UpdatedAt,
+		EmailVerified:    updatedUser.EmailVerifiedAt != nil,
+		TwoFactorEnabled: updatedUser.IsTwoFactorEnabled(),
+	}, nil
+}
+
+func (u *userServiceImpl) findInvitationByToken(ctx context.Context, token string, email string) (*models.Invitation, *errutils.Error) {
+	claims, err := parseInvitationToken(u.config.JWT.InvitationTokenSecret, token)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, errutils.NewError(exceptions.ErrInvitationExpired, errutils.BadRequest)
+	} else if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidInvitationToken, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	if !strings.EqualFold(claims.Email, strings.TrimSpace(email)) {
+		return nil, errutils.NewError(exceptions.ErrInvitationEmailMismatch, errutils.BadRequest)
+	}
+
+	bsonInvitationID, err := bson.ObjectIDFromHex(claims.InvitationID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidInvitationToken, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	invitation, err := u.invitationRepo.FindByID(ctx, bsonInvitationID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if invitation == nil || invitation.InviteeEmail == nil || !strings.EqualFold(*invitation.InviteeEmail, claims.Email) {
+		return nil, errutils.NewError(exceptions.ErrInvalidInvitationToken, errutils.BadRequest)
+	} else if isInvitationExpired(invitation) {
+		return nil, errutils.NewError(exceptions.ErrInvitationExpired, errutils.BadRequest)
+	} else if invitation.Status != models.InvitationStatusPending {
+		return nil, errutils.NewError(exceptions.ErrInvitationAlreadyResponded, errutils.BadRequest)
+	}
+
+	return invitation, nil
+}
+
+func (u *userServiceImpl) joinWorkspaceByInvitation(ctx context.Context, invitation *models.Invitation, userID bson.ObjectID) *errutils.Error {
+	// Accepting only succeeds while the invitation is pending, which makes the token single-use
+	accepted, err := u.invitationRepo.AcceptPendingByID(ctx, invitation.ID, userID)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if !accepted {
+		return errutils.NewError(exceptions.ErrInvitationAlreadyResponded, errutils.BadRequest)
+	}
+
+	_, err = u.workspaceMemberRepo.Create(ctx, &repositories.CreateWorkspaceMemberRequest{
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      userID,
+		Role:        invitationRoleToWorkspaceMemberRole(invitation.Role),
+	})
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return nil
+}
+
+func (u *userServiceImpl) ChangePassword(ctx context.Context, req *requests.ChangePasswordRequest, userID string) (*responses.ChangePasswordResponse, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	user, err := u.userRepo.FindByID(ctx, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.NotFound).WithDebugMessage("user not found")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword))
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidCurrentPassword, errutils.BadRequest)
+	}
+
+	if svcErr := u.updatePassword(ctx, user.ID, req.NewPassword); svcErr != nil {
+		return nil, svcErr
+	}
+
+	// Every other session is revoked, the caller keeps working with the new token
+	expireAt := time.Now().Add(accessTokenLifetime)
+
+	token, tokenErr := u.generateJWT(user, expireAt)
+	if tokenErr != nil {
+		return nil, tokenErr
+	}
+
+	return &responses.ChangePasswordResponse{
+		Message:       "Password changed successfully",
+		Token:         token,
+		TokenExpireAt: expireAt,
+	}, nil
+}
+
+func (u *userServiceImpl) ForgotPassword(ctx context.Context, req *requests.ForgotPasswordRequest) (*responses.ForgotPasswordResponse, *errutils.Error) {
+	// The response is the same whether or not the email is registered, so it cannot be used to look up accounts
+	res := &responses.ForgotPasswordResponse{
+		Message: "If the email is registered, a password reset link has been sent",
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil || user.IsDeactivated() {
+		return res, nil
+	}
+
+	expireAt := time.Now().Add(passwordResetTokenTTL)
+
+	token, err := signToken(u.config.JWT.PasswordResetTokenSecret, models.PasswordResetTokenClaims{
+		UserID:              user.ID.Hex(),
+		PasswordFingerprint: passwordFingerprint(user.PasswordHash),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.Hex(),
+			ExpiresAt: jwt.NewNumericDate(expireAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	resetURL := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(u.config.FrontendURL, "/"), url.QueryEscape(token))
+
+	err = u.mailRepo.Send(ctx, &repositories.SendMailRequest{
+		To:      []string{user.Email},
+		Subject: "Reset your Task Nexus password",
+		Body:    buildPasswordResetMailBody(user.FullName, resetURL, expireAt.Format(constant.TimeFormat)),
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrSendPasswordResetMailFailed, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return res, nil
+}
+
+func (u *userServiceImpl) ResetPassword(ctx context.Context, req *requests.ResetPasswordRequest) (*responses.ResetPasswordResponse, *errutils.Error) {
+	claims := &models.PasswordResetTokenClaims{}
+	if err := parseToken(u.config.JWT.PasswordResetTokenSecret, req.Token, claims); err != nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidPasswordResetToken, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonUserID, err := bson.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidPasswordResetToken, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	user, err := u.userRepo.FindByID(ctx, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidPasswordResetToken, errutils.BadRequest)
+	}
+
+	// A token issued before the last password change no longer matches, which makes it single-use
+	if claims.PasswordFingerprint != passwordFingerprint(user.PasswordHash) {
+		return nil, errutils.NewError(exceptions.ErrInvalidPasswordResetToken, errutils.BadRequest)
+	}
+
+	if user.IsDeactivated() {
+		return nil, errutils.NewError(exceptions.ErrUserDeactivated, errutils.Forbidden)
+	}
+
+	if svcErr := u.updatePassword(ctx, user.ID, req.NewPassword); svcErr != nil {
+		return nil, svcErr
+	}
+
+	return &responses.ResetPasswordResponse{
+		Message: "Password reset successfully",
+	}, nil
+}
+
+func (u *userServiceImpl) VerifyEmail(ctx context.Context, req *requests.VerifyEmailRequest) (*responses.VerifyEmailResponse, *errutils.Error) {
+	claims := &models.EmailVerificationTokenClaims{}
+	if err := parseToken(u.config.JWT.EmailVerificationTokenSecret, req.Token, claims); err != nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidEmailVerificationToken, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonUserID, err := bson.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidEmailVerificationToken, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	user, err := u.userRepo.FindByID(ctx, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil || user.Email != claims.Email {
+		return nil, errutils.NewError(exceptions.ErrInvalidEmailVerificationToken, errutils.BadRequest)
+	} else if user.EmailVerifiedAt != nil {
+		return nil, errutils.NewError(exceptions.ErrEmailAlreadyVerified, errutils.BadRequest)
+	}
+
+	err = u.userRepo.UpdateEmailVerifiedAt(ctx, user.ID, time.Now())
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.VerifyEmailResponse{
+		Message: "Email verified successfully",
+	}, nil
+}
+
+func (u *userServiceImpl) SendVerificationEmail(ctx context.Context, userID string) (*responses.SendVerificationEmailResponse, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	user, err := u.userRepo.FindByID(ctx, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.NotFound).WithDebugMessage("user not found")
+	} else if user.EmailVerifiedAt != nil {
+		return nil, errutils.NewError(exceptions.ErrEmailAlreadyVerified, errutils.BadRequest)
+	}
+
+	if svcErr := u.sendVerificationMail(ctx, user); svcErr != nil {
+		return nil, svcErr
+	}
+
+	return &responses.SendVerificationEmailResponse{
+		Message: "Verification email sent successfully",
+	}, nil
+}
+
+// updatePassword stores the new password and revokes every session issued before now.
+func (u *userServiceImpl) updatePassword(ctx context.Context, userID bson.ObjectID, newPassword string) *errutils.Error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	err = u.userRepo.UpdatePassword(ctx, userID, string(hashedPassword))
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	// Access tokens only live for accessTokenLifetime, so the marker can expire with them
+	err = u.userSessionRepo.SetRevokedAt(ctx, &repositories.SetUserSessionRevokedAtRequest{
+		UserID:    userID,
+		RevokedAt: time.Now(),
+		TTL:       accessTokenLifetime,
+	})
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return nil
+}
+
+func (u *userServiceImpl) sendVerificationMail(ctx context.Context, user *models.User) *errutils.Error {
+	expireAt := time.Now().Add(emailVerificationTokenTTL)
+
+	token, err := signToken(u.config.JWT.EmailVerificationTokenSecret, models.EmailVerificationTokenClaims{
+		UserID: user.ID.Hex(),
+		Email:  user.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.Hex(),
+			ExpiresAt: jwt.NewNumericDate(expireAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	})
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	verifyURL := fmt.Sprintf("%s/verify-email?token=%s", strings.TrimRight(u.config.FrontendURL, "/"), url.QueryEscape(token))
+
+	err = u.mailRepo.Send(ctx, &repositories.SendMailRequest{
+		To:      []string{user.Email},
+		Subject: "Verify your Task Nexus email",
+		Body:    buildEmailVerificationMailBody(user.FullName, verifyURL, expireAt.Format(constant.TimeFormat)),
+	})
+	if err != nil {
+		return errutils.NewError(exceptions.ErrSendEmailVerificationMailFailed, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return nil
+}
+
+func (u *userServiceImpl) GetOIDCAuthorizationURL(ctx context.Context) (*responses.OIDCAuthorizationURLResponse, *errutils.Error) {
+	if !u.oidcRepo.IsEnabled() {
+		return nil, errutils.NewError(exceptions.ErrOIDCNotConfigured, errutils.BadRequest)
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	nonce, err := randomToken()
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	authorizationURL, err := u.oidcRepo.AuthCodeURL(ctx, state, nonce)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrOIDCLoginFailed, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	err = u.oidcStateRepo.Set(ctx, &repositories.SetOIDCStateRequest{
+		State: state,
+		Nonce: nonce,
+		TTL:   u.config.OIDC.StateTTL,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.OIDCAuthorizationURLResponse{
+		AuthorizationURL: authorizationURL,
+		State:            state,
 	}, nil
 }
+
+func (u *userServiceImpl) LoginWithOIDC(ctx context.Context, req *requests.OIDCLoginRequest) (*responses.UserWithTokenResponse, *errutils.Error) {
+	if !u.oidcRepo.IsEnabled() {
+		return nil, errutils.NewError(exceptions.ErrOIDCNotConfigured, errutils.BadRequest)
+	}
+
+	// The state is consumed before the code exchange, so a callback cannot be replayed
+	nonce, err := u.oidcStateRepo.Consume(ctx, req.State)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if nonce == nil {
+		return nil, errutils.NewError(exceptions.ErrInvalidOIDCState, errutils.BadRequest)
+	}
+
+	identity, err := u.oidcRepo.ExchangeCode(ctx, req.Code)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrOIDCLoginFailed, errutils.Unauthorized).WithDebugMessage(err.Error())
+	} else if identity.Nonce != *nonce {
+		return nil, errutils.NewError(exceptions.ErrOIDCLoginFailed, errutils.Unauthorized).WithDebugMessage("nonce mismatch")
+	}
+
+	user, svcErr := u.findOrProvisionOIDCUser(ctx, identity)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	// Second factors are left to the identity provider, the TOTP challenge is only part of the password login
+	return u.issueAccessToken(user)
+}
+
+// findOrProvisionOIDCUser returns the user linked to the identity. On the first login the identity is linked
+// to the user with the same email, or a new user without a password is created. Deactivated users are
+// rejected before anything is linked to them.
+func (u *userServiceImpl) findOrProvisionOIDCUser(ctx context.Context, identity *models.OIDCIdentity) (*models.User, *errutils.Error) {
+	user, err := u.userRepo.FindByOIDCIdentity(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user != nil {
+		if user.IsDeactivated() {
+			return nil, errutils.NewError(exceptions.ErrUserDeactivated, errutils.Forbidden)
+		}
+		return user, nil
+	}
+
+	if identity.Email == "" {
+		return nil, errutils.NewError(exceptions.ErrOIDCEmailMissing, errutils.BadRequest)
+	} else if !identity.EmailVerified {
+		// Linking by an unverified email would let anyone take over the account with that email
+		return nil, errutils.NewError(exceptions.ErrOIDCEmailNotVerified, errutils.Forbidden)
+	}
+
+	now := time.Now()
+	link := &models.OIDCLink{
+		Issuer:   identity.Issuer,
+		Subject:  identity.Subject,
+		LinkedAt: now,
+	}
+
+	user, err = u.userRepo.FindByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	if user != nil {
+		if user.IsDeactivated() {
+			return nil, errutils.NewError(exceptions.ErrUserDeactivated, errutils.Forbidden)
+		}
+
+		err = u.userRepo.AddOIDCIdentity(ctx, user.ID, link)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
+
+		if user.EmailVerifiedAt == nil {
+			err = u.userRepo.UpdateEmailVerifiedAt(ctx, user.ID, now)
+			if err != nil {
+				return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+			}
+			user.EmailVerifiedAt = &now
+		}
+
+		return user, nil
+	}
+
+	fullName := strings.Trim(identity.Name, " ")
+	if fullName == "" {
+		fullName = strings.Split(identity.Email, "@")[0]
+	}
+
+	createdUser, err := u.userRepo.Create(ctx, &repositories.CreateUserRequest{
+		Email:             identity.Email,
+		FullName:          fullName,
+		DisplayName:       fullName,
+		DefaultProfileUrl: buildDefaultProfileUrl(fullName),
+		EmailVerifiedAt:   &now,
+		OIDCIdentities:    []models.OIDCLink{*link},
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return createdUser, nil
+}
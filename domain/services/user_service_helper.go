@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// signToken signs claims with HS256, it is shared by every single-purpose token sent by email.
+func signToken(secret string, claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString([]byte(secret))
+}
+
+// parseToken verifies a token produced by signToken and decodes it into claims.
+func parseToken(secret string, tokenString string, claims jwt.Claims) error {
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+
+	return err
+}
+
+// randomToken returns a URL-safe random string, used for values such as the OIDC state and nonce.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func buildDefaultProfileUrl(fullName string) string {
+	nameParts := strings.Split(fullName, " ")
+	var defaultProfileUrl = "https://ui-avatars.com/api/?name="
+	if len(nameParts) == 1 {
+		defaultProfileUrl += nameParts[0]
+	} else {
+		defaultProfileUrl += nameParts[0] + "+" + nameParts[1]
+	}
+
+	return defaultProfileUrl
+}
+
+func passwordFingerprint(passwordHash string) string {
+	sum := sha256.Sum256([]byte(passwordHash))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+func buildPasswordResetMailBody(fullName string, resetURL string, expiredAt string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Hi %s,\n\n", fullName)
+	sb.WriteString("We received a request to reset your Task Nexus password.\n\n")
+	fmt.Fprintf(&sb, "Reset your password using the link below:\n%s\n\n", resetURL)
+	fmt.Fprintf(&sb, "This link expires on %s. If you did not request a password reset, you can ignore this email.\n", expiredAt)
+
+	return sb.String()
+}
+
+func buildEmailVerificationMailBody(fullName string, verifyURL string, expiredAt string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Hi %s,\n\n", fullName)
+	sb.WriteString("Please confirm your email address for Task Nexus.\n\n")
+	fmt.Fprintf(&sb, "Verify your email using the link below:\n%s\n\n", verifyURL)
+	fmt.Fprintf(&sb, "This link expires on %s.\n", expiredAt)
+
+	return sb.String()
+}
+
+// isAdminEmail reports whether the email is listed in config.AdminEmails.
+func isAdminEmail(adminEmails []string, email string) bool {
+	return slices.ContainsFunc(adminEmails, func(adminEmail string) bool {
+		return strings.EqualFold(strings.TrimSpace(adminEmail), email)
+	})
+}
+
+// findAdmin returns the requester when its email is listed in config.AdminEmails.
+func findAdmin(ctx context.Context, userRepo repositories.UserRepository, adminEmails []string, userID string) (*models.User, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	user, err := userRepo.FindByID(ctx, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if user == nil {
+		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.NotFound)
+	}
+
+	if !isAdminEmail(adminEmails, user.Email) {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Only admins can perform this action")
+	}
+
+	return user, nil
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+)
+
+// fakeTwoFactorChallengeRepo keeps the challenges in memory, it burns and consumes them like the Redis repository.
+type fakeTwoFactorChallengeRepo struct {
+	failures map[string]int
+}
+
+func (r *fakeTwoFactorChallengeRepo) Create(ctx context.Context, in *repositories.CreateTwoFactorChallengeRequest) error {
+	r.failures[in.ChallengeID] = 0
+	return nil
+}
+
+func (r *fakeTwoFactorChallengeRepo) Exists(ctx context.Context, challengeID string) (bool, error) {
+	_, ok := r.failures[challengeID]
+	return ok, nil
+}
+
+func (r *fakeTwoFactorChallengeRepo) RecordFailure(ctx context.Context, challengeID string, maxFailures int) (bool, error) {
+	if _, ok := r.failures[challengeID]; !ok {
+		return false, nil
+	}
+
+	r.failures[challengeID]++
+	if r.failures[challengeID] >= maxFailures {
+		delete(r.failures, challengeID)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *fakeTwoFactorChallengeRepo) Consume(ctx context.Context, challengeID string) (bool, error) {
+	if _, ok := r.failures[challengeID]; !ok {
+		return false, nil
+	}
+
+	delete(r.failures, challengeID)
+	return true, nil
+}
+
+func TestLoginWithTwoFactor(t *testing.T) {
+	const (
+		recoveryCode      = "abcde-fghij"
+		otherRecoveryCode = "klmno-pqrst"
+		wrongCode         = "zzzzz-zzzzz"
+	)
+
+	type attempt struct {
+		code    string
+		wantErr error
+	}
+
+	wrongAttempts := func(n int) []attempt {
+		attempts := make([]attempt, 0, n)
+		for i := 0; i < n; i++ {
+			attempts = append(attempts, attempt{code: wrongCode, wantErr: exceptions.ErrInvalidTwoFactorCode})
+		}
+		return attempts
+	}
+
+	tests := []struct {
+		name     string
+		attempts []attempt
+	}{
+		{
+			name:     "correct code",
+			attempts: []attempt{{code: recoveryCode}},
+		},
+		{
+			name: "challenge is consumed by a successful login",
+			attempts: []attempt{
+				{code: recoveryCode},
+				{code: otherRecoveryCode, wantErr: exceptions.ErrInvalidTwoFactorChallengeToken},
+			},
+		},
+		{
+			name:     "wrong codes below the limit keep the challenge",
+			attempts: append(wrongAttempts(twoFactorChallengeMaxFailures-1), attempt{code: recoveryCode}),
+		},
+		{
+			name: "challenge is burned after too many wrong codes",
+			attempts: append(wrongAttempts(twoFactorChallengeMaxFailures-1),
+				attempt{code: wrongCode, wantErr: exceptions.ErrInvalidTwoFactorChallengeToken},
+				attempt{code: recoveryCode, wantErr: exceptions.ErrInvalidTwoFactorChallengeToken},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTwoFactorLoginUserService(newTwoFactorUser(recoveryCode, otherRecoveryCode))
+			user := service.userRepo.(*fakeTwoFactorUserRepo).user
+
+			challenge, svcErr := service.createTwoFactorChallenge(context.Background(), user)
+			if svcErr != nil {
+				t.Fatalf("createTwoFactorChallenge() error = %v", svcErr)
+			}
+
+			for i, attempt := range tt.attempts {
+				res, svcErr := service.LoginWithTwoFactor(context.Background(), &requests.LoginWithTwoFactorRequest{
+					ChallengeToken: challenge.ChallengeToken,
+					Code:           attempt.code,
+				})
+				if !isServiceError(svcErr, attempt.wantErr) {
+					t.Fatalf("attempt %d: LoginWithTwoFactor() error = %v, want %v", i+1, svcErr, attempt.wantErr)
+				}
+				if svcErr == nil && res.Token == "" {
+					t.Fatalf("attempt %d: LoginWithTwoFactor() returned no access token", i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginWithTwoFactorRejectsInvalidChallenges(t *testing.T) {
+	tests := []struct {
+		name string
+		// challengeToken returns the token sent with a correct recovery code
+		challengeToken func(t *testing.T, service *userServiceImpl, user *models.User) string
+	}{
+		{
+			name: "unknown challenge",
+			challengeToken: func(t *testing.T, service *userServiceImpl, user *models.User) string {
+				challenge, svcErr := service.createTwoFactorChallenge(context.Background(), user)
+				if svcErr != nil {
+					t.Fatalf("createTwoFactorChallenge() error = %v", svcErr)
+				}
+				clear(service.twoFactorChallengeRepo.(*fakeTwoFactorChallengeRepo).failures)
+				return challenge.ChallengeToken
+			},
+		},
+		{
+			name: "token signed with another secret",
+			challengeToken: func(t *testing.T, service *userServiceImpl, user *models.User) string {
+				other := newTwoFactorLoginUserService(user)
+				other.config.JWT.TwoFactorChallengeSecret = "another-secret"
+				challenge, svcErr := other.createTwoFactorChallenge(context.Background(), user)
+				if svcErr != nil {
+					t.Fatalf("createTwoFactorChallenge() error = %v", svcErr)
+				}
+				return challenge.ChallengeToken
+			},
+		},
+		{
+			name: "malformed token",
+			challengeToken: func(t *testing.T, service *userServiceImpl, user *models.User) string {
+				return "not-a-token"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newTwoFactorUser("abcde-fghij")
+			service := newTwoFactorLoginUserService(user)
+
+			_, svcErr := service.LoginWithTwoFactor(context.Background(), &requests.LoginWithTwoFactorRequest{
+				ChallengeToken: tt.challengeToken(t, service, user),
+				Code:           "abcde-fghij",
+			})
+			if !isServiceError(svcErr, exceptions.ErrInvalidTwoFactorChallengeToken) {
+				t.Errorf("LoginWithTwoFactor() error = %v, want %v", svcErr, exceptions.ErrInvalidTwoFactorChallengeToken)
+			}
+		})
+	}
+}
+
+func newTwoFactorLoginUserService(user *models.User) *userServiceImpl {
+	return &userServiceImpl{
+		config: &config.Config{
+			JWT: config.JWT{
+				AccessTokenSecret:        "access-token-secret",
+				TwoFactorChallengeSecret: "two-factor-challenge-secret",
+			},
+		},
+		userRepo:               &fakeTwoFactorUserRepo{user: user},
+		twoFactorChallengeRepo: &fakeTwoFactorChallengeRepo{failures: make(map[string]int)},
+	}
+}
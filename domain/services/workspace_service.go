@@ -18,12 +18,20 @@ type WorkspaceService interface {
 	SetupWorkspace(ctx context.Context, req *requests.CreateWorkspaceRequest, userID string) (*models.Workspace, *errutils.Error)
 	ListOwnWorkspace(ctx context.Context, userId string) ([]responses.ListOwnWorkspaceResponseWorkspace, *errutils.Error)
 	ListWorkspaceMembers(ctx context.Context, req *requests.ListWorkspaceMemberRequest) (*responses.ListWorkspaceMembersResponse, *errutils.Error)
+	Create(ctx context.Context, req *requests.CreateWorkspaceRequest, userID string) (*models.Workspace, *errutils.Error)
+	Rename(ctx context.Context, req *requests.RenameWorkspaceRequest, userID string) (*models.Workspace, *errutils.Error)
+	UpdateMemberRole(ctx context.Context, req *requests.UpdateWorkspaceMemberRoleRequest, userID string) (*models.WorkspaceMember, *errutils.Error)
+	RemoveMember(ctx context.Context, req *requests.RemoveWorkspaceMemberRequest, userID string) (*responses.RemoveWorkspaceMemberResponse, *errutils.Error)
+	TransferOwnership(ctx context.Context, req *requests.TransferWorkspaceOwnershipRequest, userID string) (*responses.TransferWorkspaceOwnershipResponse, *errutils.Error)
+	UpdateTwoFactorRequirement(ctx context.Context, req *requests.UpdateWorkspaceTwoFactorRequirementRequest, userID string) (*models.Workspace, *errutils.Error)
 }
 
 type workspaceServiceImpl struct {
 	workspaceRepo        repositories.WorkspaceRepository
 	userRepo             repositories.UserRepository
 	workspaceMemberRepo  repositories.WorkspaceMemberRepository
+	projectRepo          repositories.ProjectRepository
+	projectMemberRepo    repositories.ProjectMemberRepository
 	globalSettingService GlobalSettingService
 }
 
@@ -31,12 +39,16 @@ func NewWorkspaceService(
 	workspaceRepo repositories.WorkspaceRepository,
 	userRepo repositories.UserRepository,
 	workspaceMemberRepo repositories.WorkspaceMemberRepository,
+	projectRepo repositories.ProjectRepository,
+	projectMemberRepo repositories.ProjectMemberRepository,
 	globalSettingService GlobalSettingService,
 ) WorkspaceService {
 	return &workspaceServiceImpl{
 		workspaceRepo:        workspaceRepo,
 		userRepo:             userRepo,
 		workspaceMemberRepo:  workspaceMemberRepo,
+		projectRepo:          projectRepo,
+		projectMemberRepo:    projectMemberRepo,
 		globalSettingService: globalSettingService,
 	}
 }
@@ -63,54 +75,20 @@ func (w *workspaceServiceImpl) SetupWorkspace(ctx context.Context, req *requests
 		return nil, errutils.NewError(exceptions.ErrOwnerNotSetup, errutils.BadRequest)
 	}
 
-	userObjID, err := bson.ObjectIDFromHex(userID)
-	if err != nil {
-		return nil, errutils.NewError(err, errutils.InternalServerError)
-	}
-
-	// Find user
-	user, err := w.userRepo.FindByID(ctx, userObjID)
-	if err != nil {
-		return nil, errutils.NewError(err, errutils.InternalServerError)
-	}
-
-	if user == nil {
-		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.BadRequest)
-	}
-
-	var profileUrl = user.DefaultProfileUrl
-	if user.UploadedProfileUrl != nil {
-		profileUrl = *user.UploadedProfileUrl
-	}
-
-	// Create workspace with user as owner
-	workspace, err := w.workspaceRepo.Create(ctx, &repositories.CreateWorkspaceRequest{
-		Name:            req.Name,
-		UserDisplayName: user.DisplayName,
-		ProfileUrl:      profileUrl,
-		UserID:          userObjID,
-	})
-
-	if err != nil {
-		return nil, errutils.NewError(err, errutils.InternalServerError)
-	}
-
-	_, err = w.workspaceMemberRepo.Create(ctx, &repositories.CreateWorkspaceMemberRequest{
-		WorkspaceID: workspace.ID,
-		UserID:      userObjID,
-		Role:        models.WorkspaceMemberRoleOwner,
-	})
-
-	if err != nil {
-		return nil, errutils.NewError(err, errutils.InternalServerError).WithDebugMessage(err.Error())
+	workspace, svcErr := w.createWorkspaceWithOwner(ctx, req.Name, userID)
+	if svcErr != nil {
+		return nil, svcErr
 	}
 
 	// Set is setup workspace completed
-	err = w.globalSettingService.SetGlobalSetting(ctx, &models.KeyValuePair{
+	svcErr = w.globalSettingService.SetGlobalSetting(ctx, &models.KeyValuePair{
 		Key:   constant.GlobalSettingKeyIsSetupWorkspace,
 		Type:  models.KeyValuePairTypeBoolean,
 		Value: true,
 	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
 
 	return workspace, nil
 }
@@ -147,10 +125,11 @@ func (s *workspaceServiceImpl) ListOwnWorkspace(ctx context.Context, userId stri
 	for _, workspaceMember := range workspaceMembers {
 		if workspace, exists := workspaceMap[workspaceMember.WorkspaceID]; exists {
 			workspaceResponses = append(workspaceResponses, responses.ListOwnWorkspaceResponseWorkspace{
-				ID:       workspace.ID.Hex(),
-				Name:     workspace.Name,
-				Role:     workspaceMember.Role.String(),
-				JoinedAt: workspaceMember.JoinedAt,
+				ID:               workspace.ID.Hex(),
+				Name:             workspace.Name,
+				Role:             workspaceMember.Role.String(),
+				RequireTwoFactor: workspace.RequireTwoFactor,
+				JoinedAt:         workspaceMember.JoinedAt,
 			})
 		}
 	}
@@ -200,8 +179,9 @@ func (s *workspaceServiceImpl) ListWorkspaceMembers(ctx context.Context, req *re
 	}
 
 	users, totalUser, err := s.userRepo.SearchWithUserIDs(ctx, &repositories.SearchUserWithUserIDsRequest{
-		UserIDs: userIDs,
-		Keyword: req.Keyword,
+		UserIDs:            userIDs,
+		Keyword:            req.Keyword,
+		IncludeDeactivated: req.IncludeDeactivated,
 		PaginationRequest: repositories.PaginationRequest{
 			Page:     req.Page,
 			PageSize: req.PageSize,
@@ -234,6 +214,7 @@ func (s *workspaceServiceImpl) ListWorkspaceMembers(ctx context.Context, req *re
 				FullName:          user.FullName,
 				DisplayName:       user.DisplayName,
 				ProfileUrl:        profileUrl,
+				DeactivatedAt:     user.DeactivatedAt,
 			})
 		}
 	}
@@ -248,3 +229,319 @@ func (s *workspaceServiceImpl) ListWorkspaceMembers(ctx context.Context, req *re
 		},
 	}, nil
 }
+
+func (w *workspaceServiceImpl) Create(ctx context.Context, req *requests.CreateWorkspaceRequest, userID string) (*models.Workspace, *errutils.Error) {
+	// Additional workspaces can only be created once the first one has been set up
+	isSetupWorkspaceSetting, svcErr := w.globalSettingService.GetGlobalSettingByKey(ctx, constant.GlobalSettingKeyIsSetupWorkspace)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if !isSetupWorkspaceSetting.Value.(bool) {
+		return nil, errutils.NewError(exceptions.ErrWorkspaceNotSetup, errutils.BadRequest)
+	}
+
+	return w.createWorkspaceWithOwner(ctx, req.Name, userID)
+}
+
+func (w *workspaceServiceImpl) Rename(ctx context.Context, req *requests.RenameWorkspaceRequest, userID string) (*models.Workspace, *errutils.Error) {
+	workspace, requester, svcErr := w.findWorkspaceAndRequester(ctx, req.WorkspaceID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if requester.Role != models.WorkspaceMemberRoleOwner && requester.Role != models.WorkspaceMemberRoleModerator {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Only owner and moderator can rename workspace")
+	}
+
+	updatedWorkspace, err := w.workspaceRepo.UpdateName(ctx, &repositories.UpdateWorkspaceNameRequest{
+		WorkspaceID: workspace.ID,
+		Name:        req.Name,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return updatedWorkspace, nil
+}
+
+func (w *workspaceServiceImpl) UpdateMemberRole(ctx context.Context, req *requests.UpdateWorkspaceMemberRoleRequest, userID string) (*models.WorkspaceMember, *errutils.Error) {
+	workspace, requester, svcErr := w.findWorkspaceAndRequester(ctx, req.WorkspaceID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if requester.Role != models.WorkspaceMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Only owner can update member role")
+	}
+
+	// Ownership can only be handed over through TransferOwnership
+	role := models.WorkspaceMemberRole(req.Role)
+	if role != models.WorkspaceMemberRoleModerator && role != models.WorkspaceMemberRoleMember {
+		return nil, errutils.NewError(exceptions.ErrInvalidWorkspaceMemberRole, errutils.BadRequest).WithFields(req.Role)
+	}
+
+	member, svcErr := w.findWorkspaceMember(ctx, workspace.ID, req.UserID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if member.Role == models.WorkspaceMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrCannotModifyWorkspaceOwner, errutils.BadRequest)
+	}
+
+	updatedMember, err := w.workspaceMemberRepo.UpdateRoleByID(ctx, &repositories.UpdateWorkspaceMemberRoleRequest{
+		ID:   member.ID,
+		Role: role,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return updatedMember, nil
+}
+
+func (w *workspaceServiceImpl) RemoveMember(ctx context.Context, req *requests.RemoveWorkspaceMemberRequest, userID string) (*responses.RemoveWorkspaceMemberResponse, *errutils.Error) {
+	workspace, requester, svcErr := w.findWorkspaceAndRequester(ctx, req.WorkspaceID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if requester.Role != models.WorkspaceMemberRoleOwner && requester.Role != models.WorkspaceMemberRoleModerator {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Only owner and moderator can remove members")
+	}
+
+	member, svcErr := w.findWorkspaceMember(ctx, workspace.ID, req.UserID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if member.Role == models.WorkspaceMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrCannotModifyWorkspaceOwner, errutils.BadRequest)
+	} else if member.Role == models.WorkspaceMemberRoleModerator && requester.Role != models.WorkspaceMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Only owner can remove moderators")
+	}
+
+	// Find the member's project memberships that belong to this workspace
+	projectMembers, err := w.projectMemberRepo.FindByUserID(ctx, member.UserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	projectIDs := make([]bson.ObjectID, 0, len(projectMembers))
+	ownedProjectIDs := make(map[bson.ObjectID]bool)
+	for _, projectMember := range projectMembers {
+		projectIDs = append(projectIDs, projectMember.ProjectID)
+		if projectMember.Role == models.ProjectMemberRoleOwner {
+			ownedProjectIDs[projectMember.ProjectID] = true
+		}
+	}
+
+	removedFromProjects := make([]string, 0)
+	if len(projectIDs) > 0 {
+		projects, err := w.projectRepo.FindByProjectIDsAndWorkspaceID(ctx, projectIDs, workspace.ID)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		}
+
+		workspaceProjectIDs := make([]bson.ObjectID, 0, len(projects))
+		ownedProjectNames := make([]string, 0)
+		for _, project := range projects {
+			workspaceProjectIDs = append(workspaceProjectIDs, project.ID)
+			removedFromProjects = append(removedFromProjects, project.ID.Hex())
+			if ownedProjectIDs[project.ID] {
+				ownedProjectNames = append(ownedProjectNames, project.Name)
+			}
+		}
+
+		// Projects must not be left without an owner, so ownership has to be transferred first
+		if len(ownedProjectNames) > 0 {
+			return nil, errutils.NewError(exceptions.ErrMemberOwnsProjects, errutils.BadRequest).WithFields(ownedProjectNames...)
+		}
+
+		if len(workspaceProjectIDs) > 0 {
+			err = w.projectMemberRepo.RemoveByProjectIDsAndUserID(ctx, workspaceProjectIDs, member.UserID)
+			if err != nil {
+				return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+			}
+		}
+	}
+
+	err = w.workspaceMemberRepo.RemoveByID(ctx, member.ID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.RemoveWorkspaceMemberResponse{
+		Message:             "Member removed successfully",
+		RemovedFromProjects: removedFromProjects,
+	}, nil
+}
+
+func (w *workspaceServiceImpl) TransferOwnership(ctx context.Context, req *requests.TransferWorkspaceOwnershipRequest, userID string) (*responses.TransferWorkspaceOwnershipResponse, *errutils.Error) {
+	workspace, owner, svcErr := w.findWorkspaceAndRequester(ctx, req.WorkspaceID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if owner.Role != models.WorkspaceMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("User is not the owner of the workspace")
+	}
+
+	newOwner, svcErr := w.findWorkspaceMember(ctx, workspace.ID, req.NewOwnerUserID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if newOwner.UserID == owner.UserID {
+		return nil, errutils.NewError(exceptions.ErrCannotTransferToSelf, errutils.BadRequest)
+	}
+
+	// Promote the new owner first, so the workspace is never left without an owner
+	_, err := w.workspaceMemberRepo.UpdateRoleByID(ctx, &repositories.UpdateWorkspaceMemberRoleRequest{
+		ID:   newOwner.ID,
+		Role: models.WorkspaceMemberRoleOwner,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	_, err = w.workspaceMemberRepo.UpdateRoleByID(ctx, &repositories.UpdateWorkspaceMemberRoleRequest{
+		ID:   owner.ID,
+		Role: models.WorkspaceMemberRoleModerator,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return &responses.TransferWorkspaceOwnershipResponse{
+		Message: "Workspace ownership transferred successfully",
+	}, nil
+}
+
+func (w *workspaceServiceImpl) UpdateTwoFactorRequirement(ctx context.Context, req *requests.UpdateWorkspaceTwoFactorRequirementRequest, userID string) (*models.Workspace, *errutils.Error) {
+	workspace, requester, svcErr := w.findWorkspaceAndRequester(ctx, req.WorkspaceID, userID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if requester.Role != models.WorkspaceMemberRoleOwner {
+		return nil, errutils.NewError(exceptions.ErrPermissionDenied, errutils.Forbidden).WithDebugMessage("Only owner can change the two-factor requirement")
+	}
+
+	// The owner would otherwise lock themselves out of the workspace
+	if *req.Required {
+		owner, err := w.userRepo.FindByID(ctx, requester.UserID)
+		if err != nil {
+			return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+		} else if owner == nil {
+			return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.NotFound)
+		} else if !owner.IsTwoFactorEnabled() {
+			return nil, errutils.NewError(exceptions.ErrTwoFactorRequiredToEnforceOwner, errutils.BadRequest)
+		}
+	}
+
+	updatedWorkspace, err := w.workspaceRepo.UpdateRequireTwoFactor(ctx, &repositories.UpdateWorkspaceRequireTwoFactorRequest{
+		WorkspaceID:      workspace.ID,
+		RequireTwoFactor: *req.Required,
+	})
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return updatedWorkspace, nil
+}
+
+func (w *workspaceServiceImpl) createWorkspaceWithOwner(ctx context.Context, name string, userID string) (*models.Workspace, *errutils.Error) {
+	userObjID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(err, errutils.InternalServerError)
+	}
+
+	// Find user
+	user, err := w.userRepo.FindByID(ctx, userObjID)
+	if err != nil {
+		return nil, errutils.NewError(err, errutils.InternalServerError)
+	}
+
+	if user == nil {
+		return nil, errutils.NewError(exceptions.ErrUserNotFound, errutils.BadRequest)
+	}
+
+	var profileUrl = user.DefaultProfileUrl
+	if user.UploadedProfileUrl != nil {
+		profileUrl = *user.UploadedProfileUrl
+	}
+
+	// Create workspace with user as owner
+	workspace, err := w.workspaceRepo.Create(ctx, &repositories.CreateWorkspaceRequest{
+		Name:            name,
+		UserDisplayName: user.DisplayName,
+		ProfileUrl:      profileUrl,
+		UserID:          userObjID,
+	})
+
+	if err != nil {
+		return nil, errutils.NewError(err, errutils.InternalServerError)
+	}
+
+	_, err = w.workspaceMemberRepo.Create(ctx, &repositories.CreateWorkspaceMemberRequest{
+		WorkspaceID: workspace.ID,
+		UserID:      userObjID,
+		Role:        models.WorkspaceMemberRoleOwner,
+	})
+
+	if err != nil {
+		return nil, errutils.NewError(err, errutils.InternalServerError).WithDebugMessage(err.Error())
+	}
+
+	return workspace, nil
+}
+
+func (w *workspaceServiceImpl) findWorkspaceAndRequester(ctx context.Context, workspaceID string, userID string) (*models.Workspace, *models.WorkspaceMember, *errutils.Error) {
+	bsonWorkspaceID, err := bson.ObjectIDFromHex(workspaceID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInvalidWorkspaceID, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	workspace, err := w.workspaceRepo.FindByID(ctx, bsonWorkspaceID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if workspace == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrWorkspaceNotFound, errutils.NotFound)
+	}
+
+	requester, err := w.workspaceMemberRepo.FindByWorkspaceIDAndUserID(ctx, bsonWorkspaceID, bsonUserID)
+	if err != nil {
+		return nil, nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if requester == nil {
+		return nil, nil, errutils.NewError(exceptions.ErrRequesterNotFoundInWorkspace, errutils.Forbidden)
+	}
+
+	if svcErr := checkWorkspaceTwoFactorRequirement(ctx, w.userRepo, workspace, bsonUserID); svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	return workspace, requester, nil
+}
+
+func (w *workspaceServiceImpl) findWorkspaceMember(ctx context.Context, workspaceID bson.ObjectID, userID string) (*models.WorkspaceMember, *errutils.Error) {
+	bsonUserID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.BadRequest).WithDebugMessage(err.Error())
+	}
+
+	member, err := w.workspaceMemberRepo.FindByWorkspaceIDAndUserID(ctx, workspaceID, bsonUserID)
+	if err != nil {
+		return nil, errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if member == nil {
+		return nil, errutils.NewError(exceptions.ErrMemberNotFoundInWorkspace, errutils.NotFound)
+	}
+
+	return member, nil
+}
@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/services"
+)
+
+type InvitationExpiryJob interface {
+	Job
+}
+
+type invitationExpiryJobImpl struct {
+	config            *config.Config
+	invitationService services.InvitationService
+}
+
+func NewInvitationExpiryJob(config *config.Config, invitationService services.InvitationService) InvitationExpiryJob {
+	return &invitationExpiryJobImpl{
+		config:            config,
+		invitationService: invitationService,
+	}
+}
+
+func (i *invitationExpiryJobImpl) Name() string {
+	return "invitation-expiry"
+}
+
+func (i *invitationExpiryJobImpl) Interval() time.Duration {
+	return i.config.Job.InvitationExpiryInterval
+}
+
+func (i *invitationExpiryJobImpl) Run(ctx context.Context) error {
+	expiredCount, err := i.invitationService.ExpireOverdue(ctx)
+	if err != nil {
+		return err
+	}
+
+	if expiredCount > 0 {
+		log.Printf("⏰ Marked %d invitations as expired", expiredCount)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+// Job is a unit of background work that the scheduler runs periodically.
+type Job interface {
+	Name() string
+	Interval() time.Duration
+	Run(ctx context.Context) error
+}
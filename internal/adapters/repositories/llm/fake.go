@@ -0,0 +1,133 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+)
+
+const (
+	fakeModel         = "fake"
+	fakeEmbeddingSize = 256
+)
+
+// fakeLLMRepo answers without calling a model, the same request always gets the same response.
+// It is meant for local development and for environments without access to a model.
+type fakeLLMRepo struct{}
+
+func NewFakeLLMRepo() repositories.LLMRepository {
+	return &fakeLLMRepo{}
+}
+
+func (f *fakeLLMRepo) Complete(ctx context.Context, in *repositories.LLMCompletionRequest) (*repositories.LLMCompletion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return newFakeCompletion(in, fakeText(in.Prompt)), nil
+}
+
+func (f *fakeLLMRepo) Stream(ctx context.Context, in *repositories.LLMCompletionRequest, onChunk func(chunk string) error) (*repositories.LLMCompletion, error) {
+	text := fakeText(in.Prompt)
+
+	for _, chunk := range strings.SplitAfter(text, " ") {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := onChunk(chunk); err != nil {
+			return nil, err
+		}
+	}
+
+	return newFakeCompletion(in, text), nil
+}
+
+func (f *fakeLLMRepo) CompleteJSON(ctx context.Context, in *repositories.LLMCompletionRequest, out any) (*repositories.LLMCompletion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	text, err := json.Marshal(fakeValue("value", in.Schema))
+	if err != nil {
+		return nil, err
+	}
+
+	completion := newFakeCompletion(in, string(text))
+	if err := decodeJSONCompletion(completion.Text, out); err != nil {
+		return completion, err
+	}
+
+	return completion, nil
+}
+
+// Embed hashes the words of text into a bag-of-words vector, so texts sharing words are similar.
+func (f *fakeLLMRepo) Embed(ctx context.Context, text string) (*repositories.LLMEmbedding, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	vector := make([]float32, fakeEmbeddingSize)
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		h := fnv.New32a()
+		h.Write([]byte(strings.Trim(word, ".,:;!?\"'()[]")))
+		vector[h.Sum32()%fakeEmbeddingSize]++
+	}
+
+	return &repositories.LLMEmbedding{
+		Provider: ProviderFake,
+		Model:    fakeModel,
+		Vector:   vector,
+	}, nil
+}
+
+func newFakeCompletion(in *repositories.LLMCompletionRequest, text string) *repositories.LLMCompletion {
+	return &repositories.LLMCompletion{
+		Provider:         ProviderFake,
+		Model:            fakeModel,
+		Text:             text,
+		PromptTokens:     len(strings.Fields(in.SystemPrompt)) + len(strings.Fields(in.Prompt)),
+		CompletionTokens: len(strings.Fields(text)),
+	}
+}
+
+func fakeText(prompt string) string {
+	words := strings.Fields(prompt)
+	if len(words) > 12 {
+		words = words[:12]
+	}
+
+	return fmt.Sprintf("This is a fake response to: %s", strings.Join(words, " "))
+}
+
+// fakeValue builds the smallest value matching the schema, name is the property that holds it.
+func fakeValue(name string, schema *repositories.LLMSchema) any {
+	if schema == nil {
+		return map[string]any{}
+	}
+
+	switch schema.Type {
+	case repositories.LLMSchemaTypeString:
+		if len(schema.Enum) > 0 {
+			return schema.Enum[0]
+		}
+		return "Fake " + name
+	case repositories.LLMSchemaTypeNumber, repositories.LLMSchemaTypeInteger:
+		return 1
+	case repositories.LLMSchemaTypeBoolean:
+		return false
+	case repositories.LLMSchemaTypeArray:
+		return []any{fakeValue(name, schema.Items)}
+	}
+
+	object := make(map[string]any, len(schema.Properties))
+	for propertyName, property := range schema.Properties {
+		object[propertyName] = fakeValue(propertyName, property)
+	}
+
+	return object
+}
@@ -2,11 +2,14 @@ package llm
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/cnc-csku/task-nexus/task-management/config"
 	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/llm"
 	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
 )
 
 type GeminiRepositoryImpl struct {
@@ -17,28 +20,176 @@ type GeminiRepositoryImpl struct {
 func NewGeminiRepo(
 	client *llm.GeminiClient,
 	cfg *config.Config,
-) repositories.GeminiRepository {
+) repositories.LLMRepository {
 	return &GeminiRepositoryImpl{
 		wrapperClient: client,
 		cfg:           cfg,
 	}
 }
 
-func (g *GeminiRepositoryImpl) GenerateTaskDescription(ctx context.Context, prompt string) ([]*genai.Content, error) {
-	generatedContent, err := g.wrapperClient.Client.GenerateContent(
-		ctx,
-		genai.Text(
-			""+prompt,
-		),
-	)
+func (g *GeminiRepositoryImpl) Complete(ctx context.Context, in *repositories.LLMCompletionRequest) (*repositories.LLMCompletion, error) {
+	resp, err := g.newModel(in, false).GenerateContent(ctx, genai.Text(in.Prompt))
 	if err != nil {
 		return nil, err
 	}
 
-	var response []*genai.Content
-	for _, content := range generatedContent.Candidates {
-		response = append(response, content.Content)
+	completion := g.newCompletion(resp.UsageMetadata)
+	completion.Text = responseText(resp)
+
+	return completion, nil
+}
+
+func (g *GeminiRepositoryImpl) Stream(ctx context.Context, in *repositories.LLMCompletionRequest, onChunk func(chunk string) error) (*repositories.LLMCompletion, error) {
+	iter := g.newModel(in, false).GenerateContentStream(ctx, genai.Text(in.Prompt))
+
+	var text strings.Builder
+	var usage *genai.UsageMetadata
+	for {
+		resp, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		chunk := responseText(resp)
+		if resp.UsageMetadata != nil {
+			usage = resp.UsageMetadata
+		}
+		if chunk == "" {
+			continue
+		}
+
+		text.WriteString(chunk)
+		if err := onChunk(chunk); err != nil {
+			return nil, err
+		}
+	}
+
+	completion := g.newCompletion(usage)
+	completion.Text = text.String()
+
+	return completion, nil
+}
+
+func (g *GeminiRepositoryImpl) CompleteJSON(ctx context.Context, in *repositories.LLMCompletionRequest, out any) (*repositories.LLMCompletion, error) {
+	resp, err := g.newModel(in, true).GenerateContent(ctx, genai.Text(in.Prompt))
+	if err != nil {
+		return nil, err
+	}
+
+	completion := g.newCompletion(resp.UsageMetadata)
+	completion.Text = responseText(resp)
+
+	if err := decodeJSONCompletion(completion.Text, out); err != nil {
+		return completion, err
+	}
+
+	return completion, nil
+}
+
+func (g *GeminiRepositoryImpl) Embed(ctx context.Context, text string) (*repositories.LLMEmbedding, error) {
+	model := g.wrapperClient.Client.EmbeddingModel(g.cfg.GeminiClient.EmbeddingModel)
+	model.TaskType = genai.TaskTypeSemanticSimilarity
+
+	resp, err := model.EmbedContent(ctx, genai.Text(text))
+	if err != nil {
+		return nil, err
+	} else if resp.Embedding == nil || len(resp.Embedding.Values) == 0 {
+		return nil, errors.New("gemini returned an empty embedding")
+	}
+
+	return &repositories.LLMEmbedding{
+		Provider: ProviderGemini,
+		Model:    g.cfg.GeminiClient.EmbeddingModel,
+		Vector:   resp.Embedding.Values,
+	}, nil
+}
+
+// newModel configures a model for one request, the shared client is safe to use concurrently but a model is not.
+func (g *GeminiRepositoryImpl) newModel(in *repositories.LLMCompletionRequest, jsonOutput bool) *genai.GenerativeModel {
+	model := g.wrapperClient.Client.GenerativeModel(g.wrapperClient.Model)
+
+	if in.SystemPrompt != "" {
+		model.SystemInstruction = genai.NewUserContent(genai.Text(in.SystemPrompt))
 	}
+	if in.Temperature != nil {
+		model.SetTemperature(*in.Temperature)
+	}
+	if in.MaxTokens > 0 {
+		model.SetMaxOutputTokens(int32(in.MaxTokens))
+	}
+	if jsonOutput {
+		model.ResponseMIMEType = "application/json"
+		model.ResponseSchema = toGeminiSchema(in.Schema)
+	}
+
+	return model
+}
+
+func (g *GeminiRepositoryImpl) newCompletion(usage *genai.UsageMetadata) *repositories.LLMCompletion {
+	completion := &repositories.LLMCompletion{
+		Provider: ProviderGemini,
+		Model:    g.wrapperClient.Model,
+	}
+
+	if usage != nil {
+		completion.PromptTokens = int(usage.PromptTokenCount)
+		completion.CompletionTokens = int(usage.CandidatesTokenCount)
+	}
+
+	return completion
+}
+
+// responseText joins the text parts of the first candidate, other candidates are never requested.
+func responseText(resp *genai.GenerateContentResponse) string {
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return ""
+	}
+
+	var text strings.Builder
+	for _, part := range resp.Candidates[0].Content.Parts {
+		if t, ok := part.(genai.Text); ok {
+			text.WriteString(string(t))
+		}
+	}
+
+	return text.String()
+}
+
+func toGeminiSchema(schema *repositories.LLMSchema) *genai.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	geminiSchema := &genai.Schema{
+		Type:        geminiSchemaTypes[schema.Type],
+		Description: schema.Description,
+		Enum:        schema.Enum,
+		Items:       toGeminiSchema(schema.Items),
+		Required:    schema.Required,
+	}
+
+	if len(schema.Properties) > 0 {
+		geminiSchema.Properties = make(map[string]*genai.Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			geminiSchema.Properties[name] = toGeminiSchema(property)
+		}
+	}
+
+	// Gemini only accepts enums on strings with the "enum" format
+	if len(schema.Enum) > 0 {
+		geminiSchema.Format = "enum"
+	}
+
+	return geminiSchema
+}
 
-	return response, nil
+var geminiSchemaTypes = map[repositories.LLMSchemaType]genai.Type{
+	repositories.LLMSchemaTypeString:  genai.TypeString,
+	repositories.LLMSchemaTypeNumber:  genai.TypeNumber,
+	repositories.LLMSchemaTypeInteger: genai.TypeInteger,
+	repositories.LLMSchemaTypeBoolean: genai.TypeBoolean,
+	repositories.LLMSchemaTypeArray:   genai.TypeArray,
+	repositories.LLMSchemaTypeObject:  genai.TypeObject,
 }
@@ -0,0 +1,51 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/llm"
+)
+
+const (
+	ProviderGemini = "gemini"
+	ProviderOllama = "ollama"
+	ProviderFake   = "fake"
+)
+
+// NewLLMRepository returns the provider selected by LLM_PROVIDER, only the client of that provider is created.
+func NewLLMRepository(ctx context.Context, cfg *config.Config) repositories.LLMRepository {
+	switch cfg.LLM.Provider {
+	case ProviderGemini:
+		return NewGeminiRepo(llm.NewGeminiClient(ctx, cfg), cfg)
+	case ProviderOllama:
+		return NewOllamaRepository(llm.NewOllamaClient(ctx, cfg), cfg)
+	case ProviderFake:
+		log.Println("🤖 Using the fake LLM provider")
+		return NewFakeLLMRepo()
+	}
+
+	log.Fatalf("❌ Unknown LLM provider: %s", cfg.LLM.Provider)
+	return nil
+}
+
+// decodeJSONCompletion decodes the text of a JSON completion, models sometimes wrap it in a Markdown code fence.
+func decodeJSONCompletion(text string, out any) error {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimPrefix(text, "```")
+		text = strings.TrimSuffix(strings.TrimSpace(text), "```")
+	}
+
+	if err := json.Unmarshal([]byte(text), out); err != nil {
+		return fmt.Errorf("failed to decode JSON completion: %w", err)
+	}
+
+	return nil
+}
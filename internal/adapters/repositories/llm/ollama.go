@@ -1,8 +1,14 @@
 package llm
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
 
 	"github.com/cnc-csku/task-nexus/task-management/config"
 	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
@@ -14,7 +20,7 @@ type OllamaRepositoryImpl struct {
 	cfg    *config.Config
 }
 
-func NewOllamaRepository(client *llm.OllamaClient, cfg *config.Config) repositories.OllamaRepository {
+func NewOllamaRepository(client *llm.OllamaClient, cfg *config.Config) repositories.LLMRepository {
 	return &OllamaRepositoryImpl{
 		client: client,
 		cfg:    cfg,
@@ -22,9 +28,17 @@ func NewOllamaRepository(client *llm.OllamaClient, cfg *config.Config) repositor
 }
 
 type OllamaRequest struct {
-	Model  string `json:"model"`
-	Prompt string `json:"prompt"`
-	Stream bool   `json:"stream"`
+	Model   string         `json:"model"`
+	Prompt  string         `json:"prompt"`
+	System  string         `json:"system,omitempty"`
+	Stream  bool           `json:"stream"`
+	Format  any            `json:"format,omitempty"`
+	Options *OllamaOptions `json:"options,omitempty"`
+}
+
+type OllamaOptions struct {
+	Temperature *float32 `json:"temperature,omitempty"`
+	NumPredict  int      `json:"num_predict,omitempty"`
 }
 
 type OllamaResponse struct {
@@ -40,35 +54,173 @@ type OllamaResponse struct {
 	PromptEvalDuration int64  `json:"prompt_eval_duration"`
 	EvalCount          int64  `json:"eval_count"`
 	EvalDuration       int64  `json:"eval_duration"`
+	Error              string `json:"error"`
+}
+
+type OllamaEmbedRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
 }
 
-func (r *OllamaRepositoryImpl) Generate(model string, prompt string, stream bool) (string, error) {
-	request := OllamaRequest{
-		Model:  model,
-		Prompt: prompt,
+type OllamaEmbedResponse struct {
+	Model      string      `json:"model"`
+	Embeddings [][]float32 `json:"embeddings"`
+	Error      string      `json:"error"`
+}
+
+func (r *OllamaRepositoryImpl) Complete(ctx context.Context, in *repositories.LLMCompletionRequest) (*repositories.LLMCompletion, error) {
+	return r.generate(ctx, r.newRequest(in, false, false), nil)
+}
+
+func (r *OllamaRepositoryImpl) Stream(ctx context.Context, in *repositories.LLMCompletionRequest, onChunk func(chunk string) error) (*repositories.LLMCompletion, error) {
+	return r.generate(ctx, r.newRequest(in, true, false), onChunk)
+}
+
+func (r *OllamaRepositoryImpl) CompleteJSON(ctx context.Context, in *repositories.LLMCompletionRequest, out any) (*repositories.LLMCompletion, error) {
+	completion, err := r.generate(ctx, r.newRequest(in, false, true), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decodeJSONCompletion(completion.Text, out); err != nil {
+		return completion, err
+	}
+
+	return completion, nil
+}
+
+func (r *OllamaRepositoryImpl) Embed(ctx context.Context, text string) (*repositories.LLMEmbedding, error) {
+	requestJson, err := json.Marshal(&OllamaEmbedRequest{
+		Model: r.cfg.OllamaClient.EmbeddingModel,
+		Input: text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := r.post(ctx, "/api/embed", requestJson)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var embedResponse OllamaEmbedResponse
+	if err := json.NewDecoder(response.Body).Decode(&embedResponse); err != nil {
+		return nil, err
+	} else if embedResponse.Error != "" {
+		return nil, fmt.Errorf("ollama: %s", embedResponse.Error)
+	} else if len(embedResponse.Embeddings) == 0 || len(embedResponse.Embeddings[0]) == 0 {
+		return nil, fmt.Errorf("ollama returned an empty embedding")
+	}
+
+	return &repositories.LLMEmbedding{
+		Provider: ProviderOllama,
+		Model:    r.cfg.OllamaClient.EmbeddingModel,
+		Vector:   embedResponse.Embeddings[0],
+	}, nil
+}
+
+func (r *OllamaRepositoryImpl) newRequest(in *repositories.LLMCompletionRequest, stream bool, jsonOutput bool) *OllamaRequest {
+	request := &OllamaRequest{
+		Model:  r.cfg.OllamaClient.Model,
+		Prompt: in.Prompt,
+		System: in.SystemPrompt,
 		Stream: stream,
 	}
 
+	if in.Temperature != nil || in.MaxTokens > 0 {
+		request.Options = &OllamaOptions{
+			Temperature: in.Temperature,
+			NumPredict:  in.MaxTokens,
+		}
+	}
+
+	// Ollama accepts either "json" or a JSON schema as the format
+	if jsonOutput {
+		request.Format = "json"
+		if in.Schema != nil {
+			request.Format = in.Schema
+		}
+	}
+
+	return request
+}
+
+// generate calls the generate API, a streamed response is read line by line and each line is passed to onChunk.
+func (r *OllamaRepositoryImpl) generate(ctx context.Context, request *OllamaRequest, onChunk func(chunk string) error) (*repositories.LLMCompletion, error) {
 	requestJson, err := json.Marshal(request)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	response, err := r.post(ctx, "/api/generate", requestJson)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	completion := &repositories.LLMCompletion{
+		Provider: ProviderOllama,
+		Model:    request.Model,
+	}
+
+	var text strings.Builder
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var ollamaResponse OllamaResponse
+		if err := json.Unmarshal(line, &ollamaResponse); err != nil {
+			return nil, err
+		} else if ollamaResponse.Error != "" {
+			return nil, fmt.Errorf("ollama: %s", ollamaResponse.Error)
+		}
+
+		text.WriteString(ollamaResponse.Response)
+		if onChunk != nil && ollamaResponse.Response != "" {
+			if err := onChunk(ollamaResponse.Response); err != nil {
+				return nil, err
+			}
+		}
+
+		if ollamaResponse.Done {
+			completion.PromptTokens = int(ollamaResponse.PromptEvalCount)
+			completion.CompletionTokens = int(ollamaResponse.EvalCount)
+			break
+		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	completion.Text = text.String()
+
+	return completion, nil
+}
 
-	endpoint := "http://" + r.cfg.OllamaClient.Endpoint + "/api/generate"
-	response, err := r.client.HTTPClient.Post(
-		endpoint,
-		"application/json",
-		bytes.NewBuffer(requestJson),
-	)
+// post calls an API of the Ollama server, the caller closes the body of a successful response.
+func (r *OllamaRepositoryImpl) post(ctx context.Context, path string, requestJson []byte) (*http.Response, error) {
+	endpoint := "http://" + r.cfg.OllamaClient.Endpoint + path
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(requestJson))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	httpRequest.Header.Set("Content-Type", "application/json")
 
-	var ollamaResponse OllamaResponse
-	err = json.NewDecoder(response.Body).Decode(&ollamaResponse)
+	response, err := r.client.HTTPClient.Do(httpRequest)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return nil, fmt.Errorf("ollama returned %s: %s", response.Status, strings.TrimSpace(string(body)))
 	}
 
-	return ollamaResponse.Response, nil
+	return response, nil
 }
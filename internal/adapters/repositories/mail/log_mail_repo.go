@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+)
+
+// logMailRepo is a stand-in for local development, it prints mails instead of delivering them.
+type logMailRepo struct {
+	cfg *config.Config
+}
+
+func NewLogMailRepo(cfg *config.Config) repositories.MailRepository {
+	return &logMailRepo{
+		cfg: cfg,
+	}
+}
+
+func (l *logMailRepo) Send(ctx context.Context, in *repositories.SendMailRequest) error {
+	message, err := buildMessage(l.cfg.Mail.From, in)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("📧 Mail to %v\n%s\n", in.To, message)
+
+	if l.cfg.Mail.OutboxDir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(l.cfg.Mail.OutboxDir, 0o755); err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("%d.eml", time.Now().UnixNano())
+
+	return os.WriteFile(filepath.Join(l.cfg.Mail.OutboxDir, fileName), message, 0o644)
+}
@@ -0,0 +1,48 @@
+package mail
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+)
+
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+)
+
+// NewMailRepository returns the mail sender selected by MAIL_DRIVER, falling back to the log sender.
+func NewMailRepository(cfg *config.Config) repositories.MailRepository {
+	switch cfg.Mail.Driver {
+	case DriverSMTP:
+		return NewSMTPMailRepo(cfg)
+	default:
+		return NewLogMailRepo(cfg)
+	}
+}
+
+// buildMessage writes the headers and the body of a mail. The subject may hold user input like a workspace name,
+// it is encoded so a line break can not start a new header.
+func buildMessage(from string, in *repositories.SendMailRequest) ([]byte, error) {
+	for _, address := range append([]string{from}, in.To...) {
+		if strings.ContainsAny(address, "\r\n") {
+			return nil, fmt.Errorf("mail address %q contains a line break", address)
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From: %s\r\n", from)
+	fmt.Fprintf(&sb, "To: %s\r\n", strings.Join(in.To, ", "))
+	fmt.Fprintf(&sb, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", in.Subject))
+	fmt.Fprintf(&sb, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(in.Body)
+
+	return []byte(sb.String()), nil
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"net/smtp"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+)
+
+type smtpMailRepo struct {
+	cfg *config.Config
+}
+
+func NewSMTPMailRepo(cfg *config.Config) repositories.MailRepository {
+	return &smtpMailRepo{
+		cfg: cfg,
+	}
+}
+
+func (s *smtpMailRepo) Send(ctx context.Context, in *repositories.SendMailRequest) error {
+	smtpCfg := s.cfg.Mail.SMTP
+
+	from, err := mail.ParseAddress(s.cfg.Mail.From)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if smtpCfg.Username != "" {
+		auth = smtp.PlainAuth("", smtpCfg.Username, smtpCfg.Password, smtpCfg.Host)
+	}
+
+	message, err := buildMessage(s.cfg.Mail.From, in)
+	if err != nil {
+		return err
+	}
+
+	return smtp.SendMail(net.JoinHostPort(smtpCfg.Host, smtpCfg.Port), auth, from.Address, in.To, message)
+}
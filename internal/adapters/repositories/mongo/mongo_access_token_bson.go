@@ -0,0 +1,47 @@
+package mongo
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type accessTokenFilter bson.M
+
+func NewAccessTokenFilter() accessTokenFilter {
+	return accessTokenFilter{}
+}
+
+func (f accessTokenFilter) WithID(id bson.ObjectID) {
+	f["_id"] = id
+}
+
+func (f accessTokenFilter) WithTokenHash(tokenHash string) {
+	f["token_hash"] = tokenHash
+}
+
+func (f accessTokenFilter) WithUserID(userID bson.ObjectID) {
+	f["user_id"] = userID
+}
+
+func (f accessTokenFilter) WithNotRevoked() {
+	f["revoked_at"] = nil
+}
+
+type accessTokenUpdate bson.M
+
+func NewAccessTokenUpdate() accessTokenUpdate {
+	return accessTokenUpdate{}
+}
+
+func (u accessTokenUpdate) Revoke() {
+	u["$set"] = bson.M{
+		"revoked_at": time.Now(),
+	}
+}
+
+func (u accessTokenUpdate) UpdateLastUsedAt(lastUsedAt time.Time) {
+	u["$set"] = bson.M{
+		"last_used_at": lastUsedAt,
+	}
+}
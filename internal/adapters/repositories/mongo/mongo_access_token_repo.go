@@ -0,0 +1,125 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongoAccessTokenRepo struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+func NewMongoAccessTokenRepo(config *config.Config, mongoClient *mongo.Client) repositories.AccessTokenRepository {
+	return &mongoAccessTokenRepo{
+		client:     mongoClient,
+		collection: mongoClient.Database(config.MongoDB.Database).Collection("access_tokens"),
+	}
+}
+
+func (m *mongoAccessTokenRepo) Create(ctx context.Context, in *repositories.CreateAccessTokenRequest) (*models.AccessToken, error) {
+	accessToken := models.AccessToken{
+		ID:          bson.NewObjectID(),
+		UserID:      in.UserID,
+		Name:        in.Name,
+		TokenHash:   in.TokenHash,
+		TokenPrefix: in.TokenPrefix,
+		Scopes:      in.Scopes,
+		ExpiresAt:   in.ExpiresAt,
+		CreatedAt:   time.Now(),
+		CreatedBy:   in.CreatedBy,
+	}
+
+	_, err := m.collection.InsertOne(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accessToken, nil
+}
+
+func (m *mongoAccessTokenRepo) FindByID(ctx context.Context, id bson.ObjectID) (*models.AccessToken, error) {
+	f := NewAccessTokenFilter()
+	f.WithID(id)
+
+	return m.findOne(ctx, f)
+}
+
+func (m *mongoAccessTokenRepo) FindByTokenHash(ctx context.Context, tokenHash string) (*models.AccessToken, error) {
+	f := NewAccessTokenFilter()
+	f.WithTokenHash(tokenHash)
+
+	return m.findOne(ctx, f)
+}
+
+func (m *mongoAccessTokenRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.AccessToken, error) {
+	f := NewAccessTokenFilter()
+	f.WithUserID(userID)
+	f.WithNotRevoked()
+
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+
+	cursor, err := m.collection.Find(ctx, f, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	accessTokens := make([]*models.AccessToken, 0)
+	if err := cursor.All(ctx, &accessTokens); err != nil {
+		return nil, err
+	}
+
+	return accessTokens, nil
+}
+
+func (m *mongoAccessTokenRepo) Revoke(ctx context.Context, id bson.ObjectID) error {
+	f := NewAccessTokenFilter()
+	f.WithID(id)
+
+	u := NewAccessTokenUpdate()
+	u.Revoke()
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoAccessTokenRepo) UpdateLastUsedAt(ctx context.Context, id bson.ObjectID, lastUsedAt time.Time) error {
+	f := NewAccessTokenFilter()
+	f.WithID(id)
+
+	u := NewAccessTokenUpdate()
+	u.UpdateLastUsedAt(lastUsedAt)
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoAccessTokenRepo) findOne(ctx context.Context, f accessTokenFilter) (*models.AccessToken, error) {
+	var accessToken models.AccessToken
+	err := m.collection.FindOne(ctx, f).Decode(&accessToken)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &accessToken, nil
+}
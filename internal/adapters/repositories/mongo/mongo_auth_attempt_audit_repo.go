@@ -0,0 +1,50 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type mongoAuthAttemptAuditRepo struct {
+	collection *mongo.Collection
+}
+
+func NewMongoAuthAttemptAuditRepo(config *config.Config, mongoClient *mongo.Client) repositories.AuthAttemptAuditRepository {
+	return &mongoAuthAttemptAuditRepo{
+		collection: mongoClient.Database(config.MongoDB.Database).Collection("auth_attempt_audits"),
+	}
+}
+
+func (m *mongoAuthAttemptAuditRepo) Create(ctx context.Context, in *repositories.CreateAuthAttemptAuditRequest) (*models.AuthAttemptAudit, error) {
+	audit := models.AuthAttemptAudit{
+		ID:         bson.NewObjectID(),
+		Route:      in.Route,
+		Account:    in.Account,
+		IPAddress:  in.IPAddress,
+		UserAgent:  in.UserAgent,
+		Outcome:    in.Outcome,
+		StatusCode: in.StatusCode,
+		CreatedAt:  time.Now(),
+	}
+
+	_, err := m.collection.InsertOne(ctx, audit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &audit, nil
+}
+
+func (m *mongoAuthAttemptAuditRepo) ReplaceAccount(ctx context.Context, account string, replacement string) error {
+	_, err := m.collection.UpdateMany(ctx, bson.M{"account": account}, bson.M{
+		"$set": bson.M{"account": replacement},
+	})
+
+	return err
+}
@@ -3,6 +3,7 @@ package mongo
 import (
 	"time"
 
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -31,3 +32,15 @@ func (f invitationFilter) WithNotExpired() {
 func (f invitationFilter) WithNotResponded() {
 	f["responded_at"] = nil
 }
+
+func (f invitationFilter) WithInviteeEmail(inviteeEmail string) {
+	f["invitee_email"] = inviteeEmail
+}
+
+func (f invitationFilter) WithStatus(status models.InvitationStatus) {
+	f["status"] = status
+}
+
+func (f invitationFilter) WithExpired() {
+	f["expired_at"] = bson.M{"$lte": time.Now()}
+}
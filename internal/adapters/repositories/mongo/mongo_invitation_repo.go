@@ -61,11 +61,31 @@ func (m *mongoInvitationRepo) FindByWorkspaceIDAndInviteeUserID(ctx context.Cont
 	return &invitation, nil
 }
 
-func (m *mongoInvitationRepo) Create(ctx context.Context, invitation *repositories.CreateInvitationRequest) error {
+func (m *mongoInvitationRepo) FindByWorkspaceIDAndInviteeEmail(ctx context.Context, workspaceID bson.ObjectID, inviteeEmail string) (*models.Invitation, error) {
+	f := NewInvitationFilter()
+	f.WithWorkspaceID(workspaceID)
+	f.WithInviteeEmail(inviteeEmail)
+	f.WithNotExpired()
+	f.WithNotResponded()
+
+	var invitation models.Invitation
+	err := m.collection.FindOne(ctx, f).Decode(&invitation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (m *mongoInvitationRepo) Create(ctx context.Context, invitation *repositories.CreateInvitationRequest) (*models.Invitation, error) {
 	newInvitation := models.Invitation{
 		ID:            bson.NewObjectID(),
 		WorkspaceID:   invitation.WorkspaceID,
 		InviteeUserID: invitation.InviteeUserID,
+		InviteeEmail:  invitation.InviteeEmail,
 		Role:          invitation.Role,
 		Status:        invitation.Status,
 		ExpiredAt:     invitation.ExpiredAt,
@@ -76,10 +96,10 @@ func (m *mongoInvitationRepo) Create(ctx context.Context, invitation *repositori
 
 	_, err := m.collection.InsertOne(ctx, newInvitation)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return &newInvitation, nil
 }
 
 func (m *mongoInvitationRepo) FindByInviteeUserID(ctx context.Context, inviteeUserID bson.ObjectID, sortBy string, order string) ([]models.Invitation, error) {
@@ -172,3 +192,65 @@ func (m *mongoInvitationRepo) SearchInvitationForEachWorkspace(ctx context.Conte
 
 	return invitations, total, nil
 }
+
+// AcceptPendingByID links the invitation to the invitee and accepts it, it reports false if the invitation was no longer pending.
+func (m *mongoInvitationRepo) AcceptPendingByID(ctx context.Context, id bson.ObjectID, inviteeUserID bson.ObjectID) (bool, error) {
+	f := NewInvitationFilter()
+	f.WithID(id)
+	f.WithStatus(models.InvitationStatusPending)
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "invitee_user_id", Value: inviteeUserID},
+			{Key: "status", Value: models.InvitationStatusAccepted},
+			{Key: "responded_at", Value: time.Now()},
+		}},
+	}
+
+	res, err := m.collection.UpdateOne(ctx, f, update)
+	if err != nil {
+		return false, err
+	}
+
+	return res.ModifiedCount > 0, nil
+}
+
+// Renew reopens the invitation with a new expiry date.
+func (m *mongoInvitationRepo) Renew(ctx context.Context, id bson.ObjectID, expiredAt time.Time) (*models.Invitation, error) {
+	f := NewInvitationFilter()
+	f.WithID(id)
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "status", Value: models.InvitationStatusPending},
+			{Key: "expired_at", Value: expiredAt},
+		}},
+	}
+
+	_, err := m.collection.UpdateOne(ctx, f, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.FindByID(ctx, id)
+}
+
+// ExpireOverdue marks every pending invitation past its expiry date as expired.
+func (m *mongoInvitationRepo) ExpireOverdue(ctx context.Context) (int64, error) {
+	f := NewInvitationFilter()
+	f.WithStatus(models.InvitationStatusPending)
+	f.WithExpired()
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "status", Value: models.InvitationStatusExpired},
+		}},
+	}
+
+	res, err := m.collection.UpdateMany(ctx, f, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
@@ -1,6 +1,8 @@
 package mongo
 
 import (
+	"time"
+
 	"github.com/cnc-csku/task-nexus/task-management/domain/models"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
@@ -89,3 +91,19 @@ func (u projectUpdate) UpdateDetail(name, description string) {
 		"description": description,
 	}
 }
+
+func (u projectUpdate) UpdateStatus(status models.ProjectStatus, updatedBy bson.ObjectID) {
+	u["$set"] = bson.M{
+		"status":     status,
+		"updated_at": time.Now(),
+		"updated_by": updatedBy,
+	}
+}
+
+func (u projectUpdate) UpdatePermissions(permissions models.ProjectPermissionMatrix, updatedBy bson.ObjectID) {
+	u["$set"] = bson.M{
+		"permissions": permissions,
+		"updated_at":  time.Now(),
+		"updated_by":  updatedBy,
+	}
+}
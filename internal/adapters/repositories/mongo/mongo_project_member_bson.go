@@ -1,6 +1,8 @@
 package mongo
 
 import (
+	"time"
+
 	"github.com/cnc-csku/task-nexus/task-management/domain/models"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
@@ -39,6 +41,11 @@ func (f projectMemberFilter) WithPositions(positions []string) {
 	}
 }
 
+// WithNotRemoved excludes members that have been removed from the project.
+func (f projectMemberFilter) WithNotRemoved() {
+	f["removed_at"] = nil
+}
+
 type projectMemberUpdate bson.M
 
 func NewProjectMemberUpdate() projectMemberUpdate {
@@ -50,3 +57,15 @@ func (u projectMemberUpdate) UpdatePosition(position string) {
 		"position": position,
 	}
 }
+
+func (u projectMemberUpdate) UpdateRole(role models.ProjectMemberRole) {
+	u["$set"] = bson.M{
+		"role": role,
+	}
+}
+
+func (u projectMemberUpdate) UpdateRemovedAt(removedAt time.Time) {
+	u["$set"] = bson.M{
+		"removed_at": removedAt,
+	}
+}
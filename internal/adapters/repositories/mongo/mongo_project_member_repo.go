@@ -83,6 +83,7 @@ func (m *mongoProjectMemberRepo) FindByID(ctx context.Context, id bson.ObjectID)
 func (m *mongoProjectMemberRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.ProjectMember, error) {
 	f := NewProjectMemberFilter()
 	f.WithUserID(userID)
+	f.WithNotRemoved()
 
 	cursor, err := m.collection.Find(ctx, f)
 	if err != nil {
@@ -100,6 +101,7 @@ func (m *mongoProjectMemberRepo) FindByUserID(ctx context.Context, userID bson.O
 func (m *mongoProjectMemberRepo) FindByProjectID(ctx context.Context, projectID bson.ObjectID) ([]*models.ProjectMember, error) {
 	f := NewProjectMemberFilter()
 	f.WithProjectID(projectID)
+	f.WithNotRemoved()
 
 	cursor, err := m.collection.Find(ctx, f)
 	if err != nil {
@@ -118,6 +120,7 @@ func (m *mongoProjectMemberRepo) FindByProjectIDAndUserID(ctx context.Context, p
 	f := NewProjectMemberFilter()
 	f.WithUserID(userID)
 	f.WithProjectID(projectID)
+	f.WithNotRemoved()
 
 	projectMember := new(models.ProjectMember)
 	err := m.collection.FindOne(ctx, f).Decode(projectMember)
@@ -174,6 +177,7 @@ func (m *mongoProjectMemberRepo) FindByProjectIDAndPositions(ctx context.Context
 	f := NewProjectMemberFilter()
 	f.WithProjectID(projectID)
 	f.WithPositions(positions)
+	f.WithNotRemoved()
 
 	cursor, err := m.collection.Find(ctx, f)
 	if err != nil {
@@ -202,3 +206,62 @@ func (m *mongoProjectMemberRepo) UpdatePositionByID(ctx context.Context, in *rep
 
 	return m.FindByID(ctx, in.ID)
 }
+
+func (m *mongoProjectMemberRepo) UpdateRoleByID(ctx context.Context, in *repositories.UpdateRoleRequest) (*models.ProjectMember, error) {
+	f := NewProjectMemberFilter()
+	f.WithID(in.ID)
+
+	u := NewProjectMemberUpdate()
+	u.UpdateRole(in.Role)
+
+	err := m.collection.FindOneAndUpdate(ctx, f, u).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.FindByID(ctx, in.ID)
+}
+
+func (m *mongoProjectMemberRepo) DeleteByProjectID(ctx context.Context, projectID bson.ObjectID) error {
+	f := NewProjectMemberFilter()
+	f.WithProjectID(projectID)
+
+	_, err := m.collection.DeleteMany(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoProjectMemberRepo) RemoveByID(ctx context.Context, id bson.ObjectID) (*models.ProjectMember, error) {
+	f := NewProjectMemberFilter()
+	f.WithID(id)
+
+	u := NewProjectMemberUpdate()
+	u.UpdateRemovedAt(time.Now())
+
+	err := m.collection.FindOneAndUpdate(ctx, f, u).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.FindByID(ctx, id)
+}
+
+func (m *mongoProjectMemberRepo) RemoveByProjectIDsAndUserID(ctx context.Context, projectIDs []bson.ObjectID, userID bson.ObjectID) error {
+	f := NewProjectMemberFilter()
+	f.WithProjectIDs(projectIDs)
+	f.WithUserID(userID)
+	f.WithNotRemoved()
+
+	u := NewProjectMemberUpdate()
+	u.UpdateRemovedAt(time.Now())
+
+	_, err := m.collection.UpdateMany(ctx, f, u)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -103,6 +103,7 @@ func (m *mongoProjectRepo) Create(ctx context.Context, project *repositories.Cre
 		Workflows:           project.Workflows,
 		AttributeTemplates:  project.AttributeTemplates,
 		Positions:           project.Positions,
+		SetupStatus:         project.SetupStatus,
 		CreatedAt:           time.Now(),
 		CreatedBy:           project.CreatedBy,
 		UpdatedAt:           time.Now(),
@@ -121,6 +122,7 @@ func (m *mongoProjectRepo) Create(ctx context.Context, project *repositories.Cre
 		UserID:    project.Owner.UserID,
 		ProjectID: newProject.ID,
 		Role:      project.Owner.Role,
+		Position:  project.Owner.Position,
 		JoinedAt:  time.Now(),
 	}
 
@@ -459,3 +461,45 @@ func (m *mongoProjectRepo) UpdateDetail(ctx context.Context, in *repositories.Up
 
 	return m.FindByProjectID(ctx, in.ProjectID)
 }
+
+func (m *mongoProjectRepo) UpdateStatus(ctx context.Context, in *repositories.UpdateProjectStatusRequest) (*models.Project, error) {
+	f := NewProjectFilter()
+	f.WithID(in.ProjectID)
+
+	u := NewProjectUpdate()
+	u.UpdateStatus(in.Status, in.UpdatedBy)
+
+	err := m.collection.FindOneAndUpdate(ctx, f, u).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.FindByProjectID(ctx, in.ProjectID)
+}
+
+func (m *mongoProjectRepo) Delete(ctx context.Context, projectID bson.ObjectID) error {
+	f := NewProjectFilter()
+	f.WithID(projectID)
+
+	_, err := m.collection.DeleteOne(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoProjectRepo) UpdatePermissions(ctx context.Context, in *repositories.UpdateProjectPermissionsRequest) (*models.Project, error) {
+	f := NewProjectFilter()
+	f.WithID(in.ProjectID)
+
+	u := NewProjectUpdate()
+	u.UpdatePermissions(in.Permissions, in.UpdatedBy)
+
+	err := m.collection.FindOneAndUpdate(ctx, f, u).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.FindByProjectID(ctx, in.ProjectID)
+}
@@ -0,0 +1,21 @@
+package mongo
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+type projectTemplateFilter bson.M
+
+func NewProjectTemplateFilter() projectTemplateFilter {
+	return projectTemplateFilter{}
+}
+
+func (f projectTemplateFilter) WithID(id bson.ObjectID) {
+	f["_id"] = id
+}
+
+func (f projectTemplateFilter) WithWorkspaceID(workspaceID bson.ObjectID) {
+	f["workspace_id"] = workspaceID
+}
+
+func (f projectTemplateFilter) WithName(name string) {
+	f["name"] = name
+}
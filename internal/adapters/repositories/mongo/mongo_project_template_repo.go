@@ -0,0 +1,118 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongoProjectTemplateRepo struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+func NewMongoProjectTemplateRepo(config *config.Config, mongoClient *mongo.Client) repositories.ProjectTemplateRepository {
+	return &mongoProjectTemplateRepo{
+		client:     mongoClient,
+		collection: mongoClient.Database(config.MongoDB.Database).Collection("project_templates"),
+	}
+}
+
+func (m *mongoProjectTemplateRepo) Create(ctx context.Context, in *repositories.CreateProjectTemplateRequest) (*models.ProjectTemplate, error) {
+	newTemplate := models.ProjectTemplate{
+		ID:                 bson.NewObjectID(),
+		WorkspaceID:        in.WorkspaceID,
+		Name:               in.Name,
+		Description:        in.Description,
+		Positions:          in.Positions,
+		Workflows:          in.Workflows,
+		AttributeTemplates: in.AttributeTemplates,
+		Epics:              in.Epics,
+		CreatedAt:          time.Now(),
+		CreatedBy:          in.CreatedBy,
+		UpdatedAt:          time.Now(),
+		UpdatedBy:          in.CreatedBy,
+	}
+
+	_, err := m.collection.InsertOne(ctx, newTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newTemplate, nil
+}
+
+func (m *mongoProjectTemplateRepo) FindByID(ctx context.Context, id bson.ObjectID) (*models.ProjectTemplate, error) {
+	template := new(models.ProjectTemplate)
+
+	f := NewProjectTemplateFilter()
+	f.WithID(id)
+
+	err := m.collection.FindOne(ctx, f).Decode(template)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (m *mongoProjectTemplateRepo) FindByWorkspaceID(ctx context.Context, workspaceID bson.ObjectID) ([]*models.ProjectTemplate, error) {
+	templates := make([]*models.ProjectTemplate, 0)
+
+	f := NewProjectTemplateFilter()
+	f.WithWorkspaceID(workspaceID)
+
+	opts := options.Find().SetSort(bson.M{"name": 1})
+
+	cursor, err := m.collection.Find(ctx, f, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &templates)
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (m *mongoProjectTemplateRepo) FindByWorkspaceIDAndName(ctx context.Context, workspaceID bson.ObjectID, name string) (*models.ProjectTemplate, error) {
+	template := new(models.ProjectTemplate)
+
+	f := NewProjectTemplateFilter()
+	f.WithWorkspaceID(workspaceID)
+	f.WithName(name)
+
+	err := m.collection.FindOne(ctx, f).Decode(template)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (m *mongoProjectTemplateRepo) Delete(ctx context.Context, id bson.ObjectID) error {
+	f := NewProjectTemplateFilter()
+	f.WithID(id)
+
+	_, err := m.collection.DeleteOne(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
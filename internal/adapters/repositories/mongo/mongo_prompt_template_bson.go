@@ -0,0 +1,20 @@
+package mongo
+
+import (
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type promptTemplateFilter bson.M
+
+func NewPromptTemplateFilter() promptTemplateFilter {
+	return promptTemplateFilter{}
+}
+
+func (f promptTemplateFilter) WithKey(key models.PromptTemplateKey) {
+	f["key"] = key
+}
+
+func (f promptTemplateFilter) WithVersion(version int) {
+	f["version"] = version
+}
@@ -0,0 +1,117 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongoPromptTemplateRepo struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+func NewMongoPromptTemplateRepo(config *config.Config, mongoClient *mongo.Client) repositories.PromptTemplateRepository {
+	collection := mongoClient.Database(config.MongoDB.Database).Collection("prompt_templates")
+
+	// Two admins saving the same key at once must not both get the same version
+	_, err := collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "key", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Fatalf("❌ Error creating prompt template index: %v\n", err)
+	}
+
+	return &mongoPromptTemplateRepo{
+		client:     mongoClient,
+		collection: collection,
+	}
+}
+
+func (m *mongoPromptTemplateRepo) Create(ctx context.Context, in *repositories.CreatePromptTemplateRequest) (*models.PromptTemplate, error) {
+	newTemplate := models.PromptTemplate{
+		ID:                  bson.NewObjectID(),
+		Key:                 in.Key,
+		Version:             in.Version,
+		Template:            in.Template,
+		RestoredFromVersion: in.RestoredFromVersion,
+		CreatedAt:           time.Now(),
+		CreatedBy:           in.CreatedBy,
+	}
+
+	_, err := m.collection.InsertOne(ctx, newTemplate)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, repositories.ErrPromptTemplateVersionExists
+		}
+		return nil, err
+	}
+
+	return &newTemplate, nil
+}
+
+func (m *mongoPromptTemplateRepo) FindLatestByKey(ctx context.Context, key models.PromptTemplateKey) (*models.PromptTemplate, error) {
+	template := new(models.PromptTemplate)
+
+	f := NewPromptTemplateFilter()
+	f.WithKey(key)
+
+	opts := options.FindOne().SetSort(bson.M{"version": -1})
+
+	err := m.collection.FindOne(ctx, f, opts).Decode(template)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (m *mongoPromptTemplateRepo) FindByKeyAndVersion(ctx context.Context, key models.PromptTemplateKey, version int) (*models.PromptTemplate, error) {
+	template := new(models.PromptTemplate)
+
+	f := NewPromptTemplateFilter()
+	f.WithKey(key)
+	f.WithVersion(version)
+
+	err := m.collection.FindOne(ctx, f).Decode(template)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (m *mongoPromptTemplateRepo) FindByKey(ctx context.Context, key models.PromptTemplateKey) ([]*models.PromptTemplate, error) {
+	templates := make([]*models.PromptTemplate, 0)
+
+	f := NewPromptTemplateFilter()
+	f.WithKey(key)
+
+	opts := options.Find().SetSort(bson.M{"version": -1})
+
+	cursor, err := m.collection.Find(ctx, f, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &templates); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
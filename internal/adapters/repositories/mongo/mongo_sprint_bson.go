@@ -52,3 +52,10 @@ func (u sprintUpdater) UpdateStatus(in *repositories.UpdateSprintStatusRequest)
 		"updated_by": in.UpdatedBy,
 	}
 }
+
+// UpdateSummary replaces the summary only, editing it is not an update of the sprint itself
+func (u sprintUpdater) UpdateSummary(in *repositories.UpdateSprintSummaryRequest) {
+	u["$set"] = bson.M{
+		"summary": in.Summary,
+	}
+}
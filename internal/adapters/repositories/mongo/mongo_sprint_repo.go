@@ -189,3 +189,30 @@ func (m *mongoSprintRepo) FindByProjectIDAndStatus(ctx context.Context, projectI
 
 	return sprints, nil
 }
+
+func (m *mongoSprintRepo) DeleteByProjectID(ctx context.Context, projectID bson.ObjectID) error {
+	f := NewSprintFilter()
+	f.WithProjectID(projectID)
+
+	_, err := m.collection.DeleteMany(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoSprintRepo) UpdateSummary(ctx context.Context, req *repositories.UpdateSprintSummaryRequest) (*models.Sprint, error) {
+	f := NewSprintFilter()
+	f.WithID(req.ID)
+
+	u := NewSprintUpdater()
+	u.UpdateSummary(req)
+
+	err := m.collection.FindOneAndUpdate(ctx, f, u).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.FindByID(ctx, req.ID)
+}
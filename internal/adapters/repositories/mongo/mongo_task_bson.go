@@ -54,6 +54,18 @@ func (f taskFilter) WithNotInStatuses(statuses []string) {
 	}
 }
 
+func (f taskFilter) WithPriorities(priorities []models.TaskPriority) {
+	f["priority"] = bson.M{
+		"$in": priorities,
+	}
+}
+
+func (f taskFilter) WithDueDateBefore(dueDate time.Time) {
+	f["due_date"] = bson.M{
+		"$lt": dueDate,
+	}
+}
+
 func (f taskFilter) WithParentID(parentID bson.ObjectID) {
 	f["parent_id"] = parentID
 }
@@ -100,6 +112,21 @@ func (f taskFilter) WithUserIDs(userIDs []bson.ObjectID) {
 	}
 }
 
+func (f taskFilter) WithAssigneeOrApprover(userID bson.ObjectID) {
+	f["$or"] = []bson.M{
+		{"assignees.user_id": userID},
+		{"approvals.user_id": userID},
+	}
+}
+
+func (f taskFilter) WithCreatorOrAssigneeOrApprover(userID bson.ObjectID) {
+	f["$or"] = []bson.M{
+		{"created_by": userID},
+		{"assignees.user_id": userID},
+		{"approvals.user_id": userID},
+	}
+}
+
 func (f taskFilter) WithPositions(positions []string) {
 	f["assignees.position"] = bson.M{
 		"$in": positions,
@@ -113,6 +140,12 @@ func (f taskFilter) WithSearchKeyword(keyword string) {
 	}
 }
 
+func (f taskFilter) WithAttributeKeys(keys []string) {
+	f["attributes.key"] = bson.M{
+		"$in": keys,
+	}
+}
+
 func (f taskFilter) WithCurrentSprintIDAndPreviousSprintIDs(sprintID bson.ObjectID) {
 	f["$or"] = []bson.M{
 		{"sprint.current_sprint_id": sprintID},
@@ -165,11 +198,19 @@ func (u taskUpdate) UpdateType(in *repositories.UpdateTaskTypeRequest) {
 }
 
 func (u taskUpdate) UpdateStatus(status string, updatedBy bson.ObjectID) {
+	now := time.Now()
 	u["$set"] = bson.M{
 		"status":     status,
-		"updated_at": time.Now(),
+		"updated_at": now,
 		"updated_by": updatedBy,
 	}
+	u["$push"] = bson.M{
+		"status_changes": models.TaskStatusChange{
+			Status:    status,
+			ChangedAt: now,
+			ChangedBy: updatedBy,
+		},
+	}
 }
 
 func (u taskUpdate) UpdateApprovals(in *repositories.UpdateTaskApprovalsRequest) {
@@ -267,3 +308,30 @@ func (u taskUpdate) UpdateStartDateAndDueDate(startDate, dueDate *time.Time, upd
 		"updated_by": updatedBy,
 	}
 }
+
+func (u taskUpdate) UpdateAssigneesAndApprovals(in *repositories.UpdateTaskAssigneesAndApprovalsRequest) {
+	assignees := make([]bson.M, len(in.Assignees))
+	for i, a := range in.Assignees {
+		assignees[i] = bson.M{
+			"position": a.Position,
+			"user_id":  a.UserID,
+			"point":    a.Point,
+		}
+	}
+
+	approvals := make([]bson.M, len(in.Approvals))
+	for i, a := range in.Approvals {
+		approvals[i] = bson.M{
+			"is_approved": a.IsApproved,
+			"reason":      a.Reason,
+			"user_id":     a.UserID,
+		}
+	}
+
+	u["$set"] = bson.M{
+		"assignees":  assignees,
+		"approvals":  approvals,
+		"updated_at": time.Now(),
+		"updated_by": in.UpdatedBy,
+	}
+}
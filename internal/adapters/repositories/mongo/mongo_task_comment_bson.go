@@ -18,10 +18,20 @@ func (f taskCommentFilter) WithIDs(ids []bson.ObjectID) {
 	}
 }
 
+func (f taskCommentFilter) WithUserID(userID bson.ObjectID) {
+	f["user_id"] = userID
+}
+
 func (f taskCommentFilter) WithTaskID(taskID bson.ObjectID) {
 	f["task_id"] = taskID
 }
 
+func (f taskCommentFilter) WithTaskIDs(taskIDs []bson.ObjectID) {
+	f["task_id"] = bson.M{
+		"$in": taskIDs,
+	}
+}
+
 type taskCommentUpdate bson.M
 
 func NewTaskCommentUpdate() taskCommentUpdate {
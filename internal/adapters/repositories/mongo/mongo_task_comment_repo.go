@@ -62,3 +62,61 @@ func (m *mongoTaskCommentRepo) FindByTaskID(ctx context.Context, taskID bson.Obj
 
 	return taskComments, nil
 }
+
+func (m *mongoTaskCommentRepo) FindByTaskIDs(ctx context.Context, taskIDs []bson.ObjectID) ([]*models.TaskComment, error) {
+	taskComments := make([]*models.TaskComment, 0)
+	if len(taskIDs) == 0 {
+		return taskComments, nil
+	}
+
+	f := NewTaskCommentFilter()
+	f.WithTaskIDs(taskIDs)
+
+	o := options.Find().SetSort(bson.M{"created_at": -1})
+
+	cursor, err := m.collection.Find(ctx, f, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &taskComments); err != nil {
+		return nil, err
+	}
+
+	return taskComments, nil
+}
+
+func (m *mongoTaskCommentRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) ([]*models.TaskComment, error) {
+	f := NewTaskCommentFilter()
+	f.WithUserID(userID)
+
+	o := options.Find().SetSort(bson.M{"created_at": 1})
+
+	cursor, err := m.collection.Find(ctx, f, o)
+	if err != nil {
+		return nil, err
+	}
+
+	taskComments := make([]*models.TaskComment, 0)
+	if err := cursor.All(ctx, &taskComments); err != nil {
+		return nil, err
+	}
+
+	return taskComments, nil
+}
+
+func (m *mongoTaskCommentRepo) DeleteByTaskIDs(ctx context.Context, taskIDs []bson.ObjectID) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	f := NewTaskCommentFilter()
+	f.WithTaskIDs(taskIDs)
+
+	_, err := m.collection.DeleteMany(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
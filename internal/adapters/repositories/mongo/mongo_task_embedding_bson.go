@@ -0,0 +1,22 @@
+package mongo
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+type taskEmbeddingFilter bson.M
+
+func NewTaskEmbeddingFilter() taskEmbeddingFilter {
+	return taskEmbeddingFilter{}
+}
+
+func (f taskEmbeddingFilter) WithTaskID(taskID bson.ObjectID) {
+	f["_id"] = taskID
+}
+
+func (f taskEmbeddingFilter) WithProjectID(projectID bson.ObjectID) {
+	f["project_id"] = projectID
+}
+
+func (f taskEmbeddingFilter) WithModel(provider string, model string) {
+	f["provider"] = provider
+	f["model"] = model
+}
@@ -0,0 +1,95 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongoTaskEmbeddingRepo struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+func NewMongoTaskEmbeddingRepo(config *config.Config, mongoClient *mongo.Client) repositories.TaskEmbeddingRepository {
+	return &mongoTaskEmbeddingRepo{
+		client:     mongoClient,
+		collection: mongoClient.Database(config.MongoDB.Database).Collection("task_embeddings"),
+	}
+}
+
+func (m *mongoTaskEmbeddingRepo) Upsert(ctx context.Context, in *repositories.UpsertTaskEmbeddingRequest) (*models.TaskEmbedding, error) {
+	taskEmbedding := &models.TaskEmbedding{
+		TaskID:    in.TaskID,
+		ProjectID: in.ProjectID,
+		Provider:  in.Provider,
+		Model:     in.Model,
+		Vector:    in.Vector,
+		UpdatedAt: time.Now(),
+	}
+
+	f := NewTaskEmbeddingFilter()
+	f.WithTaskID(in.TaskID)
+
+	_, err := m.collection.ReplaceOne(ctx, f, taskEmbedding, options.Replace().SetUpsert(true))
+	if err != nil {
+		return nil, err
+	}
+
+	return taskEmbedding, nil
+}
+
+func (m *mongoTaskEmbeddingRepo) FindByTaskID(ctx context.Context, taskID bson.ObjectID) (*models.TaskEmbedding, error) {
+	taskEmbedding := new(models.TaskEmbedding)
+
+	f := NewTaskEmbeddingFilter()
+	f.WithTaskID(taskID)
+
+	err := m.collection.FindOne(ctx, f).Decode(taskEmbedding)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return taskEmbedding, nil
+}
+
+func (m *mongoTaskEmbeddingRepo) FindByProjectIDAndModel(ctx context.Context, projectID bson.ObjectID, provider string, model string) ([]*models.TaskEmbedding, error) {
+	taskEmbeddings := make([]*models.TaskEmbedding, 0)
+
+	f := NewTaskEmbeddingFilter()
+	f.WithProjectID(projectID)
+	f.WithModel(provider, model)
+
+	cursor, err := m.collection.Find(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &taskEmbeddings); err != nil {
+		return nil, err
+	}
+
+	return taskEmbeddings, nil
+}
+
+func (m *mongoTaskEmbeddingRepo) DeleteByProjectID(ctx context.Context, projectID bson.ObjectID) error {
+	f := NewTaskEmbeddingFilter()
+	f.WithProjectID(projectID)
+
+	_, err := m.collection.DeleteMany(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
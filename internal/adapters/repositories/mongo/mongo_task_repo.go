@@ -3,6 +3,7 @@ package mongo
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/cnc-csku/task-nexus/task-management/config"
@@ -27,6 +28,7 @@ func NewMongoTaskRepo(config *config.Config, mongoClient *mongo.Client) reposito
 }
 
 func (m *mongoTaskRepo) Create(ctx context.Context, task *repositories.CreateTaskRequest) (*models.Task, error) {
+	now := time.Now()
 	newTask := models.Task{
 		ID:          bson.NewObjectID(),
 		TaskRef:     task.TaskRef,
@@ -43,10 +45,13 @@ func (m *mongoTaskRepo) Create(ctx context.Context, task *repositories.CreateTas
 		Attributes:  task.Attributes,
 		StartDate:   task.StartDate,
 		DueDate:     task.DueDate,
-		CreatedAt:   time.Now(),
-		CreatedBy:   task.CreatedBy,
-		UpdatedAt:   time.Now(),
-		UpdatedBy:   task.CreatedBy,
+		StatusChanges: []models.TaskStatusChange{
+			{Status: task.Status, ChangedAt: now, ChangedBy: task.CreatedBy},
+		},
+		CreatedAt: now,
+		CreatedBy: task.CreatedBy,
+		UpdatedAt: now,
+		UpdatedBy: task.CreatedBy,
 	}
 
 	_, err := m.collection.InsertOne(ctx, newTask)
@@ -412,7 +417,11 @@ func (m *mongoTaskRepo) Search(ctx context.Context, in *repositories.SearchTaskR
 
 	f := NewTaskFilter()
 	f.WithProjectID(in.ProjectID)
-	f.WithTypes(in.TaskTypes)
+
+	// No types means every type, a natural-language search often does not name one
+	if len(in.TaskTypes) > 0 {
+		f.WithTypes(in.TaskTypes)
+	}
 
 	if in.SprintIDs != nil {
 		f.WithCurrentSprintIDs(in.SprintIDs)
@@ -438,8 +447,26 @@ func (m *mongoTaskRepo) Search(ctx context.Context, in *repositories.SearchTaskR
 		f.WithPositions(in.Positions)
 	}
 
+	if len(in.Priorities) > 0 {
+		f.WithPriorities(in.Priorities)
+	}
+
+	statuses := in.Statuses
+	if in.IsOverdue {
+		f.WithDueDateBefore(time.Now())
+
+		// Done tasks are never overdue
+		if len(statuses) == 0 {
+			f.WithNotInStatuses(in.IsDoneStatuses)
+		} else {
+			statuses = slices.DeleteFunc(slices.Clone(statuses), func(status string) bool {
+				return slices.Contains(in.IsDoneStatuses, status)
+			})
+		}
+	}
+
 	if len(in.Statuses) > 0 {
-		f.WithStatuses(in.Statuses)
+		f.WithStatuses(statuses)
 	}
 
 	if in.SearchKeyword != nil {
@@ -609,3 +636,122 @@ func (m *mongoTaskRepo) BulkUpdateStartDateAndDueDate(ctx context.Context, in *r
 
 	return nil
 }
+
+func (m *mongoTaskRepo) FindByProjectIDAndAttributeKeys(ctx context.Context, projectID bson.ObjectID, keys []string) ([]*models.Task, error) {
+	tasks := make([]*models.Task, 0)
+
+	f := NewTaskFilter()
+	f.WithProjectID(projectID)
+	f.WithAttributeKeys(keys)
+
+	cursor, err := m.collection.Find(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (m *mongoTaskRepo) BulkUpdateAttributes(ctx context.Context, in []repositories.UpdateTaskAttributesRequest) error {
+	if len(in) == 0 {
+		return nil
+	}
+
+	writeModels := make([]mongo.WriteModel, 0, len(in))
+	for _, task := range in {
+		f := NewTaskFilter()
+		f.WithID(task.ID)
+
+		u := NewTaskUpdate()
+		u.UpdateAttributes(&task)
+
+		writeModels = append(writeModels, mongo.NewUpdateOneModel().SetFilter(f).SetUpdate(u))
+	}
+
+	_, err := m.collection.BulkWrite(ctx, writeModels)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoTaskRepo) DeleteByProjectID(ctx context.Context, projectID bson.ObjectID) error {
+	f := NewTaskFilter()
+	f.WithProjectID(projectID)
+
+	_, err := m.collection.DeleteMany(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoTaskRepo) FindByProjectIDAndAssigneeOrApprover(ctx context.Context, projectID bson.ObjectID, userID bson.ObjectID) ([]*models.Task, error) {
+	tasks := make([]*models.Task, 0)
+
+	f := NewTaskFilter()
+	f.WithProjectID(projectID)
+	f.WithAssigneeOrApprover(userID)
+
+	cursor, err := m.collection.Find(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (m *mongoTaskRepo) FindByCreatorOrAssigneeOrApprover(ctx context.Context, userID bson.ObjectID) ([]*models.Task, error) {
+	tasks := make([]*models.Task, 0)
+
+	f := NewTaskFilter()
+	f.WithCreatorOrAssigneeOrApprover(userID)
+
+	cursor, err := m.collection.Find(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (m *mongoTaskRepo) BulkUpdateAssigneesAndApprovals(ctx context.Context, in []repositories.UpdateTaskAssigneesAndApprovalsRequest) error {
+	if len(in) == 0 {
+		return nil
+	}
+
+	writeModels := make([]mongo.WriteModel, 0, len(in))
+	for _, task := range in {
+		f := NewTaskFilter()
+		f.WithID(task.ID)
+
+		u := NewTaskUpdate()
+		u.UpdateAssigneesAndApprovals(&task)
+
+		writeModels = append(writeModels, mongo.NewUpdateOneModel().SetFilter(f).SetUpdate(u))
+	}
+
+	_, err := m.collection.BulkWrite(ctx, writeModels)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
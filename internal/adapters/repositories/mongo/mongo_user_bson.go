@@ -3,6 +3,7 @@ package mongo
 import (
 	"time"
 
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
 	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
@@ -21,6 +22,27 @@ func (f userFilter) WithEmail(email string) {
 	f["email"] = email
 }
 
+func (f userFilter) WithOIDCIdentity(issuer string, subject string) {
+	f["oidc_identities"] = bson.M{
+		"$elemMatch": bson.M{
+			"issuer":  issuer,
+			"subject": subject,
+		},
+	}
+}
+
+func (f userFilter) WithTwoFactorStepBefore(step int64) {
+	f["two_factor.last_used_step"] = bson.M{"$lt": step}
+}
+
+func (f userFilter) WithRecoveryCodeHash(codeHash string) {
+	f["two_factor.recovery_code_hashes"] = codeHash
+}
+
+func (f userFilter) WithServiceAccountWorkspaceID(workspaceID bson.ObjectID) {
+	f["service_account.workspace_id"] = workspaceID
+}
+
 func (f userFilter) WithUserIDs(userIDs []bson.ObjectID) {
 	f["_id"] = bson.M{"$in": userIDs}
 }
@@ -41,3 +63,90 @@ func (u userUpdate) UpdateProfile(in *repositories.UpdateUserProfileRequest) {
 		"upated_by":            in.UpdatedBy,
 	}
 }
+
+func (u userUpdate) UpdatePassword(passwordHash string) {
+	u["$set"] = bson.M{
+		"password_hash": passwordHash,
+		"updated_at":    time.Now(),
+	}
+}
+
+func (u userUpdate) UpdateEmailVerifiedAt(verifiedAt time.Time) {
+	u["$set"] = bson.M{
+		"email_verified_at": verifiedAt,
+		"updated_at":        time.Now(),
+	}
+}
+
+func (u userUpdate) AddOIDCIdentity(link *models.OIDCLink) {
+	u["$push"] = bson.M{
+		"oidc_identities": link,
+	}
+	u["$set"] = bson.M{
+		"updated_at": time.Now(),
+	}
+}
+
+func (u userUpdate) SetTwoFactor(twoFactor *models.TwoFactor) {
+	u["$set"] = bson.M{
+		"two_factor": twoFactor,
+		"updated_at": time.Now(),
+	}
+}
+
+func (u userUpdate) RemoveTwoFactor() {
+	u["$unset"] = bson.M{
+		"two_factor": "",
+	}
+	u["$set"] = bson.M{
+		"updated_at": time.Now(),
+	}
+}
+
+func (u userUpdate) UseTwoFactorStep(step int64) {
+	u["$set"] = bson.M{
+		"two_factor.last_used_step": step,
+	}
+}
+
+func (u userUpdate) UseRecoveryCode(codeHash string) {
+	u["$pull"] = bson.M{
+		"two_factor.recovery_code_hashes": codeHash,
+	}
+}
+
+func (u userUpdate) Deactivate(deactivatedBy bson.ObjectID) {
+	u["$set"] = bson.M{
+		"deactivated_at": time.Now(),
+		"deactivated_by": deactivatedBy,
+		"updated_at":     time.Now(),
+	}
+}
+
+func (u userUpdate) Reactivate() {
+	u["$unset"] = bson.M{
+		"deactivated_at": "",
+		"deactivated_by": "",
+	}
+	u["$set"] = bson.M{
+		"updated_at": time.Now(),
+	}
+}
+
+func (u userUpdate) Anonymize(in *repositories.AnonymizeUserRequest) {
+	u["$set"] = bson.M{
+		"email":                in.Email,
+		"password_hash":        "",
+		"full_name":            in.FullName,
+		"display_name":         in.DisplayName,
+		"default_profile_url":  in.DefaultProfileUrl,
+		"uploaded_profile_url": nil,
+		"anonymized_at":        time.Now(),
+		"updated_at":           time.Now(),
+	}
+	u["$unset"] = bson.M{
+		"email_verified_at": "",
+		"oidc_identities":   "",
+		"two_factor":        "",
+	}
+}
@@ -36,6 +36,9 @@ func (m *mongoUserRepo) Create(ctx context.Context, user *repositories.CreateUse
 		FullName:          user.FullName,
 		DisplayName:       user.DisplayName,
 		DefaultProfileUrl: user.DefaultProfileUrl,
+		EmailVerifiedAt:   user.EmailVerifiedAt,
+		OIDCIdentities:    user.OIDCIdentities,
+		ServiceAccount:    user.ServiceAccount,
 		CreatedAt:         time.Now(),
 		UpdatedAt:         time.Now(),
 	}
@@ -103,6 +106,11 @@ func (m *mongoUserRepo) Search(ctx context.Context, in *repositories.SearchUserR
 		}
 	}
 
+	// Deactivated users are hidden from pickers, their history still refers to them
+	if !in.IncludeDeactivated {
+		filter["deactivated_at"] = nil
+	}
+
 	findOptions := options.Find()
 	findOptions.SetSkip(int64((in.PaginationRequest.Page - 1) * in.PaginationRequest.PageSize))
 	findOptions.SetLimit(int64(in.PaginationRequest.PageSize))
@@ -149,6 +157,11 @@ func (m *mongoUserRepo) SearchWithUserIDs(ctx context.Context, in *repositories.
 		}
 	}
 
+	// Deactivated users are hidden from pickers, their history still refers to them
+	if !in.IncludeDeactivated {
+		filter["deactivated_at"] = nil
+	}
+
 	findOptions := options.Find()
 	findOptions.SetSkip(int64((in.PaginationRequest.Page - 1) * in.PaginationRequest.PageSize))
 	findOptions.SetLimit(int64(in.PaginationRequest.PageSize))
@@ -211,3 +224,181 @@ func (m *mongoUserRepo) UpdateProfile(ctx context.Context, in *repositories.Upda
 
 	return m.FindByID(ctx, in.UserID)
 }
+
+func (m *mongoUserRepo) UpdatePassword(ctx context.Context, userID bson.ObjectID, passwordHash string) error {
+	f := NewUserFilter()
+	f.WithUserID(userID)
+
+	u := NewUserUpdate()
+	u.UpdatePassword(passwordHash)
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoUserRepo) UpdateEmailVerifiedAt(ctx context.Context, userID bson.ObjectID, verifiedAt time.Time) error {
+	f := NewUserFilter()
+	f.WithUserID(userID)
+
+	u := NewUserUpdate()
+	u.UpdateEmailVerifiedAt(verifiedAt)
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoUserRepo) FindByOIDCIdentity(ctx context.Context, issuer string, subject string) (*models.User, error) {
+	user := new(models.User)
+
+	f := NewUserFilter()
+	f.WithOIDCIdentity(issuer, subject)
+
+	err := m.collection.FindOne(ctx, f).Decode(user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (m *mongoUserRepo) AddOIDCIdentity(ctx context.Context, userID bson.ObjectID, link *models.OIDCLink) error {
+	f := NewUserFilter()
+	f.WithUserID(userID)
+
+	u := NewUserUpdate()
+	u.AddOIDCIdentity(link)
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoUserRepo) SetTwoFactor(ctx context.Context, userID bson.ObjectID, twoFactor *models.TwoFactor) error {
+	f := NewUserFilter()
+	f.WithUserID(userID)
+
+	u := NewUserUpdate()
+	u.SetTwoFactor(twoFactor)
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoUserRepo) RemoveTwoFactor(ctx context.Context, userID bson.ObjectID) error {
+	f := NewUserFilter()
+	f.WithUserID(userID)
+
+	u := NewUserUpdate()
+	u.RemoveTwoFactor()
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoUserRepo) UseTwoFactorStep(ctx context.Context, userID bson.ObjectID, step int64) (bool, error) {
+	f := NewUserFilter()
+	f.WithUserID(userID)
+	f.WithTwoFactorStepBefore(step)
+
+	u := NewUserUpdate()
+	u.UseTwoFactorStep(step)
+
+	result, err := m.collection.UpdateOne(ctx, f, u)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+func (m *mongoUserRepo) UseRecoveryCode(ctx context.Context, userID bson.ObjectID, codeHash string) (bool, error) {
+	f := NewUserFilter()
+	f.WithUserID(userID)
+	f.WithRecoveryCodeHash(codeHash)
+
+	u := NewUserUpdate()
+	u.UseRecoveryCode(codeHash)
+
+	result, err := m.collection.UpdateOne(ctx, f, u)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+func (m *mongoUserRepo) FindServiceAccountsByWorkspaceID(ctx context.Context, workspaceID bson.ObjectID) ([]*models.User, error) {
+	f := NewUserFilter()
+	f.WithServiceAccountWorkspaceID(workspaceID)
+
+	cursor, err := m.collection.Find(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	users := make([]*models.User, 0)
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (m *mongoUserRepo) Deactivate(ctx context.Context, userID bson.ObjectID, deactivatedBy bson.ObjectID) error {
+	f := NewUserFilter()
+	f.WithUserID(userID)
+
+	u := NewUserUpdate()
+	u.Deactivate(deactivatedBy)
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+
+	return err
+}
+
+func (m *mongoUserRepo) Reactivate(ctx context.Context, userID bson.ObjectID) error {
+	f := NewUserFilter()
+	f.WithUserID(userID)
+
+	u := NewUserUpdate()
+	u.Reactivate()
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+
+	return err
+}
+
+func (m *mongoUserRepo) Anonymize(ctx context.Context, in *repositories.AnonymizeUserRequest) error {
+	f := NewUserFilter()
+	f.WithUserID(in.UserID)
+
+	u := NewUserUpdate()
+	u.Anonymize(in)
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+
+	return err
+}
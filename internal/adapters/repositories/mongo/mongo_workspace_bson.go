@@ -1,6 +1,10 @@
 package mongo
 
-import "go.mongodb.org/mongo-driver/v2/bson"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
 
 type workspaceFilter bson.M
 
@@ -17,3 +21,23 @@ func (f workspaceFilter) WithWorkspaceIDs(workspaceIDs []bson.ObjectID) {
 		"$in": workspaceIDs,
 	}
 }
+
+type workspaceUpdate bson.M
+
+func NewWorkspaceUpdate() workspaceUpdate {
+	return workspaceUpdate{}
+}
+
+func (u workspaceUpdate) UpdateName(name string) {
+	u["$set"] = bson.M{
+		"name":       name,
+		"updated_at": time.Now(),
+	}
+}
+
+func (u workspaceUpdate) UpdateRequireTwoFactor(requireTwoFactor bool) {
+	u["$set"] = bson.M{
+		"require_two_factor": requireTwoFactor,
+		"updated_at":         time.Now(),
+	}
+}
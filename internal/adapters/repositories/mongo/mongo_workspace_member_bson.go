@@ -1,6 +1,11 @@
 package mongo
 
-import "go.mongodb.org/mongo-driver/v2/bson"
+import (
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
 
 type workspaceMemberFilter bson.M
 
@@ -15,3 +20,30 @@ func (f workspaceMemberFilter) WithWorkspaceID(workspaceID bson.ObjectID) {
 func (f workspaceMemberFilter) WithUserID(userID bson.ObjectID) {
 	f["user_id"] = userID
 }
+
+func (f workspaceMemberFilter) WithID(id bson.ObjectID) {
+	f["_id"] = id
+}
+
+// WithNotRemoved excludes members that have been removed from the workspace.
+func (f workspaceMemberFilter) WithNotRemoved() {
+	f["removed_at"] = nil
+}
+
+type workspaceMemberUpdate bson.M
+
+func NewWorkspaceMemberUpdate() workspaceMemberUpdate {
+	return workspaceMemberUpdate{}
+}
+
+func (u workspaceMemberUpdate) UpdateRole(role models.WorkspaceMemberRole) {
+	u["$set"] = bson.M{
+		"role": role,
+	}
+}
+
+func (u workspaceMemberUpdate) UpdateRemovedAt(removedAt time.Time) {
+	u["$set"] = bson.M{
+		"removed_at": removedAt,
+	}
+}
@@ -27,6 +27,7 @@ func NewMongoWorkspaceMemberRepo(config *config.Config, mongoClient *mongo.Clien
 func (m *mongoWorkspaceMemberRepo) FindByWorkspaceID(ctx context.Context, workspaceID bson.ObjectID) ([]models.WorkspaceMember, error) {
 	f := NewWorkspaceMemberFilter()
 	f.WithWorkspaceID(workspaceID)
+	f.WithNotRemoved()
 
 	workspaaceMembers := []models.WorkspaceMember{}
 	cursor, err := m.collection.Find(ctx, f)
@@ -62,6 +63,7 @@ func (m *mongoWorkspaceMemberRepo) Create(ctx context.Context, req *repositories
 func (m *mongoWorkspaceMemberRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) ([]models.WorkspaceMember, error) {
 	f := NewWorkspaceMemberFilter()
 	f.WithUserID(userID)
+	f.WithNotRemoved()
 
 	workspaaceMembers := []models.WorkspaceMember{}
 	cursor, err := m.collection.Find(ctx, f)
@@ -81,6 +83,7 @@ func (m *mongoWorkspaceMemberRepo) FindByWorkspaceIDAndUserID(ctx context.Contex
 	f := NewWorkspaceMemberFilter()
 	f.WithWorkspaceID(workspaceID)
 	f.WithUserID(userID)
+	f.WithNotRemoved()
 
 	var workspaceMember models.WorkspaceMember
 
@@ -94,3 +97,50 @@ func (m *mongoWorkspaceMemberRepo) FindByWorkspaceIDAndUserID(ctx context.Contex
 
 	return &workspaceMember, nil
 }
+
+func (m *mongoWorkspaceMemberRepo) FindByID(ctx context.Context, id bson.ObjectID) (*models.WorkspaceMember, error) {
+	f := NewWorkspaceMemberFilter()
+	f.WithID(id)
+
+	var workspaceMember models.WorkspaceMember
+
+	err := m.collection.FindOne(ctx, f).Decode(&workspaceMember)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &workspaceMember, nil
+}
+
+func (m *mongoWorkspaceMemberRepo) UpdateRoleByID(ctx context.Context, in *repositories.UpdateWorkspaceMemberRoleRequest) (*models.WorkspaceMember, error) {
+	f := NewWorkspaceMemberFilter()
+	f.WithID(in.ID)
+
+	u := NewWorkspaceMemberUpdate()
+	u.UpdateRole(in.Role)
+
+	err := m.collection.FindOneAndUpdate(ctx, f, u).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.FindByID(ctx, in.ID)
+}
+
+func (m *mongoWorkspaceMemberRepo) RemoveByID(ctx context.Context, id bson.ObjectID) error {
+	f := NewWorkspaceMemberFilter()
+	f.WithID(id)
+
+	u := NewWorkspaceMemberUpdate()
+	u.UpdateRemovedAt(time.Now())
+
+	_, err := m.collection.UpdateOne(ctx, f, u)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -75,3 +75,33 @@ func (m *mongoWorkspaceRepo) FindByWorkspaceIDs(ctx context.Context, workspaceID
 
 	return workspaces, nil
 }
+
+func (m *mongoWorkspaceRepo) UpdateName(ctx context.Context, in *repositories.UpdateWorkspaceNameRequest) (*models.Workspace, error) {
+	f := NewWorkspaceFilter()
+	f.WithWorkspaceID(in.WorkspaceID)
+
+	u := NewWorkspaceUpdate()
+	u.UpdateName(in.Name)
+
+	err := m.collection.FindOneAndUpdate(ctx, f, u).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.FindByID(ctx, in.WorkspaceID)
+}
+
+func (m *mongoWorkspaceRepo) UpdateRequireTwoFactor(ctx context.Context, in *repositories.UpdateWorkspaceRequireTwoFactorRequest) (*models.Workspace, error) {
+	f := NewWorkspaceFilter()
+	f.WithWorkspaceID(in.WorkspaceID)
+
+	u := NewWorkspaceUpdate()
+	u.UpdateRequireTwoFactor(in.RequireTwoFactor)
+
+	err := m.collection.FindOneAndUpdate(ctx, f, u).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.FindByID(ctx, in.WorkspaceID)
+}
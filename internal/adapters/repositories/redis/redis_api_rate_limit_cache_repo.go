@@ -0,0 +1,185 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	API_RATE_LIMIT_BUCKET_KEY_FORMAT = "api-rate-limit:%s:%s"
+
+	// apiRateLimitRedisRetryInterval is how long the in-memory buckets are used after Redis failed
+	apiRateLimitRedisRetryInterval = 30 * time.Second
+	// apiRateLimitMemorySweepSize is the number of in-memory buckets above which full buckets are dropped
+	apiRateLimitMemorySweepSize = 10000
+)
+
+// takeTokenScript refills the bucket for the time passed since its last update, then takes one token.
+// It returns whether a token was taken and the tokens left.
+var takeTokenScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local refill_per_ms = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "updated_at")
+local tokens = tonumber(bucket[1]) or capacity
+local updated_at = tonumber(bucket[2]) or now
+
+tokens = math.min(capacity, tokens + math.max(0, now - updated_at) * refill_per_ms)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated_at", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil((capacity - tokens) / refill_per_ms) + 1000)
+
+return {allowed, tostring(tokens)}
+`)
+
+type redisAPIRateLimitCacheRepo struct {
+	config *config.Config
+	client *redis.Client
+
+	// The in-memory buckets take over while Redis is unreachable, limits are then per instance
+	mu              sync.Mutex
+	memoryBuckets   map[string]*memoryTokenBucket
+	redisRetryAfter time.Time
+}
+
+type memoryTokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	capacity  float64
+	refill    float64
+}
+
+func NewRedisAPIRateLimitCacheRepo(config *config.Config, client *redis.Client) repositories.APIRateLimitCacheRepository {
+	return &redisAPIRateLimitCacheRepo{
+		config:        config,
+		client:        client,
+		memoryBuckets: make(map[string]*memoryTokenBucket),
+	}
+}
+
+func (r *redisAPIRateLimitCacheRepo) Take(ctx context.Context, in *repositories.TakeAPIRateLimitTokenRequest) (*repositories.TakeAPIRateLimitTokenResult, error) {
+	key := fmt.Sprintf(API_RATE_LIMIT_BUCKET_KEY_FORMAT, in.Group, in.Subject)
+	now := time.Now()
+
+	if r.useRedis(now) {
+		allowed, tokens, err := r.takeFromRedis(ctx, key, in, now)
+		if err == nil {
+			return buildTakeResult(allowed, tokens, in), nil
+		}
+
+		log.Printf("⚠️ Redis rate limit failed, using in-memory buckets for %s: %v", apiRateLimitRedisRetryInterval, err)
+		r.mu.Lock()
+		r.redisRetryAfter = now.Add(apiRateLimitRedisRetryInterval)
+		r.mu.Unlock()
+	}
+
+	allowed, tokens := r.takeFromMemory(key, in, now)
+
+	return buildTakeResult(allowed, tokens, in), nil
+}
+
+func (r *redisAPIRateLimitCacheRepo) useRedis(now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return now.After(r.redisRetryAfter)
+}
+
+func (r *redisAPIRateLimitCacheRepo) takeFromRedis(ctx context.Context, key string, in *repositories.TakeAPIRateLimitTokenRequest, now time.Time) (bool, float64, error) {
+	values, err := takeTokenScript.Run(ctx, r.client, []string{key}, in.Capacity, in.RefillPerSecond/1000, now.UnixMilli()).Slice()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if len(values) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit script result: %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	rawTokens, _ := values[1].(string)
+
+	tokens, err := strconv.ParseFloat(rawTokens, 64)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return allowed == 1, tokens, nil
+}
+
+func (r *redisAPIRateLimitCacheRepo) takeFromMemory(key string, in *repositories.TakeAPIRateLimitTokenRequest, now time.Time) (bool, float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.memoryBuckets) > apiRateLimitMemorySweepSize {
+		r.sweepFullMemoryBuckets(now)
+	}
+
+	bucket, ok := r.memoryBuckets[key]
+	if !ok {
+		bucket = &memoryTokenBucket{
+			tokens:    float64(in.Capacity),
+			updatedAt: now,
+		}
+		r.memoryBuckets[key] = bucket
+	}
+	bucket.capacity = float64(in.Capacity)
+	bucket.refill = in.RefillPerSecond
+
+	bucket.tokens = math.Min(bucket.capacity, bucket.tokens+math.Max(0, now.Sub(bucket.updatedAt).Seconds())*bucket.refill)
+	bucket.updatedAt = now
+
+	if bucket.tokens < 1 {
+		return false, bucket.tokens
+	}
+
+	bucket.tokens--
+
+	return true, bucket.tokens
+}
+
+// sweepFullMemoryBuckets drops the buckets that have refilled completely, they are recreated full on the next request.
+func (r *redisAPIRateLimitCacheRepo) sweepFullMemoryBuckets(now time.Time) {
+	for key, bucket := range r.memoryBuckets {
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*bucket.refill >= bucket.capacity {
+			delete(r.memoryBuckets, key)
+		}
+	}
+}
+
+func buildTakeResult(allowed bool, tokens float64, in *repositories.TakeAPIRateLimitTokenRequest) *repositories.TakeAPIRateLimitTokenResult {
+	result := &repositories.TakeAPIRateLimitTokenResult{
+		Allowed:    allowed,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: refillDuration(float64(in.Capacity)-tokens, in.RefillPerSecond),
+	}
+
+	if tokens < 1 {
+		result.RetryAfter = refillDuration(1-tokens, in.RefillPerSecond)
+	}
+
+	return result
+}
+
+func refillDuration(missingTokens float64, refillPerSecond float64) time.Duration {
+	if missingTokens <= 0 || refillPerSecond <= 0 {
+		return 0
+	}
+
+	return time.Duration(missingTokens / refillPerSecond * float64(time.Second))
+}
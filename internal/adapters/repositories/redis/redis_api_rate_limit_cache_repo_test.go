@@ -0,0 +1,97 @@
+package redis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+)
+
+func TestTakeFromMemory(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	type take struct {
+		// after is the time since start the token is taken at
+		after       time.Duration
+		wantAllowed bool
+		wantTokens  float64
+	}
+
+	tests := []struct {
+		name  string
+		in    *repositories.TakeAPIRateLimitTokenRequest
+		takes []take
+	}{
+		{
+			name: "a new bucket starts full",
+			in:   &repositories.TakeAPIRateLimitTokenRequest{Capacity: 3, RefillPerSecond: 1},
+			takes: []take{
+				{wantAllowed: true, wantTokens: 2},
+			},
+		},
+		{
+			name: "burst up to the capacity",
+			in:   &repositories.TakeAPIRateLimitTokenRequest{Capacity: 2, RefillPerSecond: 1},
+			takes: []take{
+				{wantAllowed: true, wantTokens: 1},
+				{wantAllowed: true, wantTokens: 0},
+				{wantAllowed: false, wantTokens: 0},
+			},
+		},
+		{
+			name: "refills over time",
+			in:   &repositories.TakeAPIRateLimitTokenRequest{Capacity: 1, RefillPerSecond: 2},
+			takes: []take{
+				{wantAllowed: true, wantTokens: 0},
+				{after: 250 * time.Millisecond, wantAllowed: false, wantTokens: 0.5},
+				{after: 500 * time.Millisecond, wantAllowed: true, wantTokens: 0},
+			},
+		},
+		{
+			name: "refill is capped at the capacity",
+			in:   &repositories.TakeAPIRateLimitTokenRequest{Capacity: 2, RefillPerSecond: 1},
+			takes: []take{
+				{wantAllowed: true, wantTokens: 1},
+				{after: time.Hour, wantAllowed: true, wantTokens: 1},
+			},
+		},
+		{
+			name: "a clock going backwards does not remove tokens",
+			in:   &repositories.TakeAPIRateLimitTokenRequest{Capacity: 2, RefillPerSecond: 1},
+			takes: []take{
+				{after: time.Second, wantAllowed: true, wantTokens: 1},
+				{wantAllowed: true, wantTokens: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewRedisAPIRateLimitCacheRepo(nil, nil).(*redisAPIRateLimitCacheRepo)
+
+			for i, take := range tt.takes {
+				allowed, tokens := repo.takeFromMemory("bucket", tt.in, start.Add(take.after))
+				if allowed != take.wantAllowed || math.Abs(tokens-take.wantTokens) > 1e-9 {
+					t.Fatalf("take %d: takeFromMemory() = (%v, %v), want (%v, %v)", i+1, allowed, tokens, take.wantAllowed, take.wantTokens)
+				}
+			}
+		})
+	}
+}
+
+func TestTakeFromMemorySeparatesBuckets(t *testing.T) {
+	repo := NewRedisAPIRateLimitCacheRepo(nil, nil).(*redisAPIRateLimitCacheRepo)
+	in := &repositories.TakeAPIRateLimitTokenRequest{Capacity: 1, RefillPerSecond: 1}
+	now := time.Now()
+
+	if allowed, _ := repo.takeFromMemory("first", in, now); !allowed {
+		t.Fatal("first bucket: takeFromMemory() was not allowed")
+	}
+	if allowed, _ := repo.takeFromMemory("second", in, now); !allowed {
+		t.Fatal("second bucket: takeFromMemory() was not allowed, buckets must not share tokens")
+	}
+	if allowed, _ := repo.takeFromMemory("first", in, now); allowed {
+		t.Fatal("first bucket: takeFromMemory() was allowed with an empty bucket")
+	}
+}
@@ -0,0 +1,83 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	AUTH_RATE_LIMIT_IP_ATTEMPTS_KEY_FORMAT      = "auth-rate-limit:%s:ip-attempts:%s"
+	AUTH_RATE_LIMIT_ACCOUNT_FAILURES_KEY_FORMAT = "auth-rate-limit:%s:account-failures:%s"
+	AUTH_RATE_LIMIT_ACCOUNT_LOCK_KEY_FORMAT     = "auth-rate-limit:%s:account-lock:%s"
+)
+
+// incrementInWindowScript increments and sets the expiry atomically, so a counter can never be left without one
+var incrementInWindowScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+type redisAuthRateLimitCacheRepo struct {
+	config *config.Config
+	client *redis.Client
+}
+
+func NewRedisAuthRateLimitCacheRepo(config *config.Config, client *redis.Client) repositories.AuthRateLimitCacheRepository {
+	return &redisAuthRateLimitCacheRepo{
+		config: config,
+		client: client,
+	}
+}
+
+func (r *redisAuthRateLimitCacheRepo) IncrementIPAttempts(ctx context.Context, route string, ipAddress string, window time.Duration) (int64, error) {
+	key := fmt.Sprintf(AUTH_RATE_LIMIT_IP_ATTEMPTS_KEY_FORMAT, route, ipAddress)
+
+	return r.incrementInWindow(ctx, key, window)
+}
+
+func (r *redisAuthRateLimitCacheRepo) IncrementAccountFailures(ctx context.Context, route string, account string, window time.Duration) (int64, error) {
+	key := fmt.Sprintf(AUTH_RATE_LIMIT_ACCOUNT_FAILURES_KEY_FORMAT, route, account)
+
+	return r.incrementInWindow(ctx, key, window)
+}
+
+func (r *redisAuthRateLimitCacheRepo) ResetAccountFailures(ctx context.Context, route string, account string) error {
+	key := fmt.Sprintf(AUTH_RATE_LIMIT_ACCOUNT_FAILURES_KEY_FORMAT, route, account)
+
+	return r.client.Del(ctx, key).Err()
+}
+
+func (r *redisAuthRateLimitCacheRepo) LockAccount(ctx context.Context, route string, account string, duration time.Duration) error {
+	key := fmt.Sprintf(AUTH_RATE_LIMIT_ACCOUNT_LOCK_KEY_FORMAT, route, account)
+
+	return r.client.Set(ctx, key, time.Now().Unix(), duration).Err()
+}
+
+func (r *redisAuthRateLimitCacheRepo) GetAccountLockTTL(ctx context.Context, route string, account string) (time.Duration, error) {
+	key := fmt.Sprintf(AUTH_RATE_LIMIT_ACCOUNT_LOCK_KEY_FORMAT, route, account)
+
+	ttl, err := r.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// TTL is negative when the key does not exist
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+// incrementInWindow counts in a fixed window, the expiry is only set by the first increment.
+func (r *redisAuthRateLimitCacheRepo) incrementInWindow(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return incrementInWindowScript.Run(ctx, r.client, []string{key}, window.Milliseconds()).Int64()
+}
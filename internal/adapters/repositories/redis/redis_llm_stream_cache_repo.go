@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	LLM_STREAMS_KEY_FORMAT = "llm-streams:%s"
+)
+
+// acquireStreamScript keeps the streams of a user in a sorted set scored by their expiry.
+// Expired streams are dropped before counting, so a crashed instance can not hold a slot forever.
+var acquireStreamScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+if redis.call("ZCARD", KEYS[1]) >= limit then
+	return 0
+end
+
+redis.call("ZADD", KEYS[1], now + ttl, ARGV[4])
+redis.call("PEXPIRE", KEYS[1], ttl)
+return 1
+`)
+
+type redisLLMStreamCacheRepo struct {
+	config *config.Config
+	client *redis.Client
+}
+
+func NewRedisLLMStreamCacheRepo(config *config.Config, client *redis.Client) repositories.LLMStreamCacheRepository {
+	return &redisLLMStreamCacheRepo{
+		config: config,
+		client: client,
+	}
+}
+
+func (r *redisLLMStreamCacheRepo) Acquire(ctx context.Context, userID string, streamID string, limit int, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf(LLM_STREAMS_KEY_FORMAT, userID)
+
+	acquired, err := acquireStreamScript.Run(ctx, r.client, []string{key}, time.Now().UnixMilli(), limit, ttl.Milliseconds(), streamID).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return acquired == 1, nil
+}
+
+func (r *redisLLMStreamCacheRepo) Release(ctx context.Context, userID string, streamID string) error {
+	key := fmt.Sprintf(LLM_STREAMS_KEY_FORMAT, userID)
+
+	return r.client.ZRem(ctx, key, streamID).Err()
+}
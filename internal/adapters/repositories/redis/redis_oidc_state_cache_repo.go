@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const OIDC_STATE_KEY_FORMAT = "oidc-state:%s"
+
+type redisOIDCStateCacheRepo struct {
+	config *config.Config
+	client *redis.Client
+}
+
+func NewRedisOIDCStateCacheRepo(config *config.Config, client *redis.Client) repositories.OIDCStateCacheRepository {
+	return &redisOIDCStateCacheRepo{
+		config: config,
+		client: client,
+	}
+}
+
+func (r *redisOIDCStateCacheRepo) Set(ctx context.Context, in *repositories.SetOIDCStateRequest) error {
+	key := fmt.Sprintf(OIDC_STATE_KEY_FORMAT, in.State)
+
+	return r.client.Set(ctx, key, in.Nonce, in.TTL).Err()
+}
+
+// Consume returns the nonce stored for the state and deletes it, nil means the state is unknown or expired.
+func (r *redisOIDCStateCacheRepo) Consume(ctx context.Context, state string) (*string, error) {
+	key := fmt.Sprintf(OIDC_STATE_KEY_FORMAT, state)
+
+	nonce, err := r.client.GetDel(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &nonce, nil
+}
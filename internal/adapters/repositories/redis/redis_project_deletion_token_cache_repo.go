@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const PROJECT_DELETION_TOKEN_KEY_FORMAT = "project-deletion-token:%s:%s"
+
+type redisProjectDeletionTokenCacheRepo struct {
+	config *config.Config
+	client *redis.Client
+}
+
+func NewRedisProjectDeletionTokenCacheRepo(config *config.Config, client *redis.Client) repositories.ProjectDeletionTokenCacheRepository {
+	return &redisProjectDeletionTokenCacheRepo{
+		config: config,
+		client: client,
+	}
+}
+
+func (r *redisProjectDeletionTokenCacheRepo) Set(ctx context.Context, in *repositories.SetProjectDeletionTokenRequest) error {
+	key := fmt.Sprintf(PROJECT_DELETION_TOKEN_KEY_FORMAT, in.ProjectID.Hex(), in.UserID.Hex())
+
+	return r.client.Set(ctx, key, in.Token, in.TTL).Err()
+}
+
+// GetAndDelete returns the pending token and removes it, so that a token can only be used once.
+// It returns an empty string when no token has been issued or it has expired.
+func (r *redisProjectDeletionTokenCacheRepo) GetAndDelete(ctx context.Context, projectID bson.ObjectID, userID bson.ObjectID) (string, error) {
+	key := fmt.Sprintf(PROJECT_DELETION_TOKEN_KEY_FORMAT, projectID.Hex(), userID.Hex())
+
+	token, err := r.client.GetDel(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const TASK_SUMMARY_KEY_FORMAT = "task-summary:%s:%s"
+
+type redisTaskSummaryCacheRepo struct {
+	config *config.Config
+	client *redis.Client
+}
+
+func NewRedisTaskSummaryCacheRepo(config *config.Config, client *redis.Client) repositories.TaskSummaryCacheRepository {
+	return &redisTaskSummaryCacheRepo{
+		config: config,
+		client: client,
+	}
+}
+
+func (r *redisTaskSummaryCacheRepo) Get(ctx context.Context, taskID bson.ObjectID, version string) (*models.TaskSummary, error) {
+	key := fmt.Sprintf(TASK_SUMMARY_KEY_FORMAT, taskID.Hex(), version)
+
+	value, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var summary models.TaskSummary
+	if err := json.Unmarshal(value, &summary); err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+// Set caches the summary for Cache.TaskSummaryTTL, summaries of older versions expire on their own.
+func (r *redisTaskSummaryCacheRepo) Set(ctx context.Context, in *repositories.SetTaskSummaryRequest) error {
+	key := fmt.Sprintf(TASK_SUMMARY_KEY_FORMAT, in.TaskID.Hex(), in.Version)
+
+	value, err := json.Marshal(in.Summary)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, key, value, r.config.Cache.TaskSummaryTTL).Err()
+}
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const TWO_FACTOR_CHALLENGE_KEY_FORMAT = "two-factor-challenge:%s"
+
+// recordChallengeFailureScript counts the failures of an existing challenge only, so an expired
+// challenge is not brought back without a TTL. The challenge is deleted once the limit is reached.
+var recordChallengeFailureScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+
+local failures = redis.call("INCR", KEYS[1])
+if failures >= tonumber(ARGV[1]) then
+	redis.call("DEL", KEYS[1])
+	return 0
+end
+return 1
+`)
+
+type redisTwoFactorChallengeCacheRepo struct {
+	config *config.Config
+	client *redis.Client
+}
+
+func NewRedisTwoFactorChallengeCacheRepo(config *config.Config, client *redis.Client) repositories.TwoFactorChallengeCacheRepository {
+	return &redisTwoFactorChallengeCacheRepo{
+		config: config,
+		client: client,
+	}
+}
+
+func (r *redisTwoFactorChallengeCacheRepo) Create(ctx context.Context, in *repositories.CreateTwoFactorChallengeRequest) error {
+	key := fmt.Sprintf(TWO_FACTOR_CHALLENGE_KEY_FORMAT, in.ChallengeID)
+
+	return r.client.Set(ctx, key, 0, in.TTL).Err()
+}
+
+func (r *redisTwoFactorChallengeCacheRepo) Exists(ctx context.Context, challengeID string) (bool, error) {
+	key := fmt.Sprintf(TWO_FACTOR_CHALLENGE_KEY_FORMAT, challengeID)
+
+	count, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
+
+func (r *redisTwoFactorChallengeCacheRepo) RecordFailure(ctx context.Context, challengeID string, maxFailures int) (bool, error) {
+	key := fmt.Sprintf(TWO_FACTOR_CHALLENGE_KEY_FORMAT, challengeID)
+
+	active, err := recordChallengeFailureScript.Run(ctx, r.client, []string{key}, maxFailures).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return active == 1, nil
+}
+
+func (r *redisTwoFactorChallengeCacheRepo) Consume(ctx context.Context, challengeID string) (bool, error) {
+	key := fmt.Sprintf(TWO_FACTOR_CHALLENGE_KEY_FORMAT, challengeID)
+
+	count, err := r.client.Del(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
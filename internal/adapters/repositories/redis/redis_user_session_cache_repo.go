@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// The revocation time is stored in milliseconds, so tokens issued in the same second as the revocation can be told apart
+const USER_SESSION_REVOKED_AT_KEY_FORMAT = "user-session-revoked-at-ms:%s"
+
+type redisUserSessionCacheRepo struct {
+	config *config.Config
+	client *redis.Client
+}
+
+func NewRedisUserSessionCacheRepo(config *config.Config, client *redis.Client) repositories.UserSessionCacheRepository {
+	return &redisUserSessionCacheRepo{
+		config: config,
+		client: client,
+	}
+}
+
+func (r *redisUserSessionCacheRepo) SetRevokedAt(ctx context.Context, in *repositories.SetUserSessionRevokedAtRequest) error {
+	key := fmt.Sprintf(USER_SESSION_REVOKED_AT_KEY_FORMAT, in.UserID.Hex())
+
+	return r.client.Set(ctx, key, in.RevokedAt.UnixMilli(), in.TTL).Err()
+}
+
+// GetRevokedAt returns nil when the user's sessions have not been revoked.
+func (r *redisUserSessionCacheRepo) GetRevokedAt(ctx context.Context, userID bson.ObjectID) (*time.Time, error) {
+	key := fmt.Sprintf(USER_SESSION_REVOKED_AT_KEY_FORMAT, userID.Hex())
+
+	revokedAtMilli, err := r.client.Get(ctx, key).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	revokedAt := time.UnixMilli(revokedAtMilli)
+
+	return &revokedAt, nil
+}
@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus-go-lib/utils/tokenutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+type AccessTokenHandler interface {
+	Create(c echo.Context) error
+	List(c echo.Context) error
+	Revoke(c echo.Context) error
+}
+
+type accessTokenHandlerImpl struct {
+	accessTokenService services.AccessTokenService
+}
+
+func NewAccessTokenHandler(accessTokenService services.AccessTokenService) AccessTokenHandler {
+	return &accessTokenHandlerImpl{
+		accessTokenService: accessTokenService,
+	}
+}
+
+func (a *accessTokenHandlerImpl) Create(c echo.Context) error {
+	req := new(requests.CreateAccessTokenRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := a.accessTokenService.Create(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (a *accessTokenHandlerImpl) List(c echo.Context) error {
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := a.accessTokenService.List(c.Request().Context(), userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (a *accessTokenHandlerImpl) Revoke(c echo.Context) error {
+	req := new(requests.RevokeAccessTokenRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := a.accessTokenService.Revoke(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -16,6 +16,8 @@ type InvitationHandler interface {
 	ListForUser(c echo.Context) error
 	ListForWorkspaceOwner(c echo.Context) error
 	UserResponse(c echo.Context) error
+	Revoke(c echo.Context) error
+	Resend(c echo.Context) error
 }
 
 type invitationHandlerImpl struct {
@@ -90,3 +92,41 @@ func (u *invitationHandlerImpl) UserResponse(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, res)
 }
+
+func (u *invitationHandlerImpl) Revoke(c echo.Context) error {
+	req := new(requests.RevokeInvitationRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	res, err := u.invitationService.Revoke(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *invitationHandlerImpl) Resend(c echo.Context) error {
+	req := new(requests.ResendInvitationRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	res, err := u.invitationService.Resend(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
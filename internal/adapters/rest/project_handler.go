@@ -25,6 +25,11 @@ type ProjectHandler interface {
 	ListWorkflows(c echo.Context) error
 	UpdateAttributeTemplates(c echo.Context) error
 	ListAttributeTemplates(c echo.Context) error
+	Archive(c echo.Context) error
+	Unarchive(c echo.Context) error
+	RequestDeletion(c echo.Context) error
+	Delete(c echo.Context) error
+	TransferOwnership(c echo.Context) error
 }
 
 type projectHandlerImpl struct {
@@ -279,3 +284,98 @@ func (u *projectHandlerImpl) ListAttributeTemplates(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, attributeTemplates)
 }
+
+func (u *projectHandlerImpl) Archive(c echo.Context) error {
+	req := new(requests.ArchiveProjectPathParams)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	res, err := u.projectService.Archive(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *projectHandlerImpl) Unarchive(c echo.Context) error {
+	req := new(requests.UnarchiveProjectPathParams)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	res, err := u.projectService.Unarchive(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *projectHandlerImpl) RequestDeletion(c echo.Context) error {
+	req := new(requests.RequestProjectDeletionPathParams)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	res, err := u.projectService.RequestDeletion(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *projectHandlerImpl) Delete(c echo.Context) error {
+	req := new(requests.DeleteProjectRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	res, err := u.projectService.Delete(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *projectHandlerImpl) TransferOwnership(c echo.Context) error {
+	req := new(requests.TransferProjectOwnershipRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	res, err := u.projectService.TransferOwnership(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
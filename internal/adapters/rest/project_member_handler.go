@@ -13,6 +13,8 @@ import (
 
 type ProjectMemberHandler interface {
 	UpdatePosition(c echo.Context) error
+	UpdateRole(c echo.Context) error
+	Remove(c echo.Context) error
 }
 
 type projectMemberHandlerImpl struct {
@@ -43,3 +45,41 @@ func (u *projectMemberHandlerImpl) UpdatePosition(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, projectMember)
 }
+
+func (u *projectMemberHandlerImpl) UpdateRole(c echo.Context) error {
+	req := new(requests.UpdateMemberRoleRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	projectMember, err := u.projectMemberService.UpdateRole(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, projectMember)
+}
+
+func (u *projectMemberHandlerImpl) Remove(c echo.Context) error {
+	req := new(requests.RemoveMemberRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	res, err := u.projectMemberService.Remove(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus-go-lib/utils/tokenutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+type ProjectPermissionHandler interface {
+	List(c echo.Context) error
+	Update(c echo.Context) error
+}
+
+type projectPermissionHandlerImpl struct {
+	projectPermissionService services.ProjectPermissionService
+}
+
+func NewProjectPermissionHandler(projectPermissionService services.ProjectPermissionService) ProjectPermissionHandler {
+	return &projectPermissionHandlerImpl{
+		projectPermissionService: projectPermissionService,
+	}
+}
+
+func (p *projectPermissionHandlerImpl) List(c echo.Context) error {
+	req := new(requests.ListProjectPermissionsPathParams)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	permissions, err := p.projectPermissionService.List(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, permissions)
+}
+
+func (p *projectPermissionHandlerImpl) Update(c echo.Context) error {
+	req := new(requests.UpdateProjectPermissionsRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	permissions, err := p.projectPermissionService.Update(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, permissions)
+}
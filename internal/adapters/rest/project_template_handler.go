@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus-go-lib/utils/tokenutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+type ProjectTemplateHandler interface {
+	Create(c echo.Context) error
+	List(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type projectTemplateHandlerImpl struct {
+	projectTemplateService services.ProjectTemplateService
+}
+
+func NewProjectTemplateHandler(projectTemplateService services.ProjectTemplateService) ProjectTemplateHandler {
+	return &projectTemplateHandlerImpl{
+		projectTemplateService: projectTemplateService,
+	}
+}
+
+func (p *projectTemplateHandlerImpl) Create(c echo.Context) error {
+	req := new(requests.CreateProjectTemplateRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	template, err := p.projectTemplateService.Create(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusCreated, template)
+}
+
+func (p *projectTemplateHandlerImpl) List(c echo.Context) error {
+	req := new(requests.ListProjectTemplatesPathParams)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	templates, err := p.projectTemplateService.List(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, templates)
+}
+
+func (p *projectTemplateHandlerImpl) Delete(c echo.Context) error {
+	req := new(requests.DeleteProjectTemplateRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := p.projectTemplateService.Delete(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
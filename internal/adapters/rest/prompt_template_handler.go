@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus-go-lib/utils/tokenutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+type PromptTemplateHandler interface {
+	List(c echo.Context) error
+	ListVersions(c echo.Context) error
+	Update(c echo.Context) error
+	Preview(c echo.Context) error
+	Rollback(c echo.Context) error
+}
+
+type promptTemplateHandlerImpl struct {
+	promptTemplateService services.PromptTemplateService
+}
+
+func NewPromptTemplateHandler(promptTemplateService services.PromptTemplateService) PromptTemplateHandler {
+	return &promptTemplateHandlerImpl{
+		promptTemplateService: promptTemplateService,
+	}
+}
+
+func (p *promptTemplateHandlerImpl) List(c echo.Context) error {
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := p.promptTemplateService.List(c.Request().Context(), userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (p *promptTemplateHandlerImpl) ListVersions(c echo.Context) error {
+	req := new(requests.ListPromptTemplateVersionsRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := p.promptTemplateService.ListVersions(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (p *promptTemplateHandlerImpl) Update(c echo.Context) error {
+	req := new(requests.UpdatePromptTemplateRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := p.promptTemplateService.Update(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (p *promptTemplateHandlerImpl) Preview(c echo.Context) error {
+	req := new(requests.PreviewPromptTemplateRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := p.promptTemplateService.Preview(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (p *promptTemplateHandlerImpl) Rollback(c echo.Context) error {
+	req := new(requests.RollbackPromptTemplateRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := p.promptTemplateService.Rollback(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus-go-lib/utils/tokenutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+type ServiceAccountHandler interface {
+	Create(c echo.Context) error
+	List(c echo.Context) error
+	CreateToken(c echo.Context) error
+	ListTokens(c echo.Context) error
+	RevokeToken(c echo.Context) error
+}
+
+type serviceAccountHandlerImpl struct {
+	serviceAccountService services.ServiceAccountService
+}
+
+func NewServiceAccountHandler(serviceAccountService services.ServiceAccountService) ServiceAccountHandler {
+	return &serviceAccountHandlerImpl{
+		serviceAccountService: serviceAccountService,
+	}
+}
+
+func (s *serviceAccountHandlerImpl) Create(c echo.Context) error {
+	req := new(requests.CreateServiceAccountRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := s.serviceAccountService.Create(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (s *serviceAccountHandlerImpl) List(c echo.Context) error {
+	req := new(requests.ListServiceAccountsRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := s.serviceAccountService.List(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (s *serviceAccountHandlerImpl) CreateToken(c echo.Context) error {
+	req := new(requests.CreateServiceAccountTokenRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := s.serviceAccountService.CreateToken(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (s *serviceAccountHandlerImpl) ListTokens(c echo.Context) error {
+	req := new(requests.ListServiceAccountTokensRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := s.serviceAccountService.ListTokens(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (s *serviceAccountHandlerImpl) RevokeToken(c echo.Context) error {
+	req := new(requests.RevokeServiceAccountTokenRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := s.serviceAccountService.RevokeToken(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
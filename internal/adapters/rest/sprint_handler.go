@@ -19,6 +19,8 @@ type SprintHandler interface {
 	CompleteSprint(c echo.Context) error
 	UpdateStatus(c echo.Context) error
 	Delete(c echo.Context) error
+	GenerateSummary(c echo.Context) error
+	EditSummary(c echo.Context) error
 }
 
 type sprintHandlerImpl struct {
@@ -163,3 +165,41 @@ func (h *sprintHandlerImpl) Delete(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, resp)
 }
+
+func (h *sprintHandlerImpl) GenerateSummary(c echo.Context) error {
+	req := new(requests.GenerateSprintSummaryRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	sprint, err := h.sprintService.GenerateSummary(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, sprint)
+}
+
+func (h *sprintHandlerImpl) EditSummary(c echo.Context) error {
+	req := new(requests.EditSprintSummaryRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	sprint, err := h.sprintService.EditSummary(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, sprint)
+}
@@ -14,6 +14,7 @@ import (
 type TaskCommentHandler interface {
 	Create(c echo.Context) error
 	List(c echo.Context) error
+	Summarize(c echo.Context) error
 }
 
 type taskCommentHandlerImpl struct {
@@ -65,3 +66,22 @@ func (h *taskCommentHandlerImpl) List(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, taskComments)
 }
+
+func (h *taskCommentHandlerImpl) Summarize(c echo.Context) error {
+	req := new(requests.SummarizeTaskRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	resp, err := h.taskCommentService.Summarize(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
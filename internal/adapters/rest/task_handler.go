@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
@@ -17,6 +19,7 @@ type TaskHandler interface {
 	GetManyTaskDetail(c echo.Context) error
 	ListEpicTasks(c echo.Context) error
 	SearchTask(c echo.Context) error
+	NaturalLanguageSearch(c echo.Context) error
 	GetChildrenTasks(c echo.Context) error
 	UpdateDetail(c echo.Context) error
 	UpdateTitle(c echo.Context) error
@@ -29,6 +32,11 @@ type TaskHandler interface {
 	UpdateSprint(c echo.Context) error
 	UpdateAttributes(c echo.Context) error
 	GenerateDescription(c echo.Context) error
+	StreamDescription(c echo.Context) error
+	ProposeSubTasks(c echo.Context) error
+	AcceptSubTasks(c echo.Context) error
+	GetPossibleDuplicates(c echo.Context) error
+	EstimatePoints(c echo.Context) error
 }
 
 type taskHandlerImpl struct {
@@ -136,6 +144,25 @@ func (h *taskHandlerImpl) SearchTask(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+func (h *taskHandlerImpl) NaturalLanguageSearch(c echo.Context) error {
+	req := new(requests.NaturalLanguageSearchTaskRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	resp, err := h.taskService.NaturalLanguageSearch(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
 func (h *taskHandlerImpl) GetChildrenTasks(c echo.Context) error {
 	req := new(requests.GetChildrenTasksParams)
 	if err := c.Bind(req); err != nil {
@@ -363,3 +390,131 @@ func (h *taskHandlerImpl) GenerateDescription(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, resp)
 }
+
+// StreamDescription sends the description as server-sent events: "chunk" events with the text as it is generated,
+// then a "done" event with the description blocks or an "error" event.
+func (h *taskHandlerImpl) StreamDescription(c echo.Context) error {
+	req := new(requests.GenerateDescriptionRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	// The headers are written with the first event so errors before it are sent as usual
+	started := false
+	send := func(event string, data any) error {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		res := c.Response()
+		if !started {
+			res.Header().Set(echo.HeaderContentType, "text/event-stream")
+			res.Header().Set(echo.HeaderCacheControl, "no-cache")
+			res.Header().Set(echo.HeaderConnection, "keep-alive")
+			res.Header().Set("X-Accel-Buffering", "no")
+			res.WriteHeader(http.StatusOK)
+			started = true
+		}
+
+		if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+			return err
+		}
+		res.Flush()
+
+		return nil
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	resp, err := h.taskService.StreamDescription(c.Request().Context(), req, userClaims.ID, func(chunk string) error {
+		return send("chunk", map[string]string{"text": chunk})
+	})
+	if err != nil {
+		if !started {
+			return err.ToEchoError()
+		}
+		return send("error", errutils.RestErrorResponse{Status: err.Status.String(), Message: err.Message})
+	}
+
+	return send("done", resp)
+}
+
+func (h *taskHandlerImpl) ProposeSubTasks(c echo.Context) error {
+	req := new(requests.ProposeSubTasksRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	resp, err := h.taskService.ProposeSubTasks(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *taskHandlerImpl) AcceptSubTasks(c echo.Context) error {
+	req := new(requests.AcceptSubTasksRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	resp, err := h.taskService.AcceptSubTasks(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *taskHandlerImpl) GetPossibleDuplicates(c echo.Context) error {
+	req := new(requests.GetPossibleDuplicatesRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	resp, err := h.taskService.GetPossibleDuplicates(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *taskHandlerImpl) EstimatePoints(c echo.Context) error {
+	req := new(requests.EstimatePointsRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	resp, err := h.taskService.EstimatePoints(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
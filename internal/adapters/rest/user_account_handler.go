@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus-go-lib/utils/tokenutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/requests"
+	"github.com/cnc-csku/task-nexus/task-management/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+type UserAccountHandler interface {
+	Deactivate(c echo.Context) error
+	Reactivate(c echo.Context) error
+	Anonymize(c echo.Context) error
+	Export(c echo.Context) error
+}
+
+type userAccountHandlerImpl struct {
+	userAccountService services.UserAccountService
+}
+
+func NewUserAccountHandler(userAccountService services.UserAccountService) UserAccountHandler {
+	return &userAccountHandlerImpl{
+		userAccountService: userAccountService,
+	}
+}
+
+func (u *userAccountHandlerImpl) Deactivate(c echo.Context) error {
+	req := new(requests.DeactivateUserRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := u.userAccountService.Deactivate(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *userAccountHandlerImpl) Reactivate(c echo.Context) error {
+	req := new(requests.ReactivateUserRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := u.userAccountService.Reactivate(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *userAccountHandlerImpl) Anonymize(c echo.Context) error {
+	req := new(requests.AnonymizeUserRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := u.userAccountService.Anonymize(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *userAccountHandlerImpl) Export(c echo.Context) error {
+	req := new(requests.ExportUserDataRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := u.userAccountService.Export(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", res.FileName))
+
+	return c.Blob(http.StatusOK, res.ContentType, res.Content)
+}
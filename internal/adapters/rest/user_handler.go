@@ -19,6 +19,14 @@ type UserHandler interface {
 	UpdateProfile(c echo.Context) error
 	SetupUser(c echo.Context) error
 	GetUserProfile(c echo.Context) error
+	ChangePassword(c echo.Context) error
+	ForgotPassword(c echo.Context) error
+	ResetPassword(c echo.Context) error
+	VerifyEmail(c echo.Context) error
+	SendVerificationEmail(c echo.Context) error
+	GetOIDCAuthorizationURL(c echo.Context) error
+	LoginWithOIDC(c echo.Context) error
+	LoginWithTwoFactor(c echo.Context) error
 }
 
 type userHandlerImpl struct {
@@ -151,3 +159,131 @@ func (u *userHandlerImpl) GetUserProfile(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, user)
 }
+
+func (u *userHandlerImpl) ChangePassword(c echo.Context) error {
+	req := new(requests.ChangePasswordRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	res, err := u.userService.ChangePassword(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *userHandlerImpl) ForgotPassword(c echo.Context) error {
+	req := new(requests.ForgotPasswordRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := u.userService.ForgotPassword(c.Request().Context(), req)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *userHandlerImpl) ResetPassword(c echo.Context) error {
+	req := new(requests.ResetPasswordRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := u.userService.ResetPassword(c.Request().Context(), req)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *userHandlerImpl) VerifyEmail(c echo.Context) error {
+	req := new(requests.VerifyEmailRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := u.userService.VerifyEmail(c.Request().Context(), req)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *userHandlerImpl) SendVerificationEmail(c echo.Context) error {
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+	res, err := u.userService.SendVerificationEmail(c.Request().Context(), userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *userHandlerImpl) GetOIDCAuthorizationURL(c echo.Context) error {
+	res, err := u.userService.GetOIDCAuthorizationURL(c.Request().Context())
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *userHandlerImpl) LoginWithOIDC(c echo.Context) error {
+	req := new(requests.OIDCLoginRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := u.userService.LoginWithOIDC(c.Request().Context(), req)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (u *userHandlerImpl) LoginWithTwoFactor(c echo.Context) error {
+	req := new(requests.LoginWithTwoFactorRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := u.userService.LoginWithTwoFactor(c.Request().Context(), req)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
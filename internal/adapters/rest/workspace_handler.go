@@ -15,6 +15,12 @@ type WorkspaceHandler interface {
 	SetupWorkspace(c echo.Context) error
 	ListOwnWorkspace(c echo.Context) error
 	ListWorkspaceMembers(c echo.Context) error
+	Create(c echo.Context) error
+	Rename(c echo.Context) error
+	UpdateMemberRole(c echo.Context) error
+	RemoveMember(c echo.Context) error
+	TransferOwnership(c echo.Context) error
+	UpdateTwoFactorRequirement(c echo.Context) error
 }
 
 type workspaceHandlerImpl struct {
@@ -71,3 +77,123 @@ func (w *workspaceHandlerImpl) ListWorkspaceMembers(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, members)
 }
+
+func (w *workspaceHandlerImpl) Create(c echo.Context) error {
+	req := new(requests.CreateWorkspaceRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	workspace, err := w.workspaceService.Create(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusCreated, workspace)
+}
+
+func (w *workspaceHandlerImpl) Rename(c echo.Context) error {
+	req := new(requests.RenameWorkspaceRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	workspace, err := w.workspaceService.Rename(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, workspace)
+}
+
+func (w *workspaceHandlerImpl) UpdateMemberRole(c echo.Context) error {
+	req := new(requests.UpdateWorkspaceMemberRoleRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	member, err := w.workspaceService.UpdateMemberRole(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, member)
+}
+
+func (w *workspaceHandlerImpl) RemoveMember(c echo.Context) error {
+	req := new(requests.RemoveWorkspaceMemberRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := w.workspaceService.RemoveMember(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (w *workspaceHandlerImpl) TransferOwnership(c echo.Context) error {
+	req := new(requests.TransferWorkspaceOwnershipRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	res, err := w.workspaceService.TransferOwnership(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (w *workspaceHandlerImpl) UpdateTwoFactorRequirement(c echo.Context) error {
+	req := new(requests.UpdateWorkspaceTwoFactorRequirementRequest)
+	if err := c.Bind(req); err != nil {
+		return errutils.NewError(err, errutils.BadRequest).ToEchoError()
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+	workspace, err := w.workspaceService.UpdateTwoFactorRequirement(c.Request().Context(), req, userClaims.ID)
+	if err != nil {
+		return err.ToEchoError()
+	}
+
+	return c.JSON(http.StatusOK, workspace)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cnc-csku/task-nexus/task-management/docs"
 	"github.com/cnc-csku/task-nexus/task-management/domain/constant"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/router"
+	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/scheduler"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
@@ -24,6 +25,7 @@ type EchoAPI struct {
 	config      *config.Config
 	mongoClient *mongo.Client
 	router      *router.Router
+	scheduler   *scheduler.Scheduler
 }
 
 func NewEchoAPI(
@@ -31,6 +33,7 @@ func NewEchoAPI(
 	config *config.Config,
 	mongoClient *mongo.Client,
 	router *router.Router,
+	scheduler *scheduler.Scheduler,
 ) *EchoAPI {
 	return &EchoAPI{
 		echo:        echo.New(),
@@ -38,6 +41,7 @@ func NewEchoAPI(
 		config:      config,
 		mongoClient: mongoClient,
 		router:      router,
+		scheduler:   scheduler,
 	}
 }
 
@@ -86,6 +90,9 @@ func (a *EchoAPI) Start(logger *logrus.Logger) error {
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
+	// Start background jobs
+	a.scheduler.Start(a.ctx)
+
 	err := e.Start(":" + a.config.RestServer.Port)
 	if err != nil {
 
@@ -10,7 +10,9 @@ import (
 )
 
 type GeminiClient struct {
-	Client *genai.GenerativeModel
+	Client *genai.Client
+	// Model is the name of the model used for every request
+	Model string
 }
 
 func NewGeminiClient(
@@ -30,9 +32,7 @@ func NewGeminiClient(
 		cfg.GeminiClient.Model = "gemini-2.0-flash"
 	}
 
-	model := client.GenerativeModel(cfg.GeminiClient.Model)
-
 	log.Printf("✅ connected to Gemini model: %s", cfg.GeminiClient.Model)
 
-	return &GeminiClient{Client: model}
+	return &GeminiClient{Client: client, Model: cfg.GeminiClient.Model}
 }
@@ -30,10 +30,12 @@ func NewOllamaClient(ctx context.Context, cfg *config.Config) *OllamaClient {
 		}
 		httpClient = &http.Client{
 			Transport: transport,
+			Timeout:   cfg.OllamaClient.Timeout,
 		}
 	} else {
 		httpClient = &http.Client{
 			Transport: http.DefaultTransport,
+			Timeout:   cfg.OllamaClient.Timeout,
 		}
 	}
 
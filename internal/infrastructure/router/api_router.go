@@ -1,6 +1,8 @@
 package router
 
 import (
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/middlewares"
 	"github.com/labstack/echo/v4"
 )
 
@@ -16,11 +18,35 @@ func (r *Router) RegisterAPIRouter(e *echo.Echo) {
 
 	auth := api.Group("/auth/v1")
 	{
-		auth.POST("/register", r.user.Register)
-		auth.POST("/login", r.user.Login)
+		auth.POST("/register", r.user.Register, r.authRateLimitMiddleware.Limit(middlewares.AuthRateLimitRouteRegister))
+		auth.POST("/login", r.user.Login, r.authRateLimitMiddleware.Limit(middlewares.AuthRateLimitRouteLogin))
+		auth.POST("/login/two-factor", r.user.LoginWithTwoFactor, r.authRateLimitMiddleware.Limit(middlewares.AuthRateLimitRouteTwoFactor))
 		auth.GET("/profile", r.user.GetMyProfile, r.authMiddleware.Middleware)
 		auth.GET("/search", r.user.SearchUser, r.authMiddleware.Middleware)
 		auth.PUT("/profile", r.user.UpdateProfile, r.authMiddleware.Middleware)
+		auth.PUT("/password", r.user.ChangePassword, r.authMiddleware.Middleware)
+		auth.POST("/forgot-password", r.user.ForgotPassword)
+		auth.POST("/reset-password", r.user.ResetPassword)
+		auth.POST("/verify-email", r.user.VerifyEmail)
+		auth.POST("/verification-email", r.user.SendVerificationEmail, r.authMiddleware.Middleware)
+		auth.GET("/oidc/authorize", r.user.GetOIDCAuthorizationURL)
+		auth.POST("/oidc/callback", r.user.LoginWithOIDC)
+		auth.GET("/export", r.userAccount.Export, r.authMiddleware.Middleware)
+	}
+
+	twoFactor := api.Group("/auth/v1/two-factor")
+	{
+		twoFactor.POST("/enroll", r.twoFactor.Enroll, r.authMiddleware.Middleware)
+		twoFactor.POST("/enable", r.twoFactor.Enable, r.authMiddleware.Middleware)
+		twoFactor.POST("/disable", r.twoFactor.Disable, r.authMiddleware.Middleware)
+		twoFactor.POST("/recovery-codes", r.twoFactor.RegenerateRecoveryCodes, r.authMiddleware.Middleware)
+	}
+
+	accessTokens := api.Group("/auth/v1/access-tokens")
+	{
+		accessTokens.POST("", r.accessToken.Create, r.authMiddleware.Middleware)
+		accessTokens.GET("", r.accessToken.List, r.authMiddleware.Middleware)
+		accessTokens.DELETE("/:tokenId", r.accessToken.Revoke, r.authMiddleware.Middleware)
 	}
 
 	users := api.Group("/users/v1")
@@ -28,11 +54,42 @@ func (r *Router) RegisterAPIRouter(e *echo.Echo) {
 		users.GET("/:userId/profile", r.user.GetUserProfile, r.authMiddleware.Middleware)
 	}
 
+	adminUsers := api.Group("/admin/v1/users")
+	{
+		adminUsers.PUT("/:userId/deactivate", r.userAccount.Deactivate, r.authMiddleware.Middleware)
+		adminUsers.PUT("/:userId/reactivate", r.userAccount.Reactivate, r.authMiddleware.Middleware)
+		adminUsers.PUT("/:userId/anonymize", r.userAccount.Anonymize, r.authMiddleware.Middleware)
+		adminUsers.GET("/:userId/export", r.userAccount.Export, r.authMiddleware.Middleware)
+	}
+
+	adminPromptTemplates := api.Group("/admin/v1/prompt-templates")
+	{
+		adminPromptTemplates.GET("", r.promptTemplate.List, r.authMiddleware.Middleware)
+		adminPromptTemplates.GET("/:key/versions", r.promptTemplate.ListVersions, r.authMiddleware.Middleware)
+		adminPromptTemplates.PUT("/:key", r.promptTemplate.Update, r.authMiddleware.Middleware)
+		adminPromptTemplates.POST("/:key/preview", r.promptTemplate.Preview, r.authMiddleware.Middleware)
+		adminPromptTemplates.POST("/:key/rollback", r.promptTemplate.Rollback, r.authMiddleware.Middleware)
+	}
+
 	workspaces := api.Group("/workspaces/v1")
 	{
+		workspaces.POST("", r.workspace.Create, r.authMiddleware.Middleware)
 		workspaces.GET("/own-workspaces", r.workspace.ListOwnWorkspace, r.authMiddleware.Middleware)
+		workspaces.PUT("/:workspaceId/name", r.workspace.Rename, r.authMiddleware.Middleware)
+		workspaces.PUT("/:workspaceId/owner", r.workspace.TransferOwnership, r.authMiddleware.Middleware)
+		workspaces.PUT("/:workspaceId/two-factor", r.workspace.UpdateTwoFactorRequirement, r.authMiddleware.Middleware)
 		workspaces.GET("/:workspaceId/members", r.workspace.ListWorkspaceMembers, r.authMiddleware.Middleware)
+		workspaces.PUT("/:workspaceId/members/role", r.workspace.UpdateMemberRole, r.authMiddleware.Middleware)
+		workspaces.DELETE("/:workspaceId/members/:userId", r.workspace.RemoveMember, r.authMiddleware.Middleware)
 		workspaces.GET("/:workspaceId/my-projects", r.project.ListMyProjects, r.authMiddleware.Middleware)
+		workspaces.POST("/:workspaceId/project-templates", r.projectTemplate.Create, r.authMiddleware.Middleware)
+		workspaces.GET("/:workspaceId/project-templates", r.projectTemplate.List, r.authMiddleware.Middleware)
+		workspaces.DELETE("/:workspaceId/project-templates/:templateId", r.projectTemplate.Delete, r.authMiddleware.Middleware)
+		workspaces.POST("/:workspaceId/service-accounts", r.serviceAccount.Create, r.authMiddleware.Middleware)
+		workspaces.GET("/:workspaceId/service-accounts", r.serviceAccount.List, r.authMiddleware.Middleware)
+		workspaces.POST("/:workspaceId/service-accounts/:serviceAccountId/tokens", r.serviceAccount.CreateToken, r.authMiddleware.Middleware)
+		workspaces.GET("/:workspaceId/service-accounts/:serviceAccountId/tokens", r.serviceAccount.ListTokens, r.authMiddleware.Middleware)
+		workspaces.DELETE("/:workspaceId/service-accounts/:serviceAccountId/tokens/:tokenId", r.serviceAccount.RevokeToken, r.authMiddleware.Middleware)
 	}
 
 	invitations := api.Group("/invitations/v1")
@@ -41,6 +98,8 @@ func (r *Router) RegisterAPIRouter(e *echo.Echo) {
 		invitations.GET("/users", r.invitation.ListForUser, r.authMiddleware.Middleware)
 		invitations.GET("/:workspaceId/workspaces/owner", r.invitation.ListForWorkspaceOwner, r.authMiddleware.Middleware)
 		invitations.PUT("/users", r.invitation.UserResponse, r.authMiddleware.Middleware)
+		invitations.PUT("/:invitationId/revoke", r.invitation.Revoke, r.authMiddleware.Middleware)
+		invitations.POST("/:invitationId/resend", r.invitation.Resend, r.authMiddleware.Middleware)
 	}
 
 	projects := api.Group("/projects/v1")
@@ -49,76 +108,100 @@ func (r *Router) RegisterAPIRouter(e *echo.Echo) {
 		projects.GET("/:projectId", r.project.GetProjectDetail, r.authMiddleware.Middleware)
 
 		// Setup
-		projects.PUT("/:projectId/setup-status", r.project.UpdateSetupStatus, r.authMiddleware.Middleware)
+		projects.PUT("/:projectId/setup-status", r.project.UpdateSetupStatus, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionUpdateProject))
 
 		// Detail
-		projects.PUT("/:projectId/detail", r.project.UpdateDetail, r.authMiddleware.Middleware)
+		projects.PUT("/:projectId/detail", r.project.UpdateDetail, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionUpdateProject))
+
+		// Lifecycle
+		projects.PUT("/:projectId/archive", r.project.Archive, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionArchiveProject))
+		projects.PUT("/:projectId/unarchive", r.project.Unarchive, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionArchiveProject))
+		projects.POST("/:projectId/deletion-token", r.project.RequestDeletion, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionDeleteProject))
+		projects.DELETE("/:projectId", r.project.Delete, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionDeleteProject))
+		projects.PUT("/:projectId/owner", r.project.TransferOwnership, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionTransferOwnership))
 
 		// Positions
-		projects.PUT("/:projectId/positions", r.project.UpdatePositions, r.authMiddleware.Middleware)
+		projects.PUT("/:projectId/positions", r.project.UpdatePositions, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManagePositions))
 		projects.GET("/:projectId/positions", r.project.ListPositions, r.authMiddleware.Middleware)
 
 		// Members
-		projects.POST("/:projectId/members", r.project.AddMembers, r.authMiddleware.Middleware)
+		projects.POST("/:projectId/members", r.project.AddMembers, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManageMembers))
 		projects.GET("/:projectId/members", r.project.ListMembers, r.authMiddleware.Middleware)
 
 		// Workflow
-		projects.PUT("/:projectId/workflows", r.project.UpdateWorkflows, r.authMiddleware.Middleware)
+		projects.PUT("/:projectId/workflows", r.project.UpdateWorkflows, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionEditWorkflows))
 		projects.GET("/:projectId/workflows", r.project.ListWorkflows, r.authMiddleware.Middleware)
 
 		// Sprint
-		projects.POST("/:projectId/sprints", r.sprint.Create, r.authMiddleware.Middleware)
+		projects.POST("/:projectId/sprints", r.sprint.Create, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionCreateSprint))
 		projects.GET("/:projectId/sprints/:sprintId", r.sprint.GetByID, r.authMiddleware.Middleware)
-		projects.PUT("/:projectId/sprints/:sprintId", r.sprint.Edit, r.authMiddleware.Middleware)
+		projects.PUT("/:projectId/sprints/:sprintId", r.sprint.Edit, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManageSprints))
 		projects.GET("/:projectId/sprints", r.sprint.List, r.authMiddleware.Middleware)
-		projects.PUT("/:projectId/sprints/:currentSprintId/complete", r.sprint.CompleteSprint, r.authMiddleware.Middleware)
-		projects.PUT("/:projectId/sprints/:sprintId/status", r.sprint.UpdateStatus, r.authMiddleware.Middleware)
-		projects.DELETE("/:projectId/sprints/:sprintId", r.sprint.Delete, r.authMiddleware.Middleware)
+		projects.PUT("/:projectId/sprints/:currentSprintId/complete", r.sprint.CompleteSprint, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManageSprints))
+		projects.PUT("/:projectId/sprints/:sprintId/status", r.sprint.UpdateStatus, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManageSprints))
+		projects.DELETE("/:projectId/sprints/:sprintId", r.sprint.Delete, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManageSprints))
+		projects.POST("/:projectId/sprints/:sprintId/summary", r.sprint.GenerateSummary, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManageSprints))
+		projects.PUT("/:projectId/sprints/:sprintId/summary", r.sprint.EditSummary, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManageSprints))
 
 		// Attribute Templates
-		projects.PUT("/:projectId/attribute-templates", r.project.UpdateAttributeTemplates, r.authMiddleware.Middleware)
+		projects.PUT("/:projectId/attribute-templates", r.project.UpdateAttributeTemplates, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionEditAttributeTemplates))
 		projects.GET("/:projectId/attribute-templates", r.project.ListAttributeTemplates, r.authMiddleware.Middleware)
 
+		// Permissions
+		projects.GET("/:projectId/permissions", r.projectPermission.List, r.authMiddleware.Middleware)
+		projects.PUT("/:projectId/permissions", r.projectPermission.Update, r.authMiddleware.Middleware)
+
 		// Project Members
 		// Position
-		projects.PUT("/:projectId/members/position", r.projectMember.UpdatePosition, r.authMiddleware.Middleware)
+		projects.PUT("/:projectId/members/position", r.projectMember.UpdatePosition, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManageMembers))
+		// Role
+		projects.PUT("/:projectId/members/role", r.projectMember.UpdateRole, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManageMemberRoles))
+		// Remove
+		projects.DELETE("/:projectId/members/:userId", r.projectMember.Remove, r.authMiddleware.Middleware, r.projectPermissionMiddleware.Require(models.ProjectPermissionManageMembers))
 	}
 
 	tasks := api.Group("/projects/v1/:projectId/tasks/v1")
 	{
-		tasks.POST("", r.task.Create, r.authMiddleware.Middleware)
-		tasks.GET("/:taskRef", r.task.GetTaskDetail, r.authMiddleware.Middleware)
-		tasks.GET("/detail", r.task.GetManyTaskDetail, r.authMiddleware.Middleware)
-
-		tasks.GET("/epic", r.task.ListEpicTasks, r.authMiddleware.Middleware)
-		tasks.GET("", r.task.SearchTask, r.authMiddleware.Middleware)
-		tasks.GET("/children", r.task.GetChildrenTasks, r.authMiddleware.Middleware)
-
-		tasks.PUT("/:taskRef/detail", r.task.UpdateDetail, r.authMiddleware.Middleware)
-		tasks.PUT("/:taskRef/title", r.task.UpdateTitle, r.authMiddleware.Middleware)
-		tasks.PUT("/:taskRef/parent", r.task.UpdateParentID, r.authMiddleware.Middleware)
-		tasks.PUT("/:taskRef/type", r.task.UpdateType, r.authMiddleware.Middleware)
-		tasks.PUT("/:taskRef/status", r.task.UpdateStatus, r.authMiddleware.Middleware)
-		tasks.PUT("/:taskRef/approvals", r.task.UpdateApprovals, r.authMiddleware.Middleware)
-		tasks.PUT("/:taskRef/approve", r.task.ApproveTask, r.authMiddleware.Middleware)
-		tasks.PUT("/:taskRef/assignees", r.task.UpdateAssignees, r.authMiddleware.Middleware)
-		tasks.PUT("/:taskRef/sprint", r.task.UpdateSprint, r.authMiddleware.Middleware)
-		tasks.PUT("/:taskRef/attributes", r.task.UpdateAttributes, r.authMiddleware.Middleware)
-
-		tasks.POST("/:taskRef/comments", r.taskComment.Create, r.authMiddleware.Middleware)
-		tasks.GET("/:taskRef/comments", r.taskComment.List, r.authMiddleware.Middleware)
+		tasks.POST("", r.task.Create, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionCreateTask))
+		tasks.GET("/:taskRef", r.task.GetTaskDetail, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks))
+		tasks.GET("/detail", r.task.GetManyTaskDetail, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTaskSearch))
+
+		tasks.GET("/epic", r.task.ListEpicTasks, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks))
+		tasks.GET("", r.task.SearchTask, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTaskSearch))
+		tasks.POST("/natural-language-search", r.task.NaturalLanguageSearch, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTaskSearch))
+		tasks.GET("/children", r.task.GetChildrenTasks, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks))
+
+		tasks.PUT("/:taskRef/detail", r.task.UpdateDetail, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionEditTask))
+		tasks.PUT("/:taskRef/title", r.task.UpdateTitle, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionEditTask))
+		tasks.PUT("/:taskRef/parent", r.task.UpdateParentID, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionEditTask))
+		tasks.PUT("/:taskRef/type", r.task.UpdateType, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionEditTask))
+		tasks.PUT("/:taskRef/status", r.task.UpdateStatus, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionEditTask))
+		tasks.PUT("/:taskRef/approvals", r.task.UpdateApprovals, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionManageApprovers))
+		tasks.PUT("/:taskRef/approve", r.task.ApproveTask, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionApproveTask))
+		tasks.PUT("/:taskRef/assignees", r.task.UpdateAssignees, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionEditTask))
+		tasks.PUT("/:taskRef/sprint", r.task.UpdateSprint, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionEditTask))
+		tasks.PUT("/:taskRef/attributes", r.task.UpdateAttributes, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionEditTask))
+
+		tasks.POST("/:taskRef/comments", r.taskComment.Create, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionComment))
+		tasks.GET("/:taskRef/comments", r.taskComment.List, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks))
+		tasks.GET("/:taskRef/summary", r.taskComment.Summarize, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks))
 
 		// llm
+		tasks.POST("/:taskRef/sub-task-proposals", r.task.ProposeSubTasks, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionCreateTask))
+		tasks.POST("/:taskRef/sub-tasks", r.task.AcceptSubTasks, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionCreateTask))
+		tasks.GET("/:taskRef/possible-duplicates", r.task.GetPossibleDuplicates, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks))
+		tasks.POST("/:taskRef/point-estimation", r.task.EstimatePoints, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupTasks), r.projectPermissionMiddleware.Require(models.ProjectPermissionEditTask))
 	}
-	api.GET("/generate-description", r.task.GenerateDescription, r.authMiddleware.Middleware)
+	api.GET("/generate-description", r.task.GenerateDescription, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupLLM))
+	api.GET("/generate-description/stream", r.task.StreamDescription, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupLLM))
 
 	reports := api.Group("/projects/v1/:projectId/reports/v1")
 	{
-		reports.GET("/status-overview", r.report.GetStatusOverview, r.authMiddleware.Middleware)
-		reports.GET("/priority-overview", r.report.GetPriorityOverview, r.authMiddleware.Middleware)
-		reports.GET("/type-overview", r.report.GetTypeOverview, r.authMiddleware.Middleware)
-		reports.GET("/epic-task-overview", r.report.GetEpicTaskOverview, r.authMiddleware.Middleware)
-		reports.GET("/assignee-overview-by-sprint", r.report.GetAssigneeOverviewBySprint, r.authMiddleware.Middleware)
+		reports.GET("/status-overview", r.report.GetStatusOverview, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupReports))
+		reports.GET("/priority-overview", r.report.GetPriorityOverview, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupReports))
+		reports.GET("/type-overview", r.report.GetTypeOverview, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupReports))
+		reports.GET("/epic-task-overview", r.report.GetEpicTaskOverview, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupReports))
+		reports.GET("/assignee-overview-by-sprint", r.report.GetAssigneeOverviewBySprint, r.authMiddleware.Middleware, r.apiRateLimitMiddleware.Limit(middlewares.APIRateLimitGroupReports))
 	}
 
 	setup := api.Group("/setup/v1")
@@ -7,24 +7,37 @@ import (
 
 type Router struct {
 	// Handlers
-	healthCheck   rest.HealthCheckHandler
-	common        rest.CommonHandler
-	user          rest.UserHandler
-	project       rest.ProjectHandler
-	projectMember rest.ProjectMemberHandler
-	invitation    rest.InvitationHandler
-	workspace     rest.WorkspaceHandler
-	sprint        rest.SprintHandler
-	task          rest.TaskHandler
-	taskComment   rest.TaskCommentHandler
-	report        rest.ReportHandler
+	healthCheck       rest.HealthCheckHandler
+	common            rest.CommonHandler
+	user              rest.UserHandler
+	project           rest.ProjectHandler
+	projectMember     rest.ProjectMemberHandler
+	invitation        rest.InvitationHandler
+	workspace         rest.WorkspaceHandler
+	sprint            rest.SprintHandler
+	task              rest.TaskHandler
+	taskComment       rest.TaskCommentHandler
+	report            rest.ReportHandler
+	projectTemplate   rest.ProjectTemplateHandler
+	projectPermission rest.ProjectPermissionHandler
+	twoFactor         rest.TwoFactorHandler
+	accessToken       rest.AccessTokenHandler
+	serviceAccount    rest.ServiceAccountHandler
+	userAccount       rest.UserAccountHandler
+	promptTemplate    rest.PromptTemplateHandler
 
 	// Middlewares
-	authMiddleware middlewares.AuthMiddleware
+	authMiddleware              middlewares.AuthMiddleware
+	projectPermissionMiddleware middlewares.ProjectPermissionMiddleware
+	authRateLimitMiddleware     middlewares.AuthRateLimitMiddleware
+	apiRateLimitMiddleware      middlewares.APIRateLimitMiddleware
 }
 
 func NewRouter(
 	authMiddleware middlewares.AuthMiddleware,
+	projectPermissionMiddleware middlewares.ProjectPermissionMiddleware,
+	authRateLimitMiddleware middlewares.AuthRateLimitMiddleware,
+	apiRateLimitMiddleware middlewares.APIRateLimitMiddleware,
 	healthCheck rest.HealthCheckHandler,
 	common rest.CommonHandler,
 	user rest.UserHandler,
@@ -36,19 +49,36 @@ func NewRouter(
 	task rest.TaskHandler,
 	taskComment rest.TaskCommentHandler,
 	report rest.ReportHandler,
+	projectTemplate rest.ProjectTemplateHandler,
+	projectPermission rest.ProjectPermissionHandler,
+	twoFactor rest.TwoFactorHandler,
+	accessToken rest.AccessTokenHandler,
+	serviceAccount rest.ServiceAccountHandler,
+	userAccount rest.UserAccountHandler,
+	promptTemplate rest.PromptTemplateHandler,
 ) *Router {
 	return &Router{
-		authMiddleware: authMiddleware,
-		healthCheck:    healthCheck,
-		common:         common,
-		user:           user,
-		project:        project,
-		projectMember:  projectMember,
-		invitation:     invitation,
-		workspace:      workspace,
-		sprint:         sprint,
-		task:           task,
-		taskComment:    taskComment,
-		report:         report,
+		authMiddleware:              authMiddleware,
+		projectPermissionMiddleware: projectPermissionMiddleware,
+		authRateLimitMiddleware:     authRateLimitMiddleware,
+		apiRateLimitMiddleware:      apiRateLimitMiddleware,
+		healthCheck:                 healthCheck,
+		common:                      common,
+		user:                        user,
+		project:                     project,
+		projectMember:               projectMember,
+		invitation:                  invitation,
+		workspace:                   workspace,
+		sprint:                      sprint,
+		task:                        task,
+		taskComment:                 taskComment,
+		report:                      report,
+		projectTemplate:             projectTemplate,
+		projectPermission:           projectPermission,
+		twoFactor:                   twoFactor,
+		accessToken:                 accessToken,
+		serviceAccount:              serviceAccount,
+		userAccount:                 userAccount,
+		promptTemplate:              promptTemplate,
 	}
 }
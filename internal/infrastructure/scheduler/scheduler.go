@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/jobs"
+)
+
+type Scheduler struct {
+	jobs []jobs.Job
+}
+
+func NewScheduler(
+	invitationExpiry jobs.InvitationExpiryJob,
+) *Scheduler {
+	return &Scheduler{
+		jobs: []jobs.Job{
+			invitationExpiry,
+		},
+	}
+}
+
+// Start runs every job once immediately and then on its interval until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		if job.Interval() <= 0 {
+			log.Printf("⏭️ Job %s is disabled", job.Name())
+			continue
+		}
+
+		go s.run(ctx, job)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job jobs.Job) {
+	ticker := time.NewTicker(job.Interval())
+	defer ticker.Stop()
+
+	for {
+		if err := job.Run(ctx); err != nil {
+			log.Printf("❌ Job %s failed: %v", job.Name(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -4,16 +4,19 @@ import (
 	coreGrpcclient "github.com/cnc-csku/task-nexus-go-lib/grpcclient"
 	"github.com/cnc-csku/task-nexus/task-management/config"
 	"github.com/cnc-csku/task-nexus/task-management/domain/services"
+	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/jobs"
 	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/grpcclient"
 	llmRepo "github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/llm"
+	mailRepo "github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/mail"
 	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/mongo"
+	oidcRepo "github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/oidc"
 	redisRepo "github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/redis"
 	storageRepo "github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/storage"
 	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/rest"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/cache"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/database"
-	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/llm"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/router"
+	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/scheduler"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/storage"
 	"github.com/cnc-csku/task-nexus/task-management/middlewares"
 	"github.com/google/wire"
@@ -30,8 +33,7 @@ var ConfigSet = wire.NewSet(
 var InfraSet = wire.NewSet(
 	database.NewMongoClient,
 	router.NewRouter,
-	llm.NewOllamaClient,
-	llm.NewGeminiClient,
+	scheduler.NewScheduler,
 	cache.NewRedisClient,
 	storage.NewMinIOClient,
 )
@@ -47,9 +49,24 @@ var RepositorySet = wire.NewSet(
 	mongo.NewMongoSprintRepo,
 	mongo.NewMongoTaskRepo,
 	mongo.NewMongoTaskCommentRepo,
-	llmRepo.NewGeminiRepo,
+	mongo.NewMongoPromptTemplateRepo,
+	mongo.NewMongoTaskEmbeddingRepo,
+	mongo.NewMongoProjectTemplateRepo,
+	mongo.NewMongoAccessTokenRepo,
+	mongo.NewMongoAuthAttemptAuditRepo,
+	llmRepo.NewLLMRepository,
 	storageRepo.NewMinioRepository,
+	mailRepo.NewMailRepository,
+	oidcRepo.NewOIDCRepo,
 	redisRepo.NewRedisGlobalSettingCacheRepo,
+	redisRepo.NewRedisProjectDeletionTokenCacheRepo,
+	redisRepo.NewRedisUserSessionCacheRepo,
+	redisRepo.NewRedisOIDCStateCacheRepo,
+	redisRepo.NewRedisTwoFactorChallengeCacheRepo,
+	redisRepo.NewRedisAuthRateLimitCacheRepo,
+	redisRepo.NewRedisAPIRateLimitCacheRepo,
+	redisRepo.NewRedisLLMStreamCacheRepo,
+	redisRepo.NewRedisTaskSummaryCacheRepo,
 )
 
 var ServiceSet = wire.NewSet(
@@ -62,8 +79,15 @@ var ServiceSet = wire.NewSet(
 	services.NewSprintService,
 	services.NewTaskService,
 	services.NewTaskCommentService,
+	services.NewPromptTemplateService,
 	services.NewGlobalSettingService,
 	services.NewReportService,
+	services.NewProjectTemplateService,
+	services.NewProjectPermissionService,
+	services.NewTwoFactorService,
+	services.NewAccessTokenService,
+	services.NewServiceAccountService,
+	services.NewUserAccountService,
 )
 
 var RestHandlerSet = wire.NewSet(
@@ -77,7 +101,18 @@ var RestHandlerSet = wire.NewSet(
 	rest.NewSprintHandler,
 	rest.NewTaskHandler,
 	rest.NewTaskCommentHandler,
+	rest.NewPromptTemplateHandler,
 	rest.NewReportHandler,
+	rest.NewProjectTemplateHandler,
+	rest.NewProjectPermissionHandler,
+	rest.NewTwoFactorHandler,
+	rest.NewAccessTokenHandler,
+	rest.NewServiceAccountHandler,
+	rest.NewUserAccountHandler,
+)
+
+var JobSet = wire.NewSet(
+	jobs.NewInvitationExpiryJob,
 )
 
 var GrpcClientSet = wire.NewSet(
@@ -88,4 +123,7 @@ var GrpcClientSet = wire.NewSet(
 
 var MiddlewareSet = wire.NewSet(
 	middlewares.NewAdminJWTMiddleware,
+	middlewares.NewProjectPermissionMiddleware,
+	middlewares.NewAuthRateLimitMiddleware,
+	middlewares.NewAPIRateLimitMiddleware,
 )
@@ -18,6 +18,7 @@ func InitializeApp() *api.EchoAPI {
 		ServiceSet,
 		RestHandlerSet,
 		MiddlewareSet,
+		JobSet,
 		api.NewEchoAPI,
 	)
 
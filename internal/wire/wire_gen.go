@@ -9,16 +9,19 @@ package wire
 import (
 	"github.com/cnc-csku/task-nexus/task-management/config"
 	"github.com/cnc-csku/task-nexus/task-management/domain/services"
-	llm2 "github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/llm"
+	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/jobs"
+	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/llm"
+	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/mail"
 	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/mongo"
+	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/oidc"
 	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/redis"
 	storage2 "github.com/cnc-csku/task-nexus/task-management/internal/adapters/repositories/storage"
 	"github.com/cnc-csku/task-nexus/task-management/internal/adapters/rest"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/api"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/cache"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/database"
-	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/llm"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/router"
+	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/scheduler"
 	"github.com/cnc-csku/task-nexus/task-management/internal/infrastructure/storage"
 	"github.com/cnc-csku/task-nexus/task-management/middlewares"
 )
@@ -29,46 +32,79 @@ func InitializeApp() *api.EchoAPI {
 	context := NewCtx()
 	configConfig := config.NewConfig()
 	client := database.NewMongoClient(configConfig, context)
-	authMiddleware := middlewares.NewAdminJWTMiddleware(configConfig)
+	redisClient := cache.NewRedisClient(context, configConfig)
+	userSessionCacheRepository := redis.NewRedisUserSessionCacheRepo(configConfig, redisClient)
+	accessTokenRepository := mongo.NewMongoAccessTokenRepo(configConfig, client)
+	userRepository := mongo.NewMongoUserRepo(configConfig, client)
+	accessTokenService := services.NewAccessTokenService(accessTokenRepository, userRepository)
+	authMiddleware := middlewares.NewAdminJWTMiddleware(configConfig, userSessionCacheRepository, accessTokenService)
+	projectRepository := mongo.NewMongoProjectRepo(configConfig, client)
+	projectMemberRepository := mongo.NewMongoProjectMemberRepo(configConfig, client)
+	workspaceRepository := mongo.NewMongoWorkspaceRepo(configConfig, client)
+	projectPermissionService := services.NewProjectPermissionService(projectRepository, projectMemberRepository, workspaceRepository, userRepository)
+	projectPermissionMiddleware := middlewares.NewProjectPermissionMiddleware(projectPermissionService)
+	authRateLimitCacheRepository := redis.NewRedisAuthRateLimitCacheRepo(configConfig, redisClient)
+	authAttemptAuditRepository := mongo.NewMongoAuthAttemptAuditRepo(configConfig, client)
+	authRateLimitMiddleware := middlewares.NewAuthRateLimitMiddleware(configConfig, authRateLimitCacheRepository, authAttemptAuditRepository)
+	apiRateLimitCacheRepository := redis.NewRedisAPIRateLimitCacheRepo(configConfig, redisClient)
+	apiRateLimitMiddleware := middlewares.NewAPIRateLimitMiddleware(configConfig, apiRateLimitCacheRepository)
 	healthCheckHandler := rest.NewHealthCheckHandler()
 	globalSettingRepository := mongo.NewMongoGlobalSettingRepo(configConfig, client)
-	redisClient := cache.NewRedisClient(context, configConfig)
 	globalSettingCacheRepository := redis.NewRedisGlobalSettingCacheRepo(configConfig, redisClient)
 	minioClient := storage.NewMinIOClient(context, configConfig)
 	minioRepository := storage2.NewMinioRepository(minioClient, configConfig)
 	commonService := services.NewCommonService(globalSettingRepository, globalSettingCacheRepository, minioRepository)
 	globalSettingService := services.NewGlobalSettingService(globalSettingRepository, globalSettingCacheRepository)
 	commonHandler := rest.NewCommonHandler(commonService, globalSettingService)
-	userRepository := mongo.NewMongoUserRepo(configConfig, client)
-	userService := services.NewUserService(configConfig, userRepository, globalSettingRepository, globalSettingService)
-	userHandler := rest.NewUserHandler(userService)
-	workspaceRepository := mongo.NewMongoWorkspaceRepo(configConfig, client)
+	invitationRepository := mongo.NewMongoInvitationRepo(configConfig, client)
 	workspaceMemberRepository := mongo.NewMongoWorkspaceMemberRepo(configConfig, client)
-	projectRepository := mongo.NewMongoProjectRepo(configConfig, client)
-	projectMemberRepository := mongo.NewMongoProjectMemberRepo(configConfig, client)
+	mailRepository := mail.NewMailRepository(configConfig)
+	oidcRepository := oidc.NewOIDCRepo(configConfig)
+	oidcStateCacheRepository := redis.NewRedisOIDCStateCacheRepo(configConfig, redisClient)
+	twoFactorChallengeCacheRepository := redis.NewRedisTwoFactorChallengeCacheRepo(configConfig, redisClient)
+	userService := services.NewUserService(configConfig, userRepository, globalSettingRepository, globalSettingService, invitationRepository, workspaceMemberRepository, mailRepository, userSessionCacheRepository, oidcRepository, oidcStateCacheRepository, twoFactorChallengeCacheRepository)
+	userHandler := rest.NewUserHandler(userService)
 	taskRepository := mongo.NewMongoTaskRepo(configConfig, client)
-	projectService := services.NewProjectService(userRepository, workspaceRepository, workspaceMemberRepository, projectRepository, projectMemberRepository, configConfig, taskRepository)
+	projectTemplateRepository := mongo.NewMongoProjectTemplateRepo(configConfig, client)
+	sprintRepository := mongo.NewMongoSprintRepo(configConfig, client)
+	taskCommentRepository := mongo.NewMongoTaskCommentRepo(configConfig, client)
+	taskEmbeddingRepository := mongo.NewMongoTaskEmbeddingRepo(configConfig, client)
+	projectDeletionTokenCacheRepository := redis.NewRedisProjectDeletionTokenCacheRepo(configConfig, redisClient)
+	projectService := services.NewProjectService(userRepository, workspaceRepository, workspaceMemberRepository, projectRepository, projectMemberRepository, configConfig, taskRepository, projectTemplateRepository, sprintRepository, taskCommentRepository, projectDeletionTokenCacheRepository, taskEmbeddingRepository)
 	projectHandler := rest.NewProjectHandler(projectService)
-	projectMemberService := services.NewProjectMemberService(userRepository, projectRepository, projectMemberRepository)
+	projectMemberService := services.NewProjectMemberService(userRepository, projectRepository, projectMemberRepository, taskRepository)
 	projectMemberHandler := rest.NewProjectMemberHandler(projectMemberService)
-	invitationRepository := mongo.NewMongoInvitationRepo(configConfig, client)
-	invitationService := services.NewInvitationService(userRepository, workspaceRepository, invitationRepository, workspaceMemberRepository, configConfig)
+	invitationService := services.NewInvitationService(userRepository, workspaceRepository, invitationRepository, workspaceMemberRepository, mailRepository, configConfig)
 	invitationHandler := rest.NewInvitationHandler(invitationService)
-	workspaceService := services.NewWorkspaceService(workspaceRepository, userRepository, workspaceMemberRepository, globalSettingService)
+	workspaceService := services.NewWorkspaceService(workspaceRepository, userRepository, workspaceMemberRepository, projectRepository, projectMemberRepository, globalSettingService)
 	workspaceHandler := rest.NewWorkspaceHandler(workspaceService)
-	sprintRepository := mongo.NewMongoSprintRepo(configConfig, client)
-	sprintService := services.NewSprintService(sprintRepository, projectRepository, projectMemberRepository, taskRepository)
+	llmRepository := llm.NewLLMRepository(context, configConfig)
+	llmStreamCacheRepository := redis.NewRedisLLMStreamCacheRepo(configConfig, redisClient)
+	taskSummaryCacheRepository := redis.NewRedisTaskSummaryCacheRepo(configConfig, redisClient)
+	promptTemplateRepository := mongo.NewMongoPromptTemplateRepo(configConfig, client)
+	sprintService := services.NewSprintService(sprintRepository, projectRepository, projectMemberRepository, taskRepository, taskCommentRepository, llmRepository)
 	sprintHandler := rest.NewSprintHandler(sprintService)
-	taskCommentRepository := mongo.NewMongoTaskCommentRepo(configConfig, client)
-	geminiClient := llm.NewGeminiClient(context, configConfig)
-	geminiRepository := llm2.NewGeminiRepo(geminiClient, configConfig)
-	taskService := services.NewTaskService(taskRepository, projectRepository, projectMemberRepository, sprintRepository, taskCommentRepository, userRepository, geminiRepository)
+	taskService := services.NewTaskService(configConfig, taskRepository, projectRepository, projectMemberRepository, sprintRepository, taskCommentRepository, userRepository, llmRepository, taskEmbeddingRepository, llmStreamCacheRepository, promptTemplateRepository)
 	taskHandler := rest.NewTaskHandler(taskService)
-	taskCommentService := services.NewTaskCommentService(userRepository, taskCommentRepository, taskRepository, projectRepository, projectMemberRepository)
+	taskCommentService := services.NewTaskCommentService(userRepository, taskCommentRepository, taskRepository, projectRepository, projectMemberRepository, llmRepository, taskSummaryCacheRepository, promptTemplateRepository)
 	taskCommentHandler := rest.NewTaskCommentHandler(taskCommentService)
+	promptTemplateService := services.NewPromptTemplateService(configConfig, userRepository, promptTemplateRepository)
+	promptTemplateHandler := rest.NewPromptTemplateHandler(promptTemplateService)
 	reportService := services.NewReportService(userRepository, projectRepository, projectMemberRepository, sprintRepository, taskRepository)
 	reportHandler := rest.NewReportHandler(reportService)
-	routerRouter := router.NewRouter(authMiddleware, healthCheckHandler, commonHandler, userHandler, projectHandler, projectMemberHandler, invitationHandler, workspaceHandler, sprintHandler, taskHandler, taskCommentHandler, reportHandler)
-	echoAPI := api.NewEchoAPI(context, configConfig, client, routerRouter)
+	projectTemplateService := services.NewProjectTemplateService(workspaceMemberRepository, projectRepository, projectTemplateRepository, taskRepository)
+	projectTemplateHandler := rest.NewProjectTemplateHandler(projectTemplateService)
+	projectPermissionHandler := rest.NewProjectPermissionHandler(projectPermissionService)
+	twoFactorService := services.NewTwoFactorService(userRepository, workspaceRepository, workspaceMemberRepository)
+	twoFactorHandler := rest.NewTwoFactorHandler(twoFactorService)
+	accessTokenHandler := rest.NewAccessTokenHandler(accessTokenService)
+	serviceAccountService := services.NewServiceAccountService(userRepository, workspaceRepository, workspaceMemberRepository, accessTokenRepository)
+	serviceAccountHandler := rest.NewServiceAccountHandler(serviceAccountService)
+	userAccountService := services.NewUserAccountService(configConfig, userRepository, userSessionCacheRepository, taskRepository, taskCommentRepository, workspaceMemberRepository, projectMemberRepository, authAttemptAuditRepository)
+	userAccountHandler := rest.NewUserAccountHandler(userAccountService)
+	routerRouter := router.NewRouter(authMiddleware, projectPermissionMiddleware, authRateLimitMiddleware, apiRateLimitMiddleware, healthCheckHandler, commonHandler, userHandler, projectHandler, projectMemberHandler, invitationHandler, workspaceHandler, sprintHandler, taskHandler, taskCommentHandler, reportHandler, projectTemplateHandler, projectPermissionHandler, twoFactorHandler, accessTokenHandler, serviceAccountHandler, userAccountHandler, promptTemplateHandler)
+	invitationExpiryJob := jobs.NewInvitationExpiryJob(configConfig, invitationService)
+	schedulerScheduler := scheduler.NewScheduler(invitationExpiryJob)
+	echoAPI := api.NewEchoAPI(context, configConfig, client, routerRouter, schedulerScheduler)
 	return echoAPI
 }
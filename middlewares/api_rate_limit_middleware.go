@@ -0,0 +1,106 @@
+package middlewares
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus-go-lib/utils/tokenutils"
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/labstack/echo/v4"
+)
+
+// Route groups limited by APIRateLimitMiddleware, each has its own bucket size in config.APIRateLimitConfig
+const (
+	APIRateLimitGroupTasks      = "tasks"
+	APIRateLimitGroupTaskSearch = "task-search"
+	APIRateLimitGroupReports    = "reports"
+	APIRateLimitGroupLLM        = "llm"
+)
+
+type apiRateLimitMiddleware struct {
+	configs          *config.Config
+	apiRateLimitRepo repositories.APIRateLimitCacheRepository
+}
+
+// APIRateLimitMiddleware limits authenticated requests with a token bucket per user or access token and route group.
+// It sets the RateLimit-* headers on every response and must run after AuthMiddleware.
+type APIRateLimitMiddleware interface {
+	Limit(group string) echo.MiddlewareFunc
+}
+
+func NewAPIRateLimitMiddleware(configs *config.Config, apiRateLimitRepo repositories.APIRateLimitCacheRepository) APIRateLimitMiddleware {
+	return &apiRateLimitMiddleware{
+		configs:          configs,
+		apiRateLimitRepo: apiRateLimitRepo,
+	}
+}
+
+func (a *apiRateLimitMiddleware) Limit(group string) echo.MiddlewareFunc {
+	groupConfig := a.groupConfig(group)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !a.configs.APIRateLimit.Enabled || groupConfig.Capacity <= 0 || groupConfig.RefillPerSecond <= 0 {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+			// Each access token has its own bucket, so a busy script does not starve its owner's browser session
+			subject := "user:" + userClaims.ID
+			if userClaims.AccessTokenID != "" {
+				subject = "access-token:" + userClaims.AccessTokenID
+			}
+
+			result, err := a.apiRateLimitRepo.Take(c.Request().Context(), &repositories.TakeAPIRateLimitTokenRequest{
+				Group:           group,
+				Subject:         subject,
+				Capacity:        groupConfig.Capacity,
+				RefillPerSecond: groupConfig.RefillPerSecond,
+			})
+			if err != nil {
+				return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error()).ToEchoError()
+			}
+
+			header := c.Response().Header()
+			header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", groupConfig.Capacity, int64(math.Ceil(float64(groupConfig.Capacity)/groupConfig.RefillPerSecond))))
+			header.Set("RateLimit-Limit", fmt.Sprint(groupConfig.Capacity))
+			header.Set("RateLimit-Remaining", fmt.Sprint(result.Remaining))
+			header.Set("RateLimit-Reset", fmt.Sprint(ceilSeconds(result.ResetAfter)))
+
+			if !result.Allowed {
+				header.Set("Retry-After", fmt.Sprint(ceilSeconds(result.RetryAfter)))
+				return errutils.NewError(exceptions.ErrRateLimitExceeded, errutils.TooManyRequests).WithDebugMessage(group).ToEchoError()
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func (a *apiRateLimitMiddleware) groupConfig(group string) config.APIRateLimitGroupConfig {
+	switch group {
+	case APIRateLimitGroupTasks:
+		return a.configs.APIRateLimit.Tasks
+	case APIRateLimitGroupTaskSearch:
+		return a.configs.APIRateLimit.TaskSearch
+	case APIRateLimitGroupReports:
+		return a.configs.APIRateLimit.Reports
+	case APIRateLimitGroupLLM:
+		return a.configs.APIRateLimit.LLM
+	}
+
+	// Routes are registered at startup, so an unknown group is a programming error
+	log.Fatalf("❌ Unknown API rate limit group: %s", group)
+	return config.APIRateLimitGroupConfig{}
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
@@ -2,27 +2,39 @@ package middlewares
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
 	"github.com/cnc-csku/task-nexus-go-lib/utils/tokenutils"
 	"github.com/cnc-csku/task-nexus/task-management/config"
 	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
 	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/cnc-csku/task-nexus/task-management/domain/services"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 type authMiddleware struct {
-	configs *config.Config
+	configs            *config.Config
+	userSessionRepo    repositories.UserSessionCacheRepository
+	accessTokenService services.AccessTokenService
 }
 
 type AuthMiddleware interface {
 	Middleware(next echo.HandlerFunc) echo.HandlerFunc
 }
 
-func NewAdminJWTMiddleware(configs *config.Config) AuthMiddleware {
+func NewAdminJWTMiddleware(
+	configs *config.Config,
+	userSessionRepo repositories.UserSessionCacheRepository,
+	accessTokenService services.AccessTokenService,
+) AuthMiddleware {
 	return &authMiddleware{
-		configs: configs,
+		configs:            configs,
+		userSessionRepo:    userSessionRepo,
+		accessTokenService: accessTokenService,
 	}
 }
 
@@ -33,6 +45,18 @@ func (a *authMiddleware) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return errutils.NewError(err, errutils.Unauthorized).ToEchoError()
 		}
 
+		// Personal access tokens and service account tokens are opaque, they are looked up instead of parsed
+		if strings.HasPrefix(tokenString, models.AccessTokenPrefix) {
+			claims, svcErr := a.accessTokenService.Authenticate(c.Request().Context(), tokenString, c.Request().Method, c.Path())
+			if svcErr != nil {
+				return svcErr.ToEchoError()
+			}
+
+			c.Set("profile", claims)
+
+			return next(c)
+		}
+
 		// Parse and validate the token
 		token, err := jwt.ParseWithClaims(tokenString, &models.UserCustomClaims{}, func(token *jwt.Token) (any, error) {
 			// Validate the signing method
@@ -51,9 +75,30 @@ func (a *authMiddleware) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return errutils.NewError(exceptions.ErrInvalidToken, errutils.Unauthorized).ToEchoError()
 		}
 
+		// Reject tokens issued before the user's sessions were revoked, e.g. by a password change
+		if svcErr := a.checkSessionRevoked(c, claims); svcErr != nil {
+			return svcErr.ToEchoError()
+		}
+
 		// Set claims to context
 		c.Set("profile", claims)
 
 		return next(c)
 	}
 }
+
+func (a *authMiddleware) checkSessionRevoked(c echo.Context, claims *models.UserCustomClaims) *errutils.Error {
+	userID, err := bson.ObjectIDFromHex(claims.ID)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInvalidToken, errutils.Unauthorized)
+	}
+
+	revokedAt, err := a.userSessionRepo.GetRevokedAt(c.Request().Context(), userID)
+	if err != nil {
+		return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error())
+	} else if revokedAt != nil && claims.GetIssuedAtMilli() < revokedAt.UnixMilli() {
+		return errutils.NewError(exceptions.ErrSessionRevoked, errutils.Unauthorized)
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cnc-csku/task-nexus-go-lib/utils/errutils"
+	"github.com/cnc-csku/task-nexus/task-management/config"
+	"github.com/cnc-csku/task-nexus/task-management/domain/exceptions"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/repositories"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// Routes protected by AuthRateLimitMiddleware, each has its own limits in config.AuthRateLimitConfig
+const (
+	AuthRateLimitRouteLogin     = "login"
+	AuthRateLimitRouteRegister  = "register"
+	AuthRateLimitRouteTwoFactor = "two-factor"
+)
+
+// twoFactorChallengeTokenField is the body field of the two-factor login that holds the challenge token
+const twoFactorChallengeTokenField = "challengeToken"
+
+// authRateLimitMaxBodyBytes bounds the body read before any limit applies, the auth bodies are a few fields
+const authRateLimitMaxBodyBytes = 8 << 10
+
+type authRateLimitMiddleware struct {
+	configs              *config.Config
+	authRateLimitRepo    repositories.AuthRateLimitCacheRepository
+	authAttemptAuditRepo repositories.AuthAttemptAuditRepository
+}
+
+// AuthRateLimitMiddleware protects unauthenticated auth routes against brute force.
+// Attempts are limited per IP address, and an account is locked for a while after repeated failures.
+// Any 4xx response of the route counts as a failure, a successful response resets the account's failures.
+type AuthRateLimitMiddleware interface {
+	Limit(route string) echo.MiddlewareFunc
+}
+
+func NewAuthRateLimitMiddleware(
+	configs *config.Config,
+	authRateLimitRepo repositories.AuthRateLimitCacheRepository,
+	authAttemptAuditRepo repositories.AuthAttemptAuditRepository,
+) AuthRateLimitMiddleware {
+	return &authRateLimitMiddleware{
+		configs:              configs,
+		authRateLimitRepo:    authRateLimitRepo,
+		authAttemptAuditRepo: authAttemptAuditRepo,
+	}
+}
+
+func (a *authRateLimitMiddleware) Limit(route string) echo.MiddlewareFunc {
+	routeConfig := a.routeConfig(route)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !routeConfig.Enabled {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			ipAddress := c.RealIP()
+
+			attempts, err := a.authRateLimitRepo.IncrementIPAttempts(ctx, route, ipAddress, routeConfig.Window)
+			if err != nil {
+				return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error()).ToEchoError()
+			}
+
+			rawAccount, err := readAccount(c, routeConfig.AccountField)
+			if err != nil {
+				return errutils.NewError(exceptions.ErrInvalidReqPayload, errutils.BadRequest).WithDebugMessage(err.Error()).ToEchoError()
+			}
+			account := a.resolveAccount(route, rawAccount)
+
+			if routeConfig.IPLimit > 0 && attempts > int64(routeConfig.IPLimit) {
+				a.audit(c, route, account, models.AuthAttemptAuditOutcomeRateLimited, http.StatusTooManyRequests)
+				setRetryAfter(c, routeConfig.Window)
+				return errutils.NewError(exceptions.ErrTooManyAuthAttempts, errutils.TooManyRequests).ToEchoError()
+			}
+
+			if account != "" && routeConfig.MaxAccountFailures > 0 {
+				lockTTL, err := a.authRateLimitRepo.GetAccountLockTTL(ctx, route, account)
+				if err != nil {
+					return errutils.NewError(exceptions.ErrInternalError, errutils.InternalServerError).WithDebugMessage(err.Error()).ToEchoError()
+				} else if lockTTL > 0 {
+					a.audit(c, route, account, models.AuthAttemptAuditOutcomeRejectedLocked, http.StatusTooManyRequests)
+					setRetryAfter(c, lockTTL)
+					return errutils.NewError(exceptions.ErrAccountTemporarilyLocked, errutils.TooManyRequests).ToEchoError()
+				}
+			}
+
+			handlerErr := next(c)
+
+			statusCode := c.Response().Status
+			if handlerErr != nil {
+				statusCode = http.StatusInternalServerError
+				var httpErr *echo.HTTPError
+				if errors.As(handlerErr, &httpErr) {
+					statusCode = httpErr.Code
+				}
+			}
+
+			// Server errors say nothing about the credentials, they are neither failures nor successes
+			if statusCode >= http.StatusInternalServerError {
+				return handlerErr
+			}
+
+			if statusCode < http.StatusBadRequest {
+				if account != "" {
+					if err := a.authRateLimitRepo.ResetAccountFailures(ctx, route, account); err != nil {
+						log.Printf("⚠️ Failed to reset %s failures of %s: %v", route, account, err)
+					}
+				}
+				return handlerErr
+			}
+
+			outcome := models.AuthAttemptAuditOutcomeFailed
+			if account != "" && routeConfig.MaxAccountFailures > 0 {
+				failures, err := a.authRateLimitRepo.IncrementAccountFailures(ctx, route, account, routeConfig.Window)
+				if err != nil {
+					log.Printf("⚠️ Failed to count %s failure of %s: %v", route, account, err)
+				} else if failures >= int64(routeConfig.MaxAccountFailures) {
+					if err := a.authRateLimitRepo.LockAccount(ctx, route, account, routeConfig.LockoutDuration); err != nil {
+						log.Printf("⚠️ Failed to lock %s of %s: %v", route, account, err)
+					} else {
+						outcome = models.AuthAttemptAuditOutcomeAccountLocked
+						// Start counting again once the lockout is over
+						if err := a.authRateLimitRepo.ResetAccountFailures(ctx, route, account); err != nil {
+							log.Printf("⚠️ Failed to reset %s failures of %s: %v", route, account, err)
+						}
+					}
+				}
+			}
+			a.audit(c, route, account, outcome, statusCode)
+
+			return handlerErr
+		}
+	}
+}
+
+func (a *authRateLimitMiddleware) routeConfig(route string) config.AuthRateLimitRouteConfig {
+	switch route {
+	case AuthRateLimitRouteLogin:
+		return a.configs.AuthRateLimit.Login
+	case AuthRateLimitRouteRegister:
+		return a.configs.AuthRateLimit.Register
+	case AuthRateLimitRouteTwoFactor:
+		routeConfig := a.configs.AuthRateLimit.TwoFactor
+		routeConfig.AccountField = twoFactorChallengeTokenField
+		return routeConfig
+	}
+
+	// Routes are registered at startup, so an unknown route is a programming error
+	log.Fatalf("❌ Unknown auth rate limit route: %s", route)
+	return config.AuthRateLimitRouteConfig{}
+}
+
+// audit records the attempt, it never fails the request.
+func (a *authRateLimitMiddleware) audit(c echo.Context, route string, account string, outcome models.AuthAttemptAuditOutcome, statusCode int) {
+	// The request may be cancelled by the client, the audit is still written
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request().Context()), 5*time.Second)
+	defer cancel()
+
+	_, err := a.authAttemptAuditRepo.Create(ctx, &repositories.CreateAuthAttemptAuditRequest{
+		Route:      route,
+		Account:    account,
+		IPAddress:  c.RealIP(),
+		UserAgent:  c.Request().UserAgent(),
+		Outcome:    outcome,
+		StatusCode: statusCode,
+	})
+	if err != nil {
+		log.Printf("⚠️ Failed to audit %s attempt of %s: %v", route, account, err)
+	}
+}
+
+// resolveAccount turns the account field into the key failures are counted by. For the two-factor login it is
+// the user the challenge token was issued to, a token that does not verify is only counted per IP address.
+func (a *authRateLimitMiddleware) resolveAccount(route string, rawAccount string) string {
+	if rawAccount == "" {
+		return ""
+	}
+
+	if route != AuthRateLimitRouteTwoFactor {
+		return strings.ToLower(rawAccount)
+	}
+
+	claims := &models.TwoFactorChallengeTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawAccount, claims, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(a.configs.JWT.TwoFactorChallengeSecret), nil
+	})
+	if err != nil {
+		return ""
+	}
+
+	return claims.Subject
+}
+
+// readAccount returns the trimmed account field of the JSON body and restores the body for the handler.
+// Bodies over authRateLimitMaxBodyBytes are rejected.
+func readAccount(c echo.Context, field string) (string, error) {
+	req := c.Request()
+	if field == "" || req.Body == nil {
+		return "", nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, authRateLimitMaxBodyBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		// Malformed bodies are rejected by the handler and counted per IP address only
+		return "", nil
+	}
+
+	account, _ := fields[field].(string)
+
+	return strings.TrimSpace(account), nil
+}
+
+func setRetryAfter(c echo.Context, d time.Duration) {
+	c.Response().Header().Set("Retry-After", fmt.Sprint(ceilSeconds(d)))
+}
@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"github.com/cnc-csku/task-nexus-go-lib/utils/tokenutils"
+	"github.com/cnc-csku/task-nexus/task-management/domain/models"
+	"github.com/cnc-csku/task-nexus/task-management/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+type projectPermissionMiddleware struct {
+	projectPermissionService services.ProjectPermissionService
+}
+
+// ProjectPermissionMiddleware checks the requester's role against the project's permission matrix.
+// It reads the project from the projectId path param and must run after AuthMiddleware.
+type ProjectPermissionMiddleware interface {
+	Require(permission models.ProjectPermission) echo.MiddlewareFunc
+}
+
+func NewProjectPermissionMiddleware(projectPermissionService services.ProjectPermissionService) ProjectPermissionMiddleware {
+	return &projectPermissionMiddleware{
+		projectPermissionService: projectPermissionService,
+	}
+}
+
+func (p *projectPermissionMiddleware) Require(permission models.ProjectPermission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userClaims := tokenutils.GetProfileOnEchoContext(c).(*models.UserCustomClaims)
+
+			_, err := p.projectPermissionService.Authorize(c.Request().Context(), c.Param("projectId"), userClaims.ID, permission)
+			if err != nil {
+				return err.ToEchoError()
+			}
+
+			return next(c)
+		}
+	}
+}